@@ -0,0 +1,110 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// CreateAPIToken creates a new API token in the project
+func (c *Client) CreateAPIToken(
+	ctx context.Context,
+	projectID uint,
+	req *types.CreateAPIToken,
+) (*types.APIToken, error) {
+	resp := &types.APIToken{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListAPITokens lists the API tokens in the project
+func (c *Client) ListAPITokens(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListAPITokensResponse, error) {
+	resp := &types.ListAPITokensResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RevokeAPIToken revokes the API token with the given id
+func (c *Client) RevokeAPIToken(
+	ctx context.Context,
+	projectID uint,
+	tokenID string,
+) (*types.APITokenMeta, error) {
+	resp := &types.APITokenMeta{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token/%s/revoke",
+			projectID,
+			tokenID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ListStaleAPITokens lists the API tokens in the project which have not been used recently
+func (c *Client) ListStaleAPITokens(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListStaleAPITokensRequest,
+) (*types.ListAPITokensResponse, error) {
+	resp := &types.ListAPITokensResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token/stale",
+			projectID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
+
+// RotateAPIToken issues a new secret for the API token with the given id
+func (c *Client) RotateAPIToken(
+	ctx context.Context,
+	projectID uint,
+	tokenID string,
+	req *types.RotateAPITokenRequest,
+) (*types.APIToken, error) {
+	resp := &types.APIToken{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/api_token/%s/rotate",
+			projectID,
+			tokenID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -0,0 +1,107 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// ListAuditEvents lists the audit events of a project which match the given filters
+func (c *Client) ListAuditEvents(
+	ctx context.Context,
+	projectID uint,
+	req *types.ListAuditEventsRequest,
+) (*types.ListAuditEventsResponse, error) {
+	resp := &types.ListAuditEventsResponse{}
+
+	// the query is built here since the schema encoder does not support the page and time fields
+	vals := url.Values{}
+
+	if req.PaginationRequest != nil && req.Page > 0 {
+		vals.Set("page", fmt.Sprintf("%d", req.Page))
+	}
+
+	if req.Actor != "" {
+		vals.Set("actor", req.Actor)
+	}
+
+	if req.ClusterID != 0 {
+		vals.Set("cluster_id", fmt.Sprintf("%d", req.ClusterID))
+	}
+
+	if req.Namespace != "" {
+		vals.Set("namespace", req.Namespace)
+	}
+
+	if req.Verb != "" {
+		vals.Set("verb", string(req.Verb))
+	}
+
+	if req.Outcome != "" {
+		vals.Set("outcome", string(req.Outcome))
+	}
+
+	if req.Resource != "" {
+		vals.Set("resource", req.Resource)
+	}
+
+	if req.Since != nil {
+		vals.Set("since", req.Since.Format(time.RFC3339))
+	}
+
+	if req.Until != nil {
+		vals.Set("until", req.Until.Format(time.RFC3339))
+	}
+
+	path := fmt.Sprintf("/projects/%d/audit_events", projectID)
+
+	if len(vals) > 0 {
+		path = fmt.Sprintf("%s?%s", path, vals.Encode())
+	}
+
+	err := c.getRequest(path, nil, resp)
+
+	return resp, err
+}
+
+// ListAuditSinks lists the audit sinks of a project
+func (c *Client) ListAuditSinks(
+	ctx context.Context,
+	projectID uint,
+) (*types.ListAuditSinksResponse, error) {
+	resp := &types.ListAuditSinksResponse{}
+
+	err := c.getRequest(
+		fmt.Sprintf(
+			"/projects/%d/audit_sinks",
+			projectID,
+		),
+		nil,
+		resp,
+	)
+
+	return resp, err
+}
+
+// ReplayAuditSink sends the audit events created since the given time to an audit sink again
+func (c *Client) ReplayAuditSink(
+	ctx context.Context,
+	projectID, sinkID uint,
+	req *types.ReplayAuditSinkRequest,
+) (*types.AuditSink, error) {
+	resp := &types.AuditSink{}
+
+	err := c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/audit_sinks/%d/replay",
+			projectID, sinkID,
+		),
+		req,
+		resp,
+	)
+
+	return resp, err
+}
@@ -108,6 +108,24 @@ func (c *Client) UpgradeRelease(
 	)
 }
 
+// RollbackRelease rolls back a release to a previous revision
+func (c *Client) RollbackRelease(
+	ctx context.Context,
+	projID, clusterID uint,
+	namespace, name string,
+	req *types.RollbackReleaseRequest,
+) error {
+	return c.postRequest(
+		fmt.Sprintf(
+			"/projects/%d/clusters/%d/namespaces/%s/releases/%s/0/rollback",
+			projID, clusterID,
+			namespace, name,
+		),
+		req,
+		nil,
+	)
+}
+
 // DeleteRelease deletes a Porter release
 func (c *Client) DeleteRelease(
 	ctx context.Context,
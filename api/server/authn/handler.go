@@ -10,6 +10,7 @@ import (
 	"github.com/gorilla/sessions"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
 	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/models"
@@ -149,6 +150,15 @@ func (authn *AuthN) verifyTokenWithNext(w http.ResponseWriter, r *http.Request,
 			return
 		}
 
+		// ensure that the secret is the current secret of the token, or the secret before the
+		// last rotation while the overlap window has not passed
+		if !apiToken.IsValidSecret(tok.Secret) {
+			authn.sendForbiddenError(fmt.Errorf("token with id %s not valid", tok.TokenID), w, r)
+			return
+		}
+
+		authn.recordAPITokenUsage(r, apiToken)
+
 		authn.nextWithAPIToken(w, r, apiToken)
 	} else {
 		// otherwise we just use nextWithUser using the `iby` field for the token
@@ -156,6 +166,31 @@ func (authn *AuthN) verifyTokenWithNext(w http.ResponseWriter, r *http.Request,
 	}
 }
 
+// apiTokenUsageInterval is the minimum interval between writes of the token usage, so that
+// tokens which are used for many requests in a row do not write to the database on every request
+const apiTokenUsageInterval = time.Minute
+
+// recordAPITokenUsage stores the time, client IP and user agent of the last use of the token.
+// Recording the usage is best-effort, so errors are logged but do not fail the request.
+func (authn *AuthN) recordAPITokenUsage(r *http.Request, tok *models.APIToken) {
+	now := time.Now()
+	ip := requestutils.GetClientIP(r, authn.config.ServerConf.TrustedProxies)
+	userAgent := r.UserAgent()
+
+	if tok.LastUsedAt != nil && now.Sub(*tok.LastUsedAt) < apiTokenUsageInterval &&
+		tok.LastUsedIP == ip && tok.LastUsedUserAgent == userAgent {
+		return
+	}
+
+	tok.LastUsedAt = &now
+	tok.LastUsedIP = ip
+	tok.LastUsedUserAgent = userAgent
+
+	if err := authn.config.Repo.APIToken().UpdateAPITokenUsage(tok); err != nil {
+		authn.config.Logger.Warn().Msgf("could not record usage of token with id %s: %v", tok.UniqueID, err)
+	}
+}
+
 // nextWithAPIToken sets the token in context
 func (authn *AuthN) nextWithAPIToken(w http.ResponseWriter, r *http.Request, tok *models.APIToken) {
 	ctx := r.Context()
@@ -5,13 +5,16 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/authn"
 	"github.com/porter-dev/porter/api/server/shared/apitest"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -189,6 +192,128 @@ func TestAuthBadSessionUserIDType(t *testing.T) {
 	assertForbiddenError(t, next, rr)
 }
 
+func TestAPITokenCurrentSecret(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	apiToken := createRotatedAPIToken(t, config, time.Now().Add(time.Hour))
+
+	rr := serveWithAPIToken(t, config, handler, apiToken, "new-secret")
+
+	assertNextHandlerCalled(t, next, rr, getAPITokenUser(apiToken))
+}
+
+func TestAPITokenPreviousSecretWithinOverlap(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	apiToken := createRotatedAPIToken(t, config, time.Now().Add(time.Hour))
+
+	rr := serveWithAPIToken(t, config, handler, apiToken, "old-secret")
+
+	assertNextHandlerCalled(t, next, rr, getAPITokenUser(apiToken))
+
+	// the usage of the token is recorded
+	readToken, err := config.Repo.APIToken().ReadAPIToken(apiToken.ProjectID, apiToken.UniqueID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NotNil(t, readToken.LastUsedAt, "last used time should be set")
+	assert.Equal(t, "192.0.2.1", readToken.LastUsedIP, "last used ip should be set")
+}
+
+func TestAPITokenPreviousSecretAfterOverlap(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	apiToken := createRotatedAPIToken(t, config, time.Now().Add(-time.Minute))
+
+	rr := serveWithAPIToken(t, config, handler, apiToken, "old-secret")
+
+	assertForbiddenError(t, next, rr)
+}
+
+func TestAPITokenWrongSecret(t *testing.T) {
+	config, handler, next := loadHandlers(t)
+
+	apiToken := createRotatedAPIToken(t, config, time.Now().Add(time.Hour))
+
+	rr := serveWithAPIToken(t, config, handler, apiToken, "wrong-secret")
+
+	assertForbiddenError(t, next, rr)
+}
+
+// createRotatedAPIToken stores a token whose secret was rotated from "old-secret" to "new-secret",
+// with the old secret being valid until previousExpiry
+func createRotatedAPIToken(t *testing.T, config *config.Config, previousExpiry time.Time) *models.APIToken {
+	secretKey, err := bcrypt.GenerateFromPassword([]byte("new-secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	previousSecretKey, err := bcrypt.GenerateFromPassword([]byte("old-secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expiry := time.Now().Add(24 * time.Hour)
+
+	apiToken, err := config.Repo.APIToken().CreateAPIToken(&models.APIToken{
+		UniqueID:             "test-token-id",
+		ProjectID:            1,
+		CreatedByUserID:      1,
+		Expiry:               &expiry,
+		Name:                 "ci",
+		SecretKey:            secretKey,
+		PreviousSecretKey:    previousSecretKey,
+		PreviousSecretExpiry: &previousExpiry,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return apiToken
+}
+
+func serveWithAPIToken(
+	t *testing.T,
+	config *config.Config,
+	handler http.Handler,
+	apiToken *models.APIToken,
+	secret string,
+) *httptest.ResponseRecorder {
+	req, err := http.NewRequest("GET", "/auth-endpoint", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.RemoteAddr = "192.0.2.1:4321"
+
+	tok, err := token.GetStoredTokenForAPI(apiToken.CreatedByUserID, apiToken.ProjectID, apiToken.UniqueID, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tokenStr, err := tok.EncodeToken(config.TokenConf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", tokenStr))
+
+	rr := httptest.NewRecorder()
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+// getAPITokenUser returns the service account user which is attached to requests made with the token
+func getAPITokenUser(apiToken *models.APIToken) *models.User {
+	return &models.User{
+		Email:         fmt.Sprintf("%s-%d", apiToken.Name, apiToken.ProjectID),
+		EmailVerified: true,
+	}
+}
+
 type testHandler struct {
 	WasCalled bool
 	User      *models.User
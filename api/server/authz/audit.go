@@ -0,0 +1,236 @@
+package authz
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+const (
+	// maxAuditBodySize is the maximum size of a request body which is read to build the
+	// request summary of an audit event
+	maxAuditBodySize = 64 * 1024
+
+	// maxAuditSummaryValueLength is the maximum length of a value in the request summary
+	maxAuditSummaryValueLength = 64
+)
+
+// auditScopeOrder lists the scopes from the most to the least specific, to find the target
+// resource of a request
+var auditScopeOrder = []types.PermissionScope{
+	types.OperationScope,
+	types.ReleaseScope,
+	types.StackScope,
+	types.NamespaceScope,
+	types.PreviewEnvironmentScope,
+	types.InfraScope,
+	types.GitlabIntegrationScope,
+	types.GitInstallationScope,
+	types.HelmRepoScope,
+	types.RegistryScope,
+	types.InviteScope,
+	types.APIContractRevisionScope,
+	types.ClusterScope,
+	types.SettingsScope,
+	types.ProjectScope,
+}
+
+// sensitiveAuditKeys are substrings of request fields whose values are never stored in the
+// request summary
+var sensitiveAuditKeys = []string{"secret", "token", "password", "key", "cert", "credential", "env", "value", "data"}
+
+// isAuditedVerb returns true if calls with the verb are recorded in the audit log
+func isAuditedVerb(verb types.APIVerb) bool {
+	return verb == types.APIVerbCreate || verb == types.APIVerbUpdate || verb == types.APIVerbDelete
+}
+
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (rw *auditResponseWriter) WriteHeader(code int) {
+	rw.statusCode = code
+	rw.ResponseWriter.WriteHeader(code)
+}
+
+func (rw *auditResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := rw.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("ResponseWriter Interface does not support hijacking")
+	}
+	return h.Hijack()
+}
+
+func (rw *auditResponseWriter) Flush() {
+	if f, ok := rw.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// auditRecorder builds the audit event of a single request
+type auditRecorder struct {
+	config       *config.Config
+	endpointMeta types.APIRequestMetadata
+	reqScopes    map[types.PermissionScope]*types.RequestAction
+	summary      string
+}
+
+// newAuditRecorder reads the request body to build the request summary, and restores the body
+// so that it can still be read by the handler
+func newAuditRecorder(
+	config *config.Config,
+	r *http.Request,
+	endpointMeta types.APIRequestMetadata,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) *auditRecorder {
+	recorder := &auditRecorder{
+		config:       config,
+		endpointMeta: endpointMeta,
+		reqScopes:    reqScopes,
+	}
+
+	if r.Body != nil {
+		body, err := io.ReadAll(io.LimitReader(r.Body, maxAuditBodySize))
+
+		if err == nil {
+			recorder.summary = getAuditRequestSummary(body)
+		}
+
+		r.Body = io.NopCloser(io.MultiReader(bytes.NewReader(body), r.Body))
+	}
+
+	return recorder
+}
+
+// record stores the audit event with the given status code. Recording the event is
+// best-effort, so errors are logged but are not returned to the client.
+func (a *auditRecorder) record(r *http.Request, statusCode int) {
+	event := &models.AuditEvent{
+		ClientIP:       requestutils.GetClientIP(r, a.config.ServerConf.TrustedProxies),
+		Verb:           string(a.endpointMeta.Verb),
+		Method:         string(a.endpointMeta.Method),
+		Path:           r.URL.Path,
+		RequestSummary: a.summary,
+		StatusCode:     statusCode,
+	}
+
+	if project, ok := a.reqScopes[types.ProjectScope]; ok {
+		event.ProjectID = project.Resource.UInt
+	}
+
+	if a.endpointMeta.Path != nil {
+		event.Endpoint = a.endpointMeta.Path.RelativePath
+	}
+
+	if apiToken, ok := r.Context().Value("api_token").(*models.APIToken); ok {
+		event.ActorKind = string(types.AuditActorAPIToken)
+		event.UserID = apiToken.CreatedByUserID
+		event.ActorName = apiToken.Name
+		event.APITokenID = apiToken.UniqueID
+	} else if user, ok := r.Context().Value(types.UserScope).(*models.User); ok {
+		event.ActorKind = string(types.AuditActorUser)
+		event.UserID = user.ID
+		event.ActorName = user.Email
+	}
+
+	if cluster, ok := a.reqScopes[types.ClusterScope]; ok {
+		event.ClusterID = cluster.Resource.UInt
+	}
+
+	if namespace, ok := a.reqScopes[types.NamespaceScope]; ok {
+		event.Namespace = namespace.Resource.Name
+	}
+
+	for _, scope := range auditScopeOrder {
+		if action, ok := a.reqScopes[scope]; ok {
+			event.ResourceScope = string(scope)
+
+			if action.Resource.Name != "" {
+				event.Resource = action.Resource.Name
+			} else if action.Resource.UInt != 0 {
+				event.Resource = fmt.Sprintf("%d", action.Resource.UInt)
+			}
+
+			break
+		}
+	}
+
+	switch {
+	case statusCode == http.StatusForbidden:
+		event.Outcome = string(types.AuditOutcomeForbidden)
+	case statusCode >= 400:
+		event.Outcome = string(types.AuditOutcomeFailure)
+	default:
+		event.Outcome = string(types.AuditOutcomeSuccess)
+	}
+
+	if _, err := a.config.Repo.AuditEvent().CreateAuditEvent(event); err != nil {
+		a.config.Logger.Warn().Msgf("could not record audit event for %s: %v", r.URL.Path, err)
+	}
+}
+
+// getAuditRequestSummary summarizes the top-level fields of a JSON request body. Nested objects
+// and arrays, long values and fields which may hold secrets are not included.
+func getAuditRequestSummary(body []byte) string {
+	fields := make(map[string]interface{})
+
+	if err := json.Unmarshal(body, &fields); err != nil || len(fields) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(fields))
+
+	for key := range fields {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	parts := make([]string, 0, len(keys))
+
+	for _, key := range keys {
+		parts = append(parts, fmt.Sprintf("%s=%s", key, getAuditSummaryValue(key, fields[key])))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func getAuditSummaryValue(key string, val interface{}) string {
+	lowerKey := strings.ToLower(key)
+
+	for _, sensitive := range sensitiveAuditKeys {
+		if strings.Contains(lowerKey, sensitive) {
+			return "[redacted]"
+		}
+	}
+
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return "{...}"
+	case []interface{}:
+		return "[...]"
+	case nil:
+		return "null"
+	default:
+		str := fmt.Sprintf("%v", v)
+
+		if len(str) > maxAuditSummaryValueLength {
+			return str[:maxAuditSummaryValueLength] + "..."
+		}
+
+		return str
+	}
+}
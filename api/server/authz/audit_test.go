@@ -0,0 +1,81 @@
+package authz
+
+import (
+	"fmt"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/stretchr/testify/assert"
+)
+
+type auditSummaryTest struct {
+	description string
+	body        string
+	expSummary  string
+}
+
+var auditSummaryTests = []auditSummaryTest{
+	{
+		description: "fields are sorted by key",
+		body:        `{"name":"web","replicas":2,"enabled":true}`,
+		expSummary:  "enabled=true name=web replicas=2",
+	},
+	{
+		description: "sensitive fields are redacted",
+		body:        `{"name":"ci","client_secret":"abc","Password":"hunter2","env":{"A":"B"},"token_id":"tok"}`,
+		expSummary:  "Password=[redacted] client_secret=[redacted] env=[redacted] name=ci token_id=[redacted]",
+	},
+	{
+		description: "nested objects, arrays and null values are not included",
+		body:        `{"config":{"a":1},"tags":["a","b"],"description":null}`,
+		expSummary:  "config={...} description=null tags=[...]",
+	},
+	{
+		description: "long values are truncated",
+		body:        fmt.Sprintf(`{"name":"%s"}`, strings.Repeat("a", maxAuditSummaryValueLength+10)),
+		expSummary:  fmt.Sprintf("name=%s...", strings.Repeat("a", maxAuditSummaryValueLength)),
+	},
+	{
+		description: "body which is not a JSON object",
+		body:        `["a","b"]`,
+		expSummary:  "",
+	},
+	{
+		description: "empty body",
+		body:        "",
+		expSummary:  "",
+	},
+}
+
+func TestGetAuditRequestSummary(t *testing.T) {
+	for _, test := range auditSummaryTests {
+		assert.Equal(
+			t,
+			test.expSummary,
+			getAuditRequestSummary([]byte(test.body)),
+			"[ %s ]: summary not equal",
+			test.description,
+		)
+	}
+}
+
+func TestNewAuditRecorderRestoresBody(t *testing.T) {
+	body := `{"name":"web","secret_key":"abc"}`
+	req := httptest.NewRequest("POST", "/api/projects/1/api_token", strings.NewReader(body))
+
+	recorder := newAuditRecorder(&config.Config{}, req, types.APIRequestMetadata{Verb: types.APIVerbCreate}, nil)
+
+	assert.Equal(t, "name=web secret_key=[redacted]", recorder.summary, "summary not equal")
+
+	// the handler can still read the full body
+	readBody, err := io.ReadAll(req.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, body, string(readBody), "request body not equal")
+}
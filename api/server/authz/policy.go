@@ -47,6 +47,14 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// mutating calls are recorded in the audit log of the project, including calls which are
+	// forbidden by the policy
+	var recorder *auditRecorder
+
+	if isAuditedVerb(h.endpointMeta.Verb) {
+		recorder = newAuditRecorder(h.config, r, h.endpointMeta, reqScopes)
+	}
+
 	policyLoaderOpts := &policy.PolicyLoaderOpts{}
 
 	// first check if an api token exists in context
@@ -74,10 +82,20 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// namespaces and releases may be matched by the label selectors of the policy
+	if reqErr := h.loadResourceLabels(r, policyDocs, reqScopes); reqErr != nil {
+		apierrors.HandleAPIError(h.config.Logger, h.config.Alerter, w, r, reqErr, true)
+		return
+	}
+
 	// validate that the policy permits the action
 	hasAccess := policy.HasScopeAccess(policyDocs, reqScopes)
 
 	if !hasAccess {
+		if recorder != nil {
+			recorder.record(r, http.StatusForbidden)
+		}
+
 		apierrors.HandleAPIError(
 			h.config.Logger,
 			h.config.Alerter,
@@ -93,7 +111,17 @@ func (h *PolicyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	// add the set of resource ids to the request context
 	ctx := NewRequestScopeCtx(r.Context(), reqScopes)
 	r = r.Clone(ctx)
-	h.next.ServeHTTP(w, r)
+
+	if recorder == nil {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+
+	rw := &auditResponseWriter{w, http.StatusOK}
+
+	h.next.ServeHTTP(rw, r)
+
+	recorder.record(r, rw.statusCode)
 }
 
 func NewRequestScopeCtx(ctx context.Context, reqScopes map[types.PermissionScope]*types.RequestAction) context.Context {
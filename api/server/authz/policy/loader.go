@@ -70,6 +70,22 @@ func (b *RepoPolicyDocumentLoader) LoadPolicyDocuments(
 			return types.DeveloperPolicy, nil
 		case types.RoleViewer:
 			return types.ViewerPolicy, nil
+		case types.RoleCustom:
+			if role.PolicyUID == "" || b.policyRepo == nil {
+				return nil, apierrors.NewErrForbidden(
+					fmt.Errorf("custom role has no policy for user %d, project %d", userID, projectID),
+				)
+			}
+
+			apiPolicy, reqErr := GetAPIPolicyFromUID(b.policyRepo, projectID, role.PolicyUID)
+
+			if reqErr != nil {
+				return nil, apierrors.NewErrForbidden(
+					fmt.Errorf("policy %s of custom role could not be loaded for user %d, project %d: %s", role.PolicyUID, userID, projectID, reqErr.Error()),
+				)
+			}
+
+			return apiPolicy.Policy, nil
 		default:
 			return nil, apierrors.NewErrForbidden(
 				fmt.Errorf("%s role not supported for user %d, project %d", string(role.Kind), userID, projectID),
@@ -1,6 +1,7 @@
 package policy_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -39,11 +40,11 @@ var basicLoaderTests = []basicLoaderTest{
 		expPolicy:   types.ViewerPolicy,
 	},
 	{
-		description:      "should not load custom policy for basic loader",
+		description:      "should not load custom role without a policy",
 		roleKind:         types.RoleCustom,
 		expErr:           true,
 		expErrStatusCode: http.StatusForbidden,
-		expErrString:     "custom role not supported for user 1, project 1",
+		expErrString:     "custom role has no policy for user 1, project 1",
 	},
 }
 
@@ -198,3 +199,105 @@ func TestErrorCannotQuery(t *testing.T) {
 		"status is not status internal",
 	)
 }
+
+func TestCustomRolePolicyDocumentLoader(t *testing.T) {
+	assert := assert.New(t)
+
+	customPolicy := []*types.PolicyDocument{
+		{
+			Scope: types.ProjectScope,
+			Verbs: types.ReadVerbGroup(),
+			Children: map[types.PermissionScope]*types.PolicyDocument{
+				types.ClusterScope: {
+					Scope: types.ClusterScope,
+					Verbs: types.ReadWriteVerbGroup(),
+				},
+			},
+		},
+	}
+
+	policyBytes, err := json.Marshal(customPolicy)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tests := []struct {
+		description      string
+		policyUID        string
+		expErrString     string
+		expErrStatusCode int
+	}{
+		{
+			description: "should load the policy of the custom role",
+			policyUID:   "custom-policy",
+		},
+		{
+			description:      "should not load custom role with a missing policy",
+			policyUID:        "deleted-policy",
+			expErrStatusCode: http.StatusForbidden,
+			expErrString:     "policy deleted-policy of custom role could not be loaded for user 1, project 1: policy not found in project",
+		},
+	}
+
+	for _, tc := range tests {
+		projRepo := test.NewProjectRepository(true)
+		policyRepo := test.NewPolicyRepository(true)
+		loader := policy.NewBasicPolicyDocumentLoader(projRepo, policyRepo)
+
+		project, err := projRepo.CreateProject(&models.Project{
+			Name: "test-project",
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		_, err = policyRepo.CreatePolicy(&models.Policy{
+			UniqueID:    "custom-policy",
+			ProjectID:   project.ID,
+			Name:        "cluster-operator",
+			PolicyBytes: policyBytes,
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		_, err = projRepo.CreateProjectRole(project, &models.Role{
+			Role: types.Role{
+				UserID:    1,
+				ProjectID: project.ID,
+				Kind:      types.RoleCustom,
+				PolicyUID: tc.policyUID,
+			},
+		})
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		docs, reqErr := loader.LoadPolicyDocuments(&policy.PolicyLoaderOpts{
+			ProjectID: project.ID,
+			UserID:    1,
+		})
+
+		if tc.expErrStatusCode != 0 {
+			if reqErr == nil {
+				t.Errorf("[ %s ]: expected an error", tc.description)
+				continue
+			}
+
+			assert.Equal(tc.expErrString, reqErr.Error(), "[ %s ]: readable string not equal", tc.description)
+			assert.Equal(tc.expErrStatusCode, reqErr.GetStatusCode(), "[ %s ]: status code not equal", tc.description)
+
+			continue
+		}
+
+		if reqErr != nil {
+			t.Errorf("[ %s ]: unexpected error: %v", tc.description, reqErr)
+			continue
+		}
+
+		if diff := deep.Equal(customPolicy, docs); diff != nil {
+			t.Errorf("[ %s ]: policy documents not equal:", tc.description)
+			t.Error(diff)
+		}
+	}
+}
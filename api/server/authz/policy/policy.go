@@ -1,9 +1,20 @@
 package policy
 
 import (
+	"path"
+	"strings"
+
 	"github.com/porter-dev/porter/api/types"
+	"k8s.io/apimachinery/pkg/labels"
 )
 
+// labelSelectorScopes are the scopes whose resources can be matched by a label selector, since
+// these are the resources that have Kubernetes labels
+var labelSelectorScopes = map[types.PermissionScope]bool{
+	types.NamespaceScope: true,
+	types.ReleaseScope:   true,
+}
+
 // HasScopeAccess checks that a user can perform an action (`verb`) against a specific
 // resource (`resource+scope`) according to a `policy`.
 func HasScopeAccess(
@@ -29,14 +40,22 @@ func HasScopeAccess(
 		for matchScope, matchDoc := range matchDocs {
 			// for the matching scope, make sure it matches the allowed resources if the
 			// resource list is explicitly set
-			if len(matchDoc.Resources) > 0 && reqScopes[matchScope].Verb != types.APIVerbList {
-				if !isResourceAllowed(matchDoc, reqScopes[matchScope].Resource) {
+			if (len(matchDoc.Resources) > 0 || matchDoc.ResourceSelector != "") && reqScopes[matchScope].Verb != types.APIVerbList {
+				if !isResourceAllowed(matchDoc, reqScopes[matchScope]) {
 					isValid = false
 				}
 			}
 
+			verb := reqScopes[matchScope].Verb
+
+			// the scopes above the requested resource only need to be readable when the policy opts in,
+			// so that the evaluation of existing policies is unchanged
+			if policyDoc.ReadOnlyParents && isParentOfRequestedScope(matchScope, reqScopes) {
+				verb = types.APIVerbGet
+			}
+
 			// for the matching scope, make sure it matches the allowed verbs
-			if !isVerbAllowed(matchDoc, reqScopes[matchScope].Verb) {
+			if !isVerbAllowed(matchDoc, verb) {
 				isValid = false
 			}
 		}
@@ -49,20 +68,162 @@ func HasScopeAccess(
 	return false
 }
 
+// IsValidPolicy checks that every document of the policy follows the scope heirarchy, and that its
+// resource name patterns and label selectors can be parsed
+func IsValidPolicy(policy []*types.PolicyDocument) bool {
+	for _, policyDoc := range policy {
+		isValid, _ := populateAndVerifyPolicyDocument(
+			policyDoc,
+			types.ScopeHeirarchy,
+			types.ProjectScope,
+			types.ReadWriteVerbGroup(),
+			map[types.PermissionScope]*types.RequestAction{},
+			nil,
+		)
+
+		if !isValid || !hasValidResourceMatchers(policyDoc) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasResourceSelector returns true if a document of the policy matches the resources of the scope
+// by a label selector, in which case the labels of the requested resource must be loaded
+func HasResourceSelector(policy []*types.PolicyDocument, scope types.PermissionScope) bool {
+	for _, policyDoc := range policy {
+		if hasResourceSelector(policyDoc, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func hasResourceSelector(policyDoc *types.PolicyDocument, scope types.PermissionScope) bool {
+	if policyDoc == nil {
+		return false
+	}
+
+	if policyDoc.Scope == scope && policyDoc.ResourceSelector != "" {
+		return true
+	}
+
+	for _, child := range policyDoc.Children {
+		if hasResourceSelector(child, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasValidResourceMatchers checks that the resource name patterns and label selectors of the
+// document and its children can be parsed, and that label selectors are only set on scopes
+// whose resources have labels
+func hasValidResourceMatchers(policyDoc *types.PolicyDocument) bool {
+	if policyDoc == nil {
+		return true
+	}
+
+	for _, resource := range policyDoc.Resources {
+		if isResourceNamePattern(resource.Name) {
+			if _, err := path.Match(resource.Name, ""); err != nil {
+				return false
+			}
+		}
+	}
+
+	if policyDoc.ResourceSelector != "" {
+		if !labelSelectorScopes[policyDoc.Scope] {
+			return false
+		}
+
+		if _, err := labels.Parse(policyDoc.ResourceSelector); err != nil {
+			return false
+		}
+	}
+
+	for _, child := range policyDoc.Children {
+		if !hasValidResourceMatchers(child) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isParentOfRequestedScope(
+	scope types.PermissionScope,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) bool {
+	subTree := findSubTree(types.ScopeHeirarchy, scope)
+
+	for reqScope := range reqScopes {
+		if reqScope != scope && findSubTree(subTree, reqScope) != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findSubTree returns the tree of scopes below the given scope, or nil if the scope is not part
+// of the tree
+func findSubTree(tree types.ScopeTree, scope types.PermissionScope) types.ScopeTree {
+	for currScope, subTree := range tree {
+		if currScope == scope {
+			return subTree
+		}
+
+		if res := findSubTree(subTree, scope); res != nil {
+			return res
+		}
+	}
+
+	return nil
+}
+
+// isResourceAllowed checks that the requested resource is one of the resources of the document,
+// matches one of the name patterns of the document, or matches its label selector
 func isResourceAllowed(
 	matchDoc *types.PolicyDocument,
-	resource types.NameOrUInt,
+	action *types.RequestAction,
 ) bool {
-	valid := false
-
 	for _, allowedResource := range matchDoc.Resources {
-		if allowedResource == resource {
-			valid = true
-			break
+		if allowedResource == action.Resource || isResourceNameMatch(allowedResource.Name, action.Resource.Name) {
+			return true
 		}
 	}
 
-	return valid
+	// resources which do not exist have nil labels, and are never matched by a label selector
+	if matchDoc.ResourceSelector != "" && action.Labels != nil {
+		selector, err := labels.Parse(matchDoc.ResourceSelector)
+
+		// an invalid selector never matches, rather than matching every resource
+		if err == nil && selector.Matches(labels.Set(action.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isResourceNamePattern returns true if the resource name is a glob pattern
+func isResourceNamePattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// isResourceNameMatch returns true if the name matches the glob pattern, such as "team-a-*"
+func isResourceNameMatch(pattern, name string) bool {
+	if name == "" || !isResourceNamePattern(pattern) {
+		return false
+	}
+
+	matched, err := path.Match(pattern, name)
+
+	return err == nil && matched
 }
 
 func isVerbAllowed(
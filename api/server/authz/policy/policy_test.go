@@ -164,6 +164,270 @@ var hasScopeAccessTests = []testHasScopeAccess{
 		},
 		expRes: false,
 	},
+	{
+		description: "release write policy can update a release in the namespace",
+		policy:      testPolicyReleaseWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 4,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "staging",
+				},
+			},
+			types.ReleaseScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "web",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "release write policy cannot update a release in another namespace",
+		policy:      testPolicyReleaseWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 4,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "production",
+				},
+			},
+			types.ReleaseScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					Name: "web",
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "release write policy cannot create releases in the namespace",
+		policy:      testPolicyReleaseWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbCreate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbCreate,
+				Resource: types.NameOrUInt{
+					UInt: 4,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbCreate,
+				Resource: types.NameOrUInt{
+					Name: "staging",
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "release write policy can list releases in the namespace",
+		policy:      testPolicyReleaseWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					UInt: 4,
+				},
+			},
+			types.NamespaceScope: {
+				Verb: types.APIVerbList,
+				Resource: types.NameOrUInt{
+					Name: "staging",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "release write policy cannot update the cluster",
+		policy:      testPolicyReleaseWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+			types.ClusterScope: {
+				Verb: types.APIVerbUpdate,
+				Resource: types.NameOrUInt{
+					UInt: 4,
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "release write policy cannot delete the project",
+		policy:      testPolicyReleaseWrite,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.ProjectScope: {
+				Verb: types.APIVerbDelete,
+				Resource: types.NameOrUInt{
+					UInt: 1,
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "namespace glob policy can update a release in a matching namespace",
+		policy:      testPolicyNamespaceGlob,
+		reqScopes:   getReleaseRequestScopes(types.APIVerbUpdate, "team-a-staging", "web", nil, nil),
+		expRes:      true,
+	},
+	{
+		description: "namespace glob policy cannot update a release in another namespace",
+		policy:      testPolicyNamespaceGlob,
+		reqScopes:   getReleaseRequestScopes(types.APIVerbUpdate, "team-b-staging", "web", nil, nil),
+		expRes:      false,
+	},
+	{
+		description: "namespace glob policy does not match the prefix on its own",
+		policy:      testPolicyNamespaceGlob,
+		reqScopes:   getReleaseRequestScopes(types.APIVerbUpdate, "team-a", "web", nil, nil),
+		expRes:      false,
+	},
+	{
+		description: "namespace glob policy can create a matching namespace",
+		policy:      testPolicyNamespaceGlob,
+		reqScopes: map[types.PermissionScope]*types.RequestAction{
+			types.NamespaceScope: {
+				Verb: types.APIVerbCreate,
+				Resource: types.NameOrUInt{
+					Name: "team-a-prod",
+				},
+			},
+		},
+		expRes: true,
+	},
+	{
+		description: "label selector policy can update a release with matching labels",
+		policy:      testPolicyLabelSelector,
+		reqScopes: getReleaseRequestScopes(
+			types.APIVerbUpdate,
+			"payments",
+			"web",
+			map[string]string{"env": "prod"},
+			map[string]string{"tier": "backend"},
+		),
+		expRes: true,
+	},
+	{
+		description: "label selector policy cannot update a release with other labels",
+		policy:      testPolicyLabelSelector,
+		reqScopes: getReleaseRequestScopes(
+			types.APIVerbUpdate,
+			"payments",
+			"web",
+			map[string]string{"env": "prod"},
+			map[string]string{"tier": "frontend"},
+		),
+		expRes: false,
+	},
+	{
+		description: "label selector policy can update a release matching the name pattern",
+		policy:      testPolicyLabelSelector,
+		reqScopes: getReleaseRequestScopes(
+			types.APIVerbUpdate,
+			"payments",
+			"api-1",
+			map[string]string{"env": "prod"},
+			nil,
+		),
+		expRes: true,
+	},
+	{
+		description: "label selector policy cannot access a namespace with other labels",
+		policy:      testPolicyLabelSelector,
+		reqScopes: getReleaseRequestScopes(
+			types.APIVerbUpdate,
+			"payments",
+			"web",
+			map[string]string{"env": "staging"},
+			map[string]string{"tier": "backend"},
+		),
+		expRes: false,
+	},
+	{
+		description: "label selector policy cannot access a namespace without labels",
+		policy:      testPolicyLabelSelector,
+		reqScopes:   getReleaseRequestScopes(types.APIVerbGet, "payments", "web", map[string]string{}, nil),
+		expRes:      false,
+	},
+	{
+		description: "negative label selector policy can access a namespace without labels",
+		policy:      testPolicyNegativeLabelSelector,
+		reqScopes:   getNamespaceRequestScopes(types.APIVerbGet, "payments", map[string]string{}),
+		expRes:      true,
+	},
+	{
+		description: "negative label selector policy cannot access a namespace which does not exist",
+		policy:      testPolicyNegativeLabelSelector,
+		reqScopes:   getNamespaceRequestScopes(types.APIVerbGet, "payments", nil),
+		expRes:      false,
+	},
+}
+
+func TestHasScopeAccessWithoutReadOnlyParents(t *testing.T) {
+	assert := assert.New(t)
+
+	// the same policy without read_only_parents requires every scope of the request to grant the verb
+	docs := []*types.PolicyDocument{
+		{
+			Scope:    types.ProjectScope,
+			Verbs:    testPolicyReleaseWrite[0].Verbs,
+			Children: testPolicyReleaseWrite[0].Children,
+		},
+	}
+
+	assert.False(
+		policy.HasScopeAccess(docs, getReleaseRequestScopes(types.APIVerbUpdate, "staging", "web", nil, nil)),
+		"policy without read_only_parents cannot update a release through readable parents",
+	)
+
+	assert.True(
+		policy.HasScopeAccess(docs, getReleaseRequestScopes(types.APIVerbGet, "staging", "web", nil, nil)),
+		"policy without read_only_parents can read a release",
+	)
 }
 
 func TestHasScopeAccess(t *testing.T) {
@@ -179,6 +443,77 @@ func TestHasScopeAccess(t *testing.T) {
 	}
 }
 
+type testIsValidPolicy struct {
+	description string
+	policy      []*types.PolicyDocument
+	expRes      bool
+}
+
+var isValidPolicyTests = []testIsValidPolicy{
+	{
+		description: "namespace glob policy is valid",
+		policy:      testPolicyNamespaceGlob,
+		expRes:      true,
+	},
+	{
+		description: "label selector policy is valid",
+		policy:      testPolicyLabelSelector,
+		expRes:      true,
+	},
+	{
+		description: "malformed resource name pattern is invalid",
+		policy: getNamespacePolicy(&types.PolicyDocument{
+			Scope: types.NamespaceScope,
+			Verbs: types.ReadVerbGroup(),
+			Resources: []types.NameOrUInt{
+				{
+					Name: "team-[a",
+				},
+			},
+		}),
+		expRes: false,
+	},
+	{
+		description: "malformed label selector is invalid",
+		policy: getNamespacePolicy(&types.PolicyDocument{
+			Scope:            types.NamespaceScope,
+			Verbs:            types.ReadVerbGroup(),
+			ResourceSelector: "env in (prod",
+		}),
+		expRes: false,
+	},
+	{
+		description: "label selector on the cluster scope is invalid",
+		policy: []*types.PolicyDocument{
+			{
+				Scope: types.ProjectScope,
+				Verbs: types.ReadVerbGroup(),
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.ClusterScope: {
+						Scope:            types.ClusterScope,
+						Verbs:            types.ReadVerbGroup(),
+						ResourceSelector: "env=prod",
+					},
+				},
+			},
+		},
+		expRes: false,
+	},
+	{
+		description: "policy which does not follow the scope heirarchy is invalid",
+		policy:      testInvalidPolicyDocumentNested,
+		expRes:      false,
+	},
+}
+
+func TestIsValidPolicy(t *testing.T) {
+	assert := assert.New(t)
+
+	for _, test := range isValidPolicyTests {
+		assert.Equal(test.expRes, policy.IsValidPolicy(test.policy), test.description)
+	}
+}
+
 func BenchmarkSimpleHasScopeAccess(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		res := policy.HasScopeAccess(
@@ -277,6 +612,187 @@ var testPolicyNamespaceSpecific = []*types.PolicyDocument{
 	},
 }
 
+// This document only allows updating releases in the namespace "staging" of the cluster with id 4,
+// which requires the parent scopes to be readable.
+var testPolicyReleaseWrite = []*types.PolicyDocument{
+	{
+		Scope:           types.ProjectScope,
+		ReadOnlyParents: true,
+		Verbs:           types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Resources: []types.NameOrUInt{
+					{
+						UInt: 4,
+					},
+				},
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "staging",
+							},
+						},
+						Children: map[types.PermissionScope]*types.PolicyDocument{
+							types.ReleaseScope: {
+								Scope: types.ReleaseScope,
+								Verbs: []types.APIVerb{types.APIVerbGet, types.APIVerbList, types.APIVerbUpdate},
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// testPolicyNamespaceGlob allows writing every namespace in cluster 4 whose name starts
+// with "team-a-", and the releases in these namespaces
+var testPolicyNamespaceGlob = []*types.PolicyDocument{
+	{
+		Scope:           types.ProjectScope,
+		ReadOnlyParents: true,
+		Verbs:           types.ReadVerbGroup(),
+		Children: map[types.PermissionScope]*types.PolicyDocument{
+			types.ClusterScope: {
+				Scope: types.ClusterScope,
+				Verbs: types.ReadVerbGroup(),
+				Resources: []types.NameOrUInt{
+					{
+						UInt: 4,
+					},
+				},
+				Children: map[types.PermissionScope]*types.PolicyDocument{
+					types.NamespaceScope: {
+						Scope: types.NamespaceScope,
+						Verbs: types.ReadWriteVerbGroup(),
+						Resources: []types.NameOrUInt{
+							{
+								Name: "team-a-*",
+							},
+						},
+						Children: map[types.PermissionScope]*types.PolicyDocument{
+							types.ReleaseScope: {
+								Scope: types.ReleaseScope,
+								Verbs: types.ReadWriteVerbGroup(),
+							},
+						},
+					},
+				},
+			},
+		},
+	},
+}
+
+// testPolicyLabelSelector allows reading the namespaces labeled env=prod, and updating the
+// releases in these namespaces which are labeled tier=backend or are named "api-" followed by
+// a single character
+var testPolicyLabelSelector = getNamespacePolicy(&types.PolicyDocument{
+	Scope:            types.NamespaceScope,
+	Verbs:            types.ReadVerbGroup(),
+	ResourceSelector: "env in (prod)",
+	Children: map[types.PermissionScope]*types.PolicyDocument{
+		types.ReleaseScope: {
+			Scope:            types.ReleaseScope,
+			Verbs:            []types.APIVerb{types.APIVerbGet, types.APIVerbList, types.APIVerbUpdate},
+			ResourceSelector: "tier=backend",
+			Resources: []types.NameOrUInt{
+				{
+					Name: "api-?",
+				},
+			},
+		},
+	},
+})
+
+// testPolicyNegativeLabelSelector allows reading the namespaces which are not labeled env=prod
+var testPolicyNegativeLabelSelector = getNamespacePolicy(&types.PolicyDocument{
+	Scope:            types.NamespaceScope,
+	Verbs:            types.ReadVerbGroup(),
+	ResourceSelector: "env!=prod",
+})
+
+// getNamespacePolicy returns a policy which grants read access to cluster 4, and the given
+// namespace document in that cluster
+func getNamespacePolicy(namespaceDoc *types.PolicyDocument) []*types.PolicyDocument {
+	return []*types.PolicyDocument{
+		{
+			Scope:           types.ProjectScope,
+			ReadOnlyParents: true,
+			Verbs:           types.ReadVerbGroup(),
+			Children: map[types.PermissionScope]*types.PolicyDocument{
+				types.ClusterScope: {
+					Scope: types.ClusterScope,
+					Verbs: types.ReadVerbGroup(),
+					Resources: []types.NameOrUInt{
+						{
+							UInt: 4,
+						},
+					},
+					Children: map[types.PermissionScope]*types.PolicyDocument{
+						types.NamespaceScope: namespaceDoc,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getNamespaceRequestScopes returns the scopes of a request against a namespace in cluster 4 of
+// project 1, with the labels of the namespace
+func getNamespaceRequestScopes(
+	verb types.APIVerb,
+	namespace string,
+	namespaceLabels map[string]string,
+) map[types.PermissionScope]*types.RequestAction {
+	reqScopes := getReleaseRequestScopes(verb, namespace, "", namespaceLabels, nil)
+
+	delete(reqScopes, types.ReleaseScope)
+
+	return reqScopes
+}
+
+// getReleaseRequestScopes returns the scopes of a request against a release in cluster 4 of
+// project 1, with the labels of the namespace and the release
+func getReleaseRequestScopes(
+	verb types.APIVerb,
+	namespace, release string,
+	namespaceLabels, releaseLabels map[string]string,
+) map[types.PermissionScope]*types.RequestAction {
+	return map[types.PermissionScope]*types.RequestAction{
+		types.ProjectScope: {
+			Verb: verb,
+			Resource: types.NameOrUInt{
+				UInt: 1,
+			},
+		},
+		types.ClusterScope: {
+			Verb: verb,
+			Resource: types.NameOrUInt{
+				UInt: 4,
+			},
+		},
+		types.NamespaceScope: {
+			Verb: verb,
+			Resource: types.NameOrUInt{
+				Name: namespace,
+			},
+			Labels: namespaceLabels,
+		},
+		types.ReleaseScope: {
+			Verb: verb,
+			Resource: types.NameOrUInt{
+				Name: release,
+			},
+			Labels: releaseLabels,
+		},
+	}
+}
+
 // NOTE: these are invalid policy documents that don't follow the accepted heirarchy
 // for scopes. Don't use this as a model for a valid doc.
 var testInvalidPolicyDocument = []*types.PolicyDocument{
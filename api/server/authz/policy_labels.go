@@ -0,0 +1,125 @@
+package authz
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"gorm.io/gorm"
+	k8sErrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// helmStorageLabels are the labels which Helm sets on the secrets storing a release, and which are
+// not part of the labels of the release itself
+var helmStorageLabels = []string{"owner", "name", "status", "version", "createdAt", "modifiedAt"}
+
+// loadResourceLabels loads the labels of the requested namespace and release when the policy
+// matches them by a label selector. Resources which do not exist keep nil labels, so that they
+// are not matched by any label selector, including negative selectors such as "env!=prod".
+func (h *PolicyHandler) loadResourceLabels(
+	r *http.Request,
+	policyDocs []*types.PolicyDocument,
+	reqScopes map[types.PermissionScope]*types.RequestAction,
+) apierrors.RequestError {
+	nsAction, loadNamespace := reqScopes[types.NamespaceScope]
+	loadNamespace = loadNamespace && nsAction.Resource.Name != "" &&
+		policy.HasResourceSelector(policyDocs, types.NamespaceScope)
+
+	releaseAction, loadRelease := reqScopes[types.ReleaseScope]
+	loadRelease = loadRelease && releaseAction.Resource.Name != "" && nsAction != nil &&
+		policy.HasResourceSelector(policyDocs, types.ReleaseScope)
+
+	clusterAction, ok := reqScopes[types.ClusterScope]
+
+	if !ok || (!loadNamespace && !loadRelease) {
+		return nil
+	}
+
+	cluster, err := h.config.Repo.Cluster().ReadCluster(reqScopes[types.ProjectScope].Resource.UInt, clusterAction.Resource.UInt)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return apierrors.NewErrForbidden(
+				fmt.Errorf("cluster with id %d not found in project", clusterAction.Resource.UInt),
+			)
+		}
+
+		return apierrors.NewErrInternal(err)
+	}
+
+	agent, err := NewOutOfClusterAgentGetter(h.config).GetAgent(r, cluster, "")
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	if loadNamespace {
+		namespace, err := agent.GetNamespace(nsAction.Resource.Name)
+
+		if err != nil && !k8sErrors.IsNotFound(err) {
+			return apierrors.NewErrInternal(fmt.Errorf("could not load labels of namespace %s: %w", nsAction.Resource.Name, err))
+		} else if err == nil {
+			nsAction.Labels = namespace.Labels
+
+			if nsAction.Labels == nil {
+				nsAction.Labels = make(map[string]string)
+			}
+		}
+	}
+
+	if loadRelease {
+		releaseAction.Labels, err = getReleaseLabels(agent, nsAction.Resource.Name, releaseAction.Resource.Name)
+
+		if err != nil {
+			return apierrors.NewErrInternal(fmt.Errorf("could not load labels of release %s: %w", releaseAction.Resource.Name, err))
+		}
+	}
+
+	return nil
+}
+
+// getReleaseLabels returns the labels of the latest revision of a Helm release, or nil if the release
+// does not exist. Release labels are the labels of the Helm storage secret of the revision, such as
+// "sh.helm.release.v1.web.v3", without the labels which Helm sets itself. Since the Helm version used
+// by Porter does not support setting labels on a release, these labels are set with
+// "kubectl label secret", and have to be set again on the secret of each new revision.
+func getReleaseLabels(agent *kubernetes.Agent, namespace, name string) (map[string]string, error) {
+	secrets, err := agent.Clientset.CoreV1().Secrets(namespace).List(
+		context.Background(),
+		metav1.ListOptions{
+			LabelSelector: fmt.Sprintf("owner=helm,name=%s", name),
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var res map[string]string
+	latestVersion := -1
+
+	for _, secret := range secrets.Items {
+		version, err := strconv.Atoi(secret.Labels["version"])
+
+		if err != nil || version <= latestVersion {
+			continue
+		}
+
+		latestVersion = version
+		res = make(map[string]string)
+
+		for key, val := range secret.Labels {
+			res[key] = val
+		}
+
+		for _, key := range helmStorageLabels {
+			delete(res, key)
+		}
+	}
+
+	return res, nil
+}
@@ -1,6 +1,7 @@
 package api_token
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"time"
@@ -51,6 +52,27 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		req.ExpiresAt = time.Now().Add(time.Hour * 24 * 365)
 	}
 
+	if (req.PolicyUID == "") == (len(req.Policy) == 0) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("exactly one of policy_uid or policy must be set"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	// tokens which are created with their own policy documents get a dedicated policy, so that
+	// they can be scoped to a single cluster, namespace or release
+	if len(req.Policy) > 0 {
+		uid, err := p.createTokenPolicy(user, proj, req)
+		if err != nil {
+			p.HandleAPIError(w, r, err)
+			return
+		}
+
+		req.PolicyUID = uid
+	}
+
 	apiPolicy, reqErr := policy.GetAPIPolicyFromUID(p.Repo().Policy(), proj.ID, req.PolicyUID)
 
 	if reqErr != nil {
@@ -58,6 +80,12 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	// token policies grant write access to the scopes they list, while the scopes above them are
+	// only readable
+	for _, policyDoc := range req.Policy {
+		policyDoc.ReadOnlyParents = true
+	}
+
 	uid, err := encryption.GenerateRandomBytes(16)
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -111,3 +139,45 @@ func (p *APITokenCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 
 	p.WriteResult(w, r, apiToken.ToAPITokenType(apiPolicy.Policy, encoded))
 }
+
+func (p *APITokenCreateHandler) createTokenPolicy(
+	user *models.User,
+	proj *models.Project,
+	req *types.CreateAPIToken,
+) (string, apierrors.RequestError) {
+	if !policy.IsValidPolicy(req.Policy) {
+		return "", apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("policy does not follow the scope heirarchy, or has an invalid resource pattern or label selector"),
+			http.StatusBadRequest,
+		)
+	}
+
+	// token policies grant write access to the scopes they list, while the scopes above them are
+	// only readable
+	for _, policyDoc := range req.Policy {
+		policyDoc.ReadOnlyParents = true
+	}
+
+	uid, err := encryption.GenerateRandomBytes(16)
+	if err != nil {
+		return "", apierrors.NewErrInternal(err)
+	}
+
+	policyBytes, err := json.Marshal(req.Policy)
+	if err != nil {
+		return "", apierrors.NewErrInternal(err)
+	}
+
+	_, err = p.Repo().Policy().CreatePolicy(&models.Policy{
+		ProjectID:       proj.ID,
+		UniqueID:        uid,
+		CreatedByUserID: user.ID,
+		Name:            fmt.Sprintf("token-%s", req.Name),
+		PolicyBytes:     policyBytes,
+	})
+	if err != nil {
+		return "", apierrors.NewErrInternal(err)
+	}
+
+	return uid, nil
+}
@@ -40,7 +40,7 @@ func (p *APITokenListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	apiTokens := make([]*types.APITokenMeta, 0)
+	apiTokens := make(types.ListAPITokensResponse, 0)
 
 	for _, tok := range tokens {
 		apiTokens = append(apiTokens, tok.ToAPITokenMetaType())
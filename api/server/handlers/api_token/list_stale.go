@@ -0,0 +1,77 @@
+package api_token
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// defaultStaleTokenDuration is the duration after which an unused token is considered stale
+const defaultStaleTokenDuration = 30 * 24 * time.Hour
+
+type APITokenListStaleHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewAPITokenListStaleHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *APITokenListStaleHandler {
+	return &APITokenListStaleHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *APITokenListStaleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if !proj.APITokensEnabled {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("api token endpoints are not enabled for this project")))
+		return
+	}
+
+	req := &types.ListStaleAPITokensRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, req); !ok {
+		return
+	}
+
+	unusedFor := defaultStaleTokenDuration
+
+	if req.UnusedFor != "" {
+		var err error
+
+		unusedFor, err = time.ParseDuration(req.UnusedFor)
+
+		if err != nil || unusedFor <= 0 {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("unused_for must be a positive duration, such as 720h"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	tokens, err := p.Repo().APIToken().ListStaleAPITokensByProjectID(proj.ID, time.Now().Add(-unusedFor))
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	apiTokens := make(types.ListAPITokensResponse, 0)
+
+	for _, tok := range tokens {
+		apiTokens = append(apiTokens, tok.ToAPITokenMetaType())
+	}
+
+	p.WriteResult(w, r, apiTokens)
+}
@@ -0,0 +1,156 @@
+package api_token
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz/policy"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auth/token"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// defaultRotationOverlap is the duration for which the previous secret of a rotated
+	// token keeps working
+	defaultRotationOverlap = 24 * time.Hour
+
+	// maxRotationOverlap limits how long a rotated secret can keep working
+	maxRotationOverlap = 7 * 24 * time.Hour
+)
+
+type APITokenRotateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewAPITokenRotateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *APITokenRotateHandler {
+	return &APITokenRotateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *APITokenRotateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	if !proj.APITokensEnabled {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("api token endpoints are not enabled for this project")))
+		return
+	}
+
+	// get the token id from the request
+	tokenID, reqErr := requestutils.GetURLParamString(r, types.URLParamTokenID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	req := &types.RotateAPITokenRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, req); !ok {
+		return
+	}
+
+	overlap := defaultRotationOverlap
+
+	if req.Overlap != "" {
+		var err error
+
+		overlap, err = time.ParseDuration(req.Overlap)
+
+		if err != nil || overlap < 0 || overlap > maxRotationOverlap {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("overlap must be a duration between 0s and %s", maxRotationOverlap),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
+	apiToken, err := p.Repo().APIToken().ReadAPIToken(proj.ID, tokenID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("token with id %s not found in project", tokenID),
+				http.StatusNotFound,
+			))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if apiToken.Revoked || apiToken.IsExpired() {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("token with id %s is revoked or expired and cannot be rotated", tokenID),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	apiPolicy, reqErr := policy.GetAPIPolicyFromUID(p.Repo().Policy(), proj.ID, apiToken.PolicyUID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	secretKey, err := encryption.GenerateRandomBytes(16)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// hash the secret key for storage in the db
+	hashedToken, err := bcrypt.GenerateFromPassword([]byte(secretKey), 8)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the current secret keeps working until the end of the overlap window, while any secret
+	// from an earlier rotation stops working immediately
+	previousExpiry := time.Now().Add(overlap)
+
+	apiToken.PreviousSecretKey = apiToken.SecretKey
+	apiToken.PreviousSecretExpiry = &previousExpiry
+	apiToken.SecretKey = hashedToken
+
+	apiToken, err = p.Repo().APIToken().UpdateAPIToken(apiToken)
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	jwt, err := token.GetStoredTokenForAPI(apiToken.CreatedByUserID, proj.ID, apiToken.UniqueID, secretKey)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	encoded, err := jwt.EncodeToken(p.Config().TokenConf)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, apiToken.ToAPITokenType(apiPolicy.Policy, encoded))
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/auditexport"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type CreateAuditSinkHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewCreateAuditSinkHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *CreateAuditSinkHandler {
+	return &CreateAuditSinkHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *CreateAuditSinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateAuditSinkRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if err := auditexport.ValidateConfig(request.Kind, request.AuditSinkConfig); err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	confBytes, err := json.Marshal(request.AuditSinkConfig)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// new sinks only receive the events after they were created, older events can be sent
+	// with a replay
+	lastEventID, err := c.Repo().AuditEvent().GetLastAuditEventIDBefore(proj.ID, time.Now())
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sink, err := c.Repo().AuditEvent().CreateAuditSink(&models.AuditSink{
+		ProjectID:   proj.ID,
+		Name:        request.Name,
+		Kind:        string(request.Kind),
+		LastEventID: lastEventID,
+		Config:      confBytes,
+	})
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	c.WriteResult(w, r, sink.ToAuditSinkType())
+}
@@ -0,0 +1,59 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type DeleteAuditSinkHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDeleteAuditSinkHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DeleteAuditSinkHandler {
+	return &DeleteAuditSinkHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *DeleteAuditSinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sinkID, reqErr := requestutils.GetURLParamUint(r, types.URLParamAuditSinkID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	sink, err := c.Repo().AuditEvent().ReadAuditSink(proj.ID, sinkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("audit sink %d not found", sinkID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sink, err = c.Repo().AuditEvent().DeleteAuditSink(sink)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, sink.ToAuditSinkType())
+}
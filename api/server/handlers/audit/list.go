@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// auditEventsPageSize is the number of audit events returned per page
+const auditEventsPageSize = 50
+
+type ListAuditEventsHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewListAuditEventsHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ListAuditEventsHandler {
+	return &ListAuditEventsHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *ListAuditEventsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.ListAuditEventsRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	var page int64
+
+	if request.PaginationRequest != nil && request.Page > 0 {
+		page = request.Page
+	}
+
+	events, count, err := c.Repo().AuditEvent().ListAuditEventsByProjectID(
+		proj.ID,
+		request,
+		auditEventsPageSize,
+		int(page)*auditEventsPageSize,
+	)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := &types.ListAuditEventsResponse{
+		Events: make([]*types.AuditEvent, 0),
+		Pagination: &types.PaginationResponse{
+			NumPages:    (count + auditEventsPageSize - 1) / auditEventsPageSize,
+			CurrentPage: page,
+		},
+	}
+
+	if page+1 < res.Pagination.NumPages {
+		res.Pagination.NextPage = page + 1
+	}
+
+	for _, event := range events {
+		res.Events = append(res.Events, event.ToAuditEventType())
+	}
+
+	c.WriteResult(w, r, res)
+}
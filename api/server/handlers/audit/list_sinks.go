@@ -0,0 +1,43 @@
+package audit
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type ListAuditSinksHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewListAuditSinksHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *ListAuditSinksHandler {
+	return &ListAuditSinksHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (c *ListAuditSinksHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sinks, err := c.Repo().AuditEvent().ListAuditSinksByProjectID(proj.ID)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListAuditSinksResponse, 0)
+
+	for _, sink := range sinks {
+		res = append(res, sink.ToAuditSinkType())
+	}
+
+	c.WriteResult(w, r, res)
+}
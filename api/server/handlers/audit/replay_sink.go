@@ -0,0 +1,76 @@
+package audit
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type ReplayAuditSinkHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewReplayAuditSinkHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *ReplayAuditSinkHandler {
+	return &ReplayAuditSinkHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+// ServeHTTP moves the cursor of the sink back to the last event before the requested time, so
+// that the next export sends every later event again
+func (c *ReplayAuditSinkHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	sinkID, reqErr := requestutils.GetURLParamUint(r, types.URLParamAuditSinkID)
+
+	if reqErr != nil {
+		c.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	request := &types.ReplayAuditSinkRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	sink, err := c.Repo().AuditEvent().ReadAuditSink(proj.ID, sinkID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("audit sink %d not found", sinkID)))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	lastEventID, err := c.Repo().AuditEvent().GetLastAuditEventIDBefore(proj.ID, request.Since)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	sink.LastEventID = lastEventID
+
+	sink, err = c.Repo().AuditEvent().UpdateAuditSinkCursor(sink)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, sink.ToAuditSinkType())
+}
@@ -0,0 +1,97 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"gorm.io/gorm"
+)
+
+type NotifyDeploymentEventHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewNotifyDeploymentEventHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *NotifyDeploymentEventHandler {
+	return &NotifyDeploymentEventHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (c *NotifyDeploymentEventHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	request := &types.NotifyDeploymentEventRequest{}
+
+	if ok := c.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if cluster.NotificationsDisabled {
+		return
+	}
+
+	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
+
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	notifConf, err := commonutils.GetNotificationConfig(c.Config(), rel)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	status := notifier.DeploymentStatus(request.Event)
+
+	notifURL := fmt.Sprintf(
+		"%s/applications/%s/%s/%s?project_id=%d",
+		c.Config().ServerConf.ServerURL,
+		url.PathEscape(cluster.Name),
+		request.ReleaseNamespace,
+		request.ReleaseName,
+		cluster.ProjectID,
+	)
+
+	if request.JobName != "" {
+		notifURL = fmt.Sprintf(
+			"%s/jobs/%s/%s/%s?project_id=%d&job=%s",
+			c.Config().ServerConf.ServerURL,
+			url.PathEscape(cluster.Name),
+			request.ReleaseNamespace,
+			request.ReleaseName,
+			cluster.ProjectID,
+			url.QueryEscape(request.JobName),
+		)
+	}
+
+	err = commonutils.GetDeploymentNotifier(c.Config(), cluster.ProjectID, notifConf).Notify(&notifier.NotifyOpts{
+		ProjectID:   cluster.ProjectID,
+		ClusterID:   cluster.ID,
+		ClusterName: cluster.Name,
+		Status:      status,
+		Info:        request.Info,
+		Name:        request.ReleaseName,
+		Namespace:   request.ReleaseNamespace,
+		URL:         notifURL,
+	})
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+}
@@ -0,0 +1,167 @@
+package cluster_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/cluster"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/stretchr/testify/assert"
+)
+
+// slackRecorder is a Slack webhook which records the payloads it receives
+type slackRecorder struct {
+	mu       sync.Mutex
+	payloads []string
+}
+
+func (s *slackRecorder) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.payloads = append(s.payloads, string(body))
+}
+
+func TestNotifyDeploymentEvent(t *testing.T) {
+	tests := []struct {
+		name                  string
+		notifConf             *models.NotificationConfig
+		notificationsDisabled bool
+		event                 string
+		expStatus             int
+		expNotified           bool
+	}{
+		{
+			name:        "subscribed events are sent",
+			notifConf:   &models.NotificationConfig{Enabled: true, Events: "job_failed,oom_killed"},
+			event:       "job_failed",
+			expStatus:   http.StatusOK,
+			expNotified: true,
+		},
+		{
+			name:      "events which are not listed are not sent",
+			notifConf: &models.NotificationConfig{Enabled: true, Events: "job_failed,oom_killed"},
+			event:     "job_succeeded",
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "legacy configs are not subscribed to the new events",
+			notifConf: &models.NotificationConfig{Enabled: true, Success: true, Failure: true},
+			event:     "job_failed",
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "releases without a config are not subscribed to the new events",
+			event:     "oom_killed",
+			expStatus: http.StatusOK,
+		},
+		{
+			name:                  "clusters with disabled notifications are not notified",
+			notifConf:             &models.NotificationConfig{Enabled: true, Events: "job_failed"},
+			notificationsDisabled: true,
+			event:                 "job_failed",
+			expStatus:             http.StatusOK,
+		},
+		{
+			name:      "unknown events are rejected",
+			notifConf: &models.NotificationConfig{Enabled: true, Events: "job_failed"},
+			event:     "helm_deployed",
+			expStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config := apitest.LoadConfig(t)
+
+			recorder := &slackRecorder{}
+			server := httptest.NewServer(recorder)
+			defer server.Close()
+
+			proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			cl, err := config.Repo.Cluster().CreateCluster(&models.Cluster{
+				ProjectID:             proj.ID,
+				Name:                  "test-cluster",
+				NotificationsDisabled: tc.notificationsDisabled,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			rel := &models.Release{Name: "web", Namespace: "default", ClusterID: cl.ID, ProjectID: proj.ID}
+
+			if tc.notifConf != nil {
+				notifConf, err := config.Repo.NotificationConfig().CreateNotificationConfig(tc.notifConf)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				rel.NotificationConfig = notifConf.ID
+			}
+
+			if _, err := config.Repo.Release().CreateRelease(rel); err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = config.Repo.SlackIntegration().CreateSlackIntegration(&ints.SlackIntegration{
+				ProjectID: proj.ID,
+				Webhook:   []byte(server.URL),
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			req, rr := apitest.GetRequestAndRecorder(
+				t,
+				string(types.HTTPVerbPost),
+				"/api/projects/1/clusters/1/deployment_events",
+				&types.NotifyDeploymentEventRequest{
+					ReleaseName:      "web",
+					ReleaseNamespace: "default",
+					Event:            tc.event,
+					JobName:          "web-28000000",
+				},
+			)
+
+			req = apitest.WithProject(t, req, proj)
+			req = req.WithContext(context.WithValue(req.Context(), types.ClusterScope, cl))
+
+			handler := cluster.NewNotifyDeploymentEventHandler(
+				config,
+				shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+				shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+			)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expStatus, rr.Result().StatusCode)
+
+			recorder.mu.Lock()
+			defer recorder.mu.Unlock()
+
+			if !tc.expNotified {
+				assert.Empty(t, recorder.payloads, "no notification should be sent")
+				return
+			}
+
+			if assert.Len(t, recorder.payloads, 1, "the event should be sent once") {
+				assert.True(t, strings.Contains(recorder.payloads[0], "job=web-28000000"), "the notification should link to the job run")
+			}
+		})
+	}
+}
@@ -10,12 +10,12 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
-	"github.com/porter-dev/porter/internal/notifier/slack"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
@@ -45,8 +45,6 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
 	rel, err := c.Repo().Release().ReadRelease(cluster.ID, request.ReleaseName, request.ReleaseNamespace)
 
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
@@ -72,10 +70,10 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	notifiers := make([]notifier.IncidentNotifier, 0)
-
-	if c.Config().SlackConf != nil {
-		notifiers = append(notifiers, slack.NewIncidentNotifier(slackInts...))
+	notifiers, err := commonutils.GetIncidentNotifiers(c.Config(), cluster, notifConf)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
 	if sc := c.Config().ServerConf; sc.SendgridAPIKey != "" && sc.SendgridSenderEmail != "" && sc.SendgridIncidentAlertTemplateID != "" {
@@ -121,6 +119,20 @@ func (c *NotifyNewIncidentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 			return
 		}
+
+		if status, ok := notifier.GetIncidentDeploymentStatus(request); ok {
+			commonutils.GetDeploymentNotifier(c.Config(), cluster.ProjectID, notifConf).Notify(&notifier.NotifyOpts{
+				ProjectID:   cluster.ProjectID,
+				ClusterID:   cluster.ID,
+				ClusterName: cluster.Name,
+				Status:      status,
+				Info:        request.Summary,
+				Name:        request.ReleaseName,
+				Namespace:   request.ReleaseNamespace,
+				URL:         url,
+				Timestamp:   request.LastSeen,
+			})
+		}
 	}
 }
 
@@ -0,0 +1,79 @@
+package discord_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type DiscordIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewDiscordIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DiscordIntegrationCreateHandler {
+	return &DiscordIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *DiscordIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateDiscordIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if err := validateWebhookURL(request.Webhook); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	discordInt, err := p.Repo().DiscordIntegration().CreateDiscordIntegration(&ints.DiscordIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Channel:   request.Channel,
+		Webhook:   []byte(request.Webhook),
+	})
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	p.WriteResult(w, r, discordInt.ToDiscordIntegrationType())
+}
+
+// validateWebhookURL only accepts Discord webhook URLs, so that
+// notifications cannot be used to send requests to arbitrary hosts
+func validateWebhookURL(webhook string) error {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return fmt.Errorf("webhook is not a valid URL")
+	}
+
+	host := u.Hostname()
+
+	if u.Scheme != "https" || (host != "discord.com" && host != "discordapp.com") ||
+		!strings.HasPrefix(u.Path, "/api/webhooks/") {
+		return fmt.Errorf("webhook must be a Discord webhook URL")
+	}
+
+	return nil
+}
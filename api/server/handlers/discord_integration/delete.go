@@ -0,0 +1,56 @@
+package discord_integration
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DiscordIntegrationDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewDiscordIntegrationDeleteHandler(
+	config *config.Config,
+) *DiscordIntegrationDeleteHandler {
+	return &DiscordIntegrationDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *DiscordIntegrationDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	integrationID, reqErr := requestutils.GetURLParamUint(r, types.URLParamDiscordIntegrationID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	discordInts, err := p.Repo().DiscordIntegration().ListDiscordIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, discordInt := range discordInts {
+		if discordInt.ID == integrationID {
+			err = p.Repo().DiscordIntegration().DeleteDiscordIntegration(discordInt.ID)
+			if err != nil {
+				p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("discord integration %d not found", integrationID)))
+}
@@ -0,0 +1,43 @@
+package discord_integration
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type DiscordIntegrationListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewDiscordIntegrationListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *DiscordIntegrationListHandler {
+	return &DiscordIntegrationListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *DiscordIntegrationListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	discordInts, err := p.Repo().DiscordIntegration().ListDiscordIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListDiscordIntegrationsResponse, 0)
+
+	for _, discordInt := range discordInts {
+		res = append(res, discordInt.ToDiscordIntegrationType())
+	}
+
+	p.WriteResult(w, r, res)
+}
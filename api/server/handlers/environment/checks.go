@@ -0,0 +1,148 @@
+package environment
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// the GitHub API rejects check run outputs with a summary or text longer than 65535 characters
+const maxCheckRunOutputLength = 65535
+
+// getCheckRunName returns the name of the check run for the environment, which is what branch
+// protection rules refer to when requiring a status check
+func getCheckRunName(env *models.Environment) string {
+	return fmt.Sprintf("Porter Preview Environment (%s)", env.Name)
+}
+
+// createGithubCheckRun creates an in-progress check run for the latest commit of the deployment,
+// linked to the workflow run which is deploying it
+func createGithubCheckRun(
+	client *github.Client,
+	env *models.Environment,
+	depl *models.Deployment,
+	actionID uint,
+) (int64, error) {
+	// the commit SHA sent by the CLI is shortened, but check runs require the full SHA
+	headSHA, _, err := client.Repositories.GetCommitSHA1(
+		context.Background(), env.GitRepoOwner, env.GitRepoName, depl.CommitSHA, "",
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %w", errGithubAPI, err)
+	}
+
+	checkRun, _, err := client.Checks.CreateCheckRun(
+		context.Background(), env.GitRepoOwner, env.GitRepoName, github.CreateCheckRunOptions{
+			Name:    getCheckRunName(env),
+			HeadSHA: headSHA,
+			DetailsURL: github.String(fmt.Sprintf("https://github.com/%s/%s/actions/runs/%d",
+				env.GitRepoOwner, env.GitRepoName, actionID)),
+			ExternalID: github.String(fmt.Sprintf("%d", depl.ID)),
+			Status:     github.String("in_progress"),
+			StartedAt:  &github.Timestamp{Time: time.Now()},
+			Output: &github.CheckRunOutput{
+				Title:   github.String("Deploying preview environment"),
+				Summary: github.String(fmt.Sprintf("Deploying `%s` to namespace `%s`", depl.CommitSHA, depl.Namespace)),
+			},
+		},
+	)
+	if err != nil {
+		return 0, fmt.Errorf("%v: %w", errGithubAPI, err)
+	}
+
+	return checkRun.GetID(), nil
+}
+
+// completeGithubCheckRun marks the check run of the deployment as completed with the given conclusion,
+// which is one of "success", "failure" or "cancelled"
+func completeGithubCheckRun(
+	client *github.Client,
+	env *models.Environment,
+	depl *models.Deployment,
+	conclusion string,
+	output *github.CheckRunOutput,
+) error {
+	if depl.GHCheckRunID == 0 {
+		return nil
+	}
+
+	_, _, err := client.Checks.UpdateCheckRun(
+		context.Background(), env.GitRepoOwner, env.GitRepoName, depl.GHCheckRunID, github.UpdateCheckRunOptions{
+			Name:        getCheckRunName(env),
+			Status:      github.String("completed"),
+			Conclusion:  github.String(conclusion),
+			CompletedAt: &github.Timestamp{Time: time.Now()},
+			Output:      output,
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%v: %w", errGithubAPI, err)
+	}
+
+	return nil
+}
+
+// getCheckRunOutput lists the result of every resource of the deployment, linking successfully deployed
+// resources to the dashboard. The build logs are linked through the details URL of the check run.
+func getCheckRunOutput(
+	config *config.Config,
+	project *models.Project,
+	cluster *models.Cluster,
+	depl *models.Deployment,
+	successfulResources []*types.SuccessfullyDeployedResource,
+	resourceErrors map[string]string,
+) *github.CheckRunOutput {
+	var title, summary string
+
+	if len(resourceErrors) > 0 {
+		title = fmt.Sprintf("%d resource(s) failed to deploy", len(resourceErrors))
+		summary = fmt.Sprintf("❌ Errors encountered while deploying `%s`", depl.CommitSHA)
+	} else {
+		title = "Preview environment deployed"
+		summary = fmt.Sprintf("✅ `%s` has been successfully deployed", depl.CommitSHA)
+
+		if depl.Subdomain != "" {
+			summary += fmt.Sprintf(" to %s", depl.Subdomain)
+		}
+	}
+
+	text := "| Resource | Result |\n|-|-|\n"
+
+	for _, res := range successfulResources {
+		text += fmt.Sprintf("| [`%s`](%s) | ✅ Deployed |\n", res.ReleaseName,
+			getResourceDashboardURL(config, project, cluster, depl, res))
+	}
+
+	var failed []string
+
+	for res := range resourceErrors {
+		failed = append(failed, res)
+	}
+
+	sort.Strings(failed)
+
+	for _, res := range failed {
+		text += fmt.Sprintf("| `%s` | ❌ Failed |\n", res)
+	}
+
+	for _, res := range failed {
+		text += fmt.Sprintf("\n#### `%s`\n```\n%s\n```\n", res, resourceErrors[res])
+	}
+
+	if len(text) > maxCheckRunOutputLength {
+		text = strings.ToValidUTF8(text[:maxCheckRunOutputLength-3], "") + "..."
+	}
+
+	return &github.CheckRunOutput{
+		Title:   github.String(title),
+		Summary: github.String(summary),
+		Text:    github.String(text),
+	}
+}
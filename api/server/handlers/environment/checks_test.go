@@ -0,0 +1,266 @@
+package environment
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/google/go-github/v41/github"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const testHeadSHA = "abc1234def5678abc1234def5678abc1234def56"
+
+// checkRunRecorder is a fake GitHub API which records the check runs that are created and updated
+type checkRunRecorder struct {
+	created []map[string]interface{}
+	updated []map[string]interface{}
+}
+
+func newCheckRunClient(t *testing.T) (*github.Client, *checkRunRecorder) {
+	rec := &checkRunRecorder{}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/repos/acme/api/commits/abc1234", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(testHeadSHA))
+	})
+
+	mux.HandleFunc("/repos/acme/api/check-runs", func(w http.ResponseWriter, r *http.Request) {
+		rec.created = append(rec.created, decodeCheckRun(t, r))
+		w.Write([]byte(`{"id": 42}`))
+	})
+
+	mux.HandleFunc("/repos/acme/api/check-runs/42", func(w http.ResponseWriter, r *http.Request) {
+		rec.updated = append(rec.updated, decodeCheckRun(t, r))
+		w.Write([]byte(`{"id": 42}`))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := github.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+
+	return client, rec
+}
+
+func decodeCheckRun(t *testing.T, r *http.Request) map[string]interface{} {
+	body := make(map[string]interface{})
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+
+	return body
+}
+
+func TestCheckRunStatus(t *testing.T) {
+	env := &models.Environment{Name: "preview", GitRepoOwner: "acme", GitRepoName: "api"}
+
+	tests := []struct {
+		name           string
+		run            func(client *github.Client, depl *models.Deployment) error
+		checkRunID     int64
+		wantCreated    bool
+		wantStatus     string
+		wantConclusion string
+	}{
+		{
+			name: "created check run is in progress",
+			run: func(client *github.Client, depl *models.Deployment) error {
+				id, err := createGithubCheckRun(client, env, depl, 7)
+				assert.EqualValues(t, 42, id)
+				return err
+			},
+			wantCreated: true,
+			wantStatus:  "in_progress",
+		},
+		{
+			name: "successful deployment",
+			run: func(client *github.Client, depl *models.Deployment) error {
+				return completeGithubCheckRun(client, env, depl, "success", nil)
+			},
+			checkRunID:     42,
+			wantStatus:     "completed",
+			wantConclusion: "success",
+		},
+		{
+			name: "failed deployment",
+			run: func(client *github.Client, depl *models.Deployment) error {
+				return completeGithubCheckRun(client, env, depl, "failure", nil)
+			},
+			checkRunID:     42,
+			wantStatus:     "completed",
+			wantConclusion: "failure",
+		},
+		{
+			name: "cancelled deployment",
+			run: func(client *github.Client, depl *models.Deployment) error {
+				return completeGithubCheckRun(client, env, depl, "cancelled", nil)
+			},
+			checkRunID:     42,
+			wantStatus:     "completed",
+			wantConclusion: "cancelled",
+		},
+		{
+			name: "deployment without check run",
+			run: func(client *github.Client, depl *models.Deployment) error {
+				return completeGithubCheckRun(client, env, depl, "success", nil)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, rec := newCheckRunClient(t)
+
+			depl := &models.Deployment{
+				CommitSHA:    "abc1234",
+				Namespace:    "pr-7",
+				GHCheckRunID: tt.checkRunID,
+			}
+			depl.ID = 3
+
+			if err := tt.run(client, depl); err != nil {
+				t.Fatal(err)
+			}
+
+			var checkRuns []map[string]interface{}
+
+			if tt.wantCreated {
+				checkRuns = rec.created
+				assert.Empty(t, rec.updated)
+			} else {
+				checkRuns = rec.updated
+				assert.Empty(t, rec.created)
+			}
+
+			if tt.wantStatus == "" {
+				assert.Empty(t, checkRuns)
+				return
+			}
+
+			if !assert.Len(t, checkRuns, 1) {
+				return
+			}
+
+			checkRun := checkRuns[0]
+
+			assert.Equal(t, "Porter Preview Environment (preview)", checkRun["name"])
+			assert.Equal(t, tt.wantStatus, checkRun["status"])
+
+			if tt.wantConclusion != "" {
+				assert.Equal(t, tt.wantConclusion, checkRun["conclusion"])
+			} else {
+				assert.NotContains(t, checkRun, "conclusion")
+			}
+
+			if tt.wantCreated {
+				assert.Equal(t, testHeadSHA, checkRun["head_sha"], "check run should use the full commit SHA")
+				assert.Equal(t, "https://github.com/acme/api/actions/runs/7", checkRun["details_url"])
+				assert.Equal(t, "3", checkRun["external_id"])
+			}
+		})
+	}
+}
+
+func TestGetCheckRunOutput(t *testing.T) {
+	conf := &config.Config{}
+	conf.ServerConf = &env.ServerConf{ServerURL: "https://porter.example.com"}
+
+	project := &models.Project{}
+	project.ID = 1
+
+	cluster := &models.Cluster{Name: "prod"}
+
+	tests := []struct {
+		name                string
+		subdomain           string
+		successfulResources []*types.SuccessfullyDeployedResource
+		resourceErrors      map[string]string
+		wantTitle           string
+		wantSummary         string
+		wantText            string
+	}{
+		{
+			name: "all resources deployed",
+			successfulResources: []*types.SuccessfullyDeployedResource{
+				{ReleaseName: "web", ReleaseType: "web"},
+				{ReleaseName: "migrate", ReleaseType: "job"},
+			},
+			wantTitle:   "Preview environment deployed",
+			wantSummary: "✅ `abc1234` has been successfully deployed",
+			wantText: "| Resource | Result |\n|-|-|\n" +
+				"| [`web`](https://porter.example.com/applications/prod/pr-7/web?project_id=1) | ✅ Deployed |\n" +
+				"| [`migrate`](https://porter.example.com/jobs/prod/pr-7/migrate?project_id=1) | ✅ Deployed |\n",
+		},
+		{
+			name:      "deployed to subdomain",
+			subdomain: "https://pr-7.example.com",
+			successfulResources: []*types.SuccessfullyDeployedResource{
+				{ReleaseName: "web", ReleaseType: "web"},
+			},
+			wantTitle:   "Preview environment deployed",
+			wantSummary: "✅ `abc1234` has been successfully deployed to https://pr-7.example.com",
+			wantText: "| Resource | Result |\n|-|-|\n" +
+				"| [`web`](https://porter.example.com/applications/prod/pr-7/web?project_id=1) | ✅ Deployed |\n",
+		},
+		{
+			name:      "failed resources are sorted",
+			subdomain: "https://pr-7.example.com",
+			successfulResources: []*types.SuccessfullyDeployedResource{
+				{ReleaseName: "web", ReleaseType: "web"},
+			},
+			resourceErrors: map[string]string{
+				"worker": "image not found",
+				"db":     "timed out",
+			},
+			wantTitle:   "2 resource(s) failed to deploy",
+			wantSummary: "❌ Errors encountered while deploying `abc1234`",
+			wantText: "| Resource | Result |\n|-|-|\n" +
+				"| [`web`](https://porter.example.com/applications/prod/pr-7/web?project_id=1) | ✅ Deployed |\n" +
+				"| `db` | ❌ Failed |\n" +
+				"| `worker` | ❌ Failed |\n" +
+				"\n#### `db`\n```\ntimed out\n```\n" +
+				"\n#### `worker`\n```\nimage not found\n```\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			depl := &models.Deployment{CommitSHA: "abc1234", Namespace: "pr-7", Subdomain: tt.subdomain}
+
+			output := getCheckRunOutput(conf, project, cluster, depl, tt.successfulResources, tt.resourceErrors)
+
+			assert.Equal(t, tt.wantTitle, output.GetTitle())
+			assert.Equal(t, tt.wantSummary, output.GetSummary())
+			assert.Equal(t, tt.wantText, output.GetText())
+		})
+	}
+}
+
+func TestGetCheckRunOutputTruncated(t *testing.T) {
+	conf := &config.Config{}
+	conf.ServerConf = &env.ServerConf{}
+
+	depl := &models.Deployment{CommitSHA: "abc1234", Namespace: "pr-7"}
+
+	// multi-byte characters must not be split when the text is cut off
+	output := getCheckRunOutput(conf, &models.Project{}, &models.Cluster{}, depl, nil, map[string]string{
+		"web": strings.Repeat("❌", maxCheckRunOutputLength),
+	})
+
+	text := output.GetText()
+
+	assert.LessOrEqual(t, len(text), maxCheckRunOutputLength)
+	assert.True(t, strings.HasSuffix(text, "..."))
+	assert.True(t, utf8.ValidString(text))
+}
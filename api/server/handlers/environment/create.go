@@ -75,6 +75,7 @@ func (c *CreateEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		Mode:                request.Mode,
 		WebhookID:           string(webhookUID),
 		NewCommentsDisabled: request.DisableNewComments,
+		GithubChecksEnabled: request.EnableGithubChecks,
 		GitDeployBranches:   strings.Join(request.GitDeployBranches, ","),
 	}
 
@@ -5,11 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/google/go-github/v41/github"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -59,6 +62,11 @@ func (c *CreateDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if env.IsGitlab() {
+		c.createGitlabDeployment(w, r, env, request)
+		return
+	}
+
 	// create deployment on GitHub API
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
@@ -123,5 +131,88 @@ func (c *CreateDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if env.GithubChecksEnabled {
+		c.createCheckRun(w, r, client, env, depl, request.ActionID)
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
+
+// createCheckRun creates the check run of the deployment as a best-effort operation, since the
+// deployment has already been created
+func (c *CreateDeploymentByClusterHandler) createCheckRun(
+	w http.ResponseWriter,
+	r *http.Request,
+	client *github.Client,
+	env *models.Environment,
+	depl *models.Deployment,
+	actionID uint,
+) {
+	checkRunID, err := createGithubCheckRun(client, env, depl, actionID)
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	depl.GHCheckRunID = checkRunID
+
+	_, err = c.Repo().Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+}
+
+func (c *CreateDeploymentByClusterHandler) createGitlabDeployment(
+	w http.ResponseWriter,
+	r *http.Request,
+	env *models.Environment,
+	request *types.CreateDeploymentRequest,
+) {
+	client, _, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if request.PullRequestID != 0 {
+		// add a check for GitLab MR status
+		mrClosed, err := isGitlabMRClosed(client, env, int(request.PullRequestID))
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+			return
+		}
+
+		if mrClosed {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("attempting to create deployment for a closed gitlab merge request"), http.StatusConflict,
+			))
+			return
+		}
+	}
+
+	// GitLab has no deployment objects to create, the merge request is only updated with notes
+	depl, err := c.Repo().Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     request.Namespace,
+		Status:        types.DeploymentStatusCreating,
+		PullRequestID: request.PullRequestID,
+		RepoOwner:     request.GitHubMetadata.RepoOwner,
+		RepoName:      request.GitHubMetadata.RepoName,
+		PRName:        request.GitHubMetadata.PRName,
+		CommitSHA:     request.GitHubMetadata.CommitSHA,
+		PRBranchFrom:  request.GitHubMetadata.PRBranchFrom,
+		PRBranchInto:  request.GitHubMetadata.PRBranchInto,
+		LastPushedAt:  time.Now(),
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error creating deployment: %w", err)))
+		return
+	}
+
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
@@ -93,6 +93,11 @@ func (c *DeleteDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	if env.IsGitlab() {
+		c.WriteResult(w, r, depl.ToDeploymentType())
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -0,0 +1,125 @@
+package environment
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	gitlabCI "github.com/porter-dev/porter/internal/integrations/ci/gitlab"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"gorm.io/gorm"
+)
+
+type DeleteGitlabEnvironmentHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewDeleteGitlabEnvironmentHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *DeleteGitlabEnvironmentHandler {
+	return &DeleteGitlabEnvironmentHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *DeleteGitlabEnvironmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	gi, _ := r.Context().Value(types.GitlabIntegrationScope).(*integrations.GitlabIntegration)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
+
+	owner, name, ok := commonutils.GetOwnerAndNameParams(c, w, r)
+
+	if !ok {
+		return
+	}
+
+	env, err := c.Repo().Environment().ReadEnvironmentByOwnerRepoName(project.ID, cluster.ID, owner, name)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
+			return
+		}
+
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if env.GitlabIntegrationID != gi.ID {
+		c.HandleAPIError(w, r, apierrors.NewErrNotFound(errEnvironmentNotFound))
+		return
+	}
+
+	// delete all corresponding deployments
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	depls, err := c.Repo().Environment().ListDeployments(env.ID)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, depl := range depls {
+		if !isSystemNamespace(depl.Namespace) {
+			agent.DeleteNamespace(depl.Namespace)
+		}
+
+		if _, err := c.Repo().Environment().DeleteDeployment(depl); err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	client, _, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	gitlabWebhookID := env.GitlabWebhookID
+
+	// delete the environment
+	env, err = c.Repo().Environment().DeleteEnvironment(env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// FIXME: ignore the return status codes for now, should be fixed when we start returning all non-fatal errors
+	if gitlabWebhookID != 0 {
+		client.Projects.DeleteProjectHook(getGitlabProjectID(env), gitlabWebhookID)
+	}
+
+	err = gitlabCI.DeletePreviewEnv(&gitlabCI.PreviewEnvOpts{
+		Client:              client,
+		GitRepoOwner:        env.GitRepoOwner,
+		GitRepoName:         env.GitRepoName,
+		EnvironmentName:     env.Name,
+		InstanceName:        c.Config().ServerConf.InstanceName,
+		ProjectID:           project.ID,
+		ClusterID:           cluster.ID,
+		GitlabIntegrationID: gi.ID,
+	})
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, env.ToEnvironmentType())
+}
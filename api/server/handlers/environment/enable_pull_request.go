@@ -92,24 +92,58 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 		}
 	}
 
-	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	var apiErr apierrors.RequestError
+
+	if env.IsGitlab() {
+		apiErr = runGitlabMRPipeline(c.Config(), env, request.Number)
+	} else {
+		apiErr = c.dispatchGithubWorkflow(r, env, request)
+	}
+
+	if apiErr != nil {
+		c.HandleAPIError(w, r, apiErr)
+		return
+	}
+
+	// create the deployment
+	depl, err := c.Repo().Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "",
+		Status:        types.DeploymentStatusCreating,
+		PullRequestID: request.Number,
+		RepoOwner:     request.RepoOwner,
+		RepoName:      request.RepoName,
+		PRName:        request.Title,
+		PRBranchFrom:  request.BranchFrom,
+		PRBranchInto:  request.BranchInto,
+	})
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
+
+func (c *EnablePullRequestHandler) dispatchGithubWorkflow(
+	r *http.Request,
+	env *models.Environment,
+	request *types.PullRequest,
+) apierrors.RequestError {
+	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
 	// add an extra check that the installation has permission to read this pull request
 	pr, _, err := client.PullRequests.Get(r.Context(), env.GitRepoOwner, env.GitRepoName, int(request.Number))
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGithubAPI, err),
-			http.StatusConflict))
-		return
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGithubAPI, err), http.StatusConflict)
 	}
 
 	if pr.GetState() == "closed" {
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("cannot enable deployment for closed PR"),
-			http.StatusConflict))
-		return
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("cannot enable deployment for closed PR"),
+			http.StatusConflict)
 	}
 
 	ghResp, err := client.Actions.CreateWorkflowDispatchEventByFileName(
@@ -127,45 +161,25 @@ func (c *EnablePullRequestHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 
 	if ghResp != nil {
 		if ghResp.StatusCode == 404 {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			return apierrors.NewErrPassThroughToClient(
 				fmt.Errorf(
 					"please make sure the preview environment workflow files are present in PR branch %s and are up to"+
 						" date with the default branch", request.BranchFrom,
-				), http.StatusConflict),
+				), http.StatusConflict,
 			)
-			return
 		} else if ghResp.StatusCode == 422 {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			return apierrors.NewErrPassThroughToClient(
 				fmt.Errorf(
 					"please make sure the workflow files in PR branch %s are up to date with the default branch",
 					request.BranchFrom,
-				), http.StatusConflict),
+				), http.StatusConflict,
 			)
-			return
 		}
 	}
 
 	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
-	}
-
-	// create the deployment
-	depl, err := c.Repo().Environment().CreateDeployment(&models.Deployment{
-		EnvironmentID: env.ID,
-		Namespace:     "",
-		Status:        types.DeploymentStatusCreating,
-		PullRequestID: request.Number,
-		RepoOwner:     request.RepoOwner,
-		RepoName:      request.RepoName,
-		PRName:        request.Title,
-		PRBranchFrom:  request.BranchFrom,
-		PRBranchInto:  request.BranchInto,
-	})
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
-		return
+		return apierrors.NewErrInternal(err)
 	}
 
-	c.WriteResult(w, r, depl.ToDeploymentType())
+	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -112,6 +113,11 @@ func (c *FinalizeDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r
 		return
 	}
 
+	if env.IsGitlab() {
+		c.finalizeGitlabDeployment(w, r, env, depl)
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -142,6 +148,16 @@ func (c *FinalizeDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r
 		return
 	}
 
+	if env.GithubChecksEnabled {
+		err = completeGithubCheckRun(client, env, depl, "success", getCheckRunOutput(
+			c.Config(), project, cluster, depl, request.SuccessfulResources, nil,
+		))
+
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
 	if !depl.IsBranchDeploy() {
 		// add a check for the PR to be open before creating a comment
 		prClosed, err := isGithubPRClosed(client, request.RepoOwner, request.RepoName, int(depl.PullRequestID))
@@ -184,3 +200,62 @@ func (c *FinalizeDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r
 
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
+
+func (c *FinalizeDeploymentByClusterHandler) finalizeGitlabDeployment(
+	w http.ResponseWriter,
+	r *http.Request,
+	env *models.Environment,
+	depl *models.Deployment,
+) {
+	if depl.IsBranchDeploy() {
+		c.WriteResult(w, r, depl.ToDeploymentType())
+		return
+	}
+
+	client, gi, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// add a check for the MR to be open before creating a note
+	mrClosed, err := isGitlabMRClosed(client, env, int(depl.PullRequestID))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error fetching details of gitlab merge request for deployment ID: %d. Error: %w",
+				depl.ID, err), http.StatusConflict,
+		))
+		return
+	}
+
+	if mrClosed {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("GitLab merge request has been closed"),
+			http.StatusConflict))
+		return
+	}
+
+	noteBody := "## Porter Preview Environments\n"
+
+	if depl.Subdomain == "" {
+		noteBody += fmt.Sprintf(
+			"✅ The latest SHA ([`%s`](%s)) has been successfully deployed.",
+			depl.CommitSHA, getGitlabCommitURL(gi.InstanceURL, depl),
+		)
+	} else {
+		noteBody += fmt.Sprintf(
+			"✅ The latest SHA ([`%s`](%s)) has been successfully deployed to %s",
+			depl.CommitSHA, getGitlabCommitURL(gi.InstanceURL, depl), depl.Subdomain,
+		)
+	}
+
+	err = createOrUpdateGitlabNote(client, c.Repo(), env, depl, noteBody)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
@@ -109,6 +109,11 @@ func (c *FinalizeDeploymentWithErrorsByClusterHandler) ServeHTTP(w http.Response
 		return
 	}
 
+	if env.IsGitlab() {
+		c.finalizeGitlabDeployment(w, r, project, cluster, env, depl, request)
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
 	if err != nil {
@@ -116,15 +121,7 @@ func (c *FinalizeDeploymentWithErrorsByClusterHandler) ServeHTTP(w http.Response
 		return
 	}
 
-	depl.Status = types.DeploymentStatusFailed
-
-	var lastErrors []string
-
-	for resName, errString := range request.Errors {
-		lastErrors = append(lastErrors, fmt.Sprintf("%s: %s", resName, errString))
-	}
-
-	depl.LastErrors = strings.Join(lastErrors, ",")
+	setDeploymentErrors(depl, request)
 
 	// we do not care of the error in this case because the list deployments endpoint
 	// talks to the github API to fetch the deployment status correctly
@@ -138,6 +135,16 @@ func (c *FinalizeDeploymentWithErrorsByClusterHandler) ServeHTTP(w http.Response
 		},
 	)
 
+	if env.GithubChecksEnabled {
+		err = completeGithubCheckRun(client, env, depl, "failure", getCheckRunOutput(
+			c.Config(), project, cluster, depl, request.SuccessfulResources, request.Errors,
+		))
+
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
 	if !depl.IsBranchDeploy() {
 		// add a check for the PR to be open before creating a comment
 		prClosed, err := isGithubPRClosed(client, request.RepoOwner, request.RepoName, int(depl.PullRequestID))
@@ -161,38 +168,12 @@ func (c *FinalizeDeploymentWithErrorsByClusterHandler) ServeHTTP(w http.Response
 			return
 		}
 
-		commentBody := fmt.Sprintf(
-			"## Porter Preview Environments\n"+
-				"❌ Errors encountered while deploying the changes\n"+
-				"||Deployment Information|\n"+
-				"|-|-|\n"+
-				"| Latest SHA | [`%s`](https://github.com/%s/%s/commit/%s) |\n"+
-				"| Build Logs | %s |\n",
-			depl.CommitSHA, depl.RepoOwner, depl.RepoName, depl.CommitSHA, workflowRun.GetHTMLURL(),
+		commentBody := getDeploymentErrorsCommentBody(
+			c.Config(), project, cluster, depl, request,
+			fmt.Sprintf("https://github.com/%s/%s/commit/%s", depl.RepoOwner, depl.RepoName, depl.CommitSHA),
+			workflowRun.GetHTMLURL(),
 		)
 
-		if len(request.SuccessfulResources) > 0 {
-			commentBody += "#### Successfully deployed resources\n"
-
-			for _, res := range request.SuccessfulResources {
-				if res.ReleaseType == "job" {
-					commentBody += fmt.Sprintf("- [`%s`](%s/jobs/%s/%s/%s?project_id=%d)\n",
-						res.ReleaseName, c.Config().ServerConf.ServerURL, cluster.Name, depl.Namespace,
-						res.ReleaseName, project.ID)
-				} else {
-					commentBody += fmt.Sprintf("- [`%s`](%s/applications/%s/%s/%s?project_id=%d)\n",
-						res.ReleaseName, c.Config().ServerConf.ServerURL, cluster.Name, depl.Namespace,
-						res.ReleaseName, project.ID)
-				}
-			}
-		}
-
-		commentBody += "#### Failed resources\n"
-
-		for res, err := range request.Errors {
-			commentBody += fmt.Sprintf("<details>\n  <summary><code>%s</code></summary>\n\n  **Error:** %s\n</details>\n", res, err)
-		}
-
 		err = createOrUpdateComment(client, c.Repo(), env.NewCommentsDisabled, depl, github.String(commentBody))
 
 		if err != nil {
@@ -203,3 +184,127 @@ func (c *FinalizeDeploymentWithErrorsByClusterHandler) ServeHTTP(w http.Response
 
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
+
+func (c *FinalizeDeploymentWithErrorsByClusterHandler) finalizeGitlabDeployment(
+	w http.ResponseWriter,
+	r *http.Request,
+	project *models.Project,
+	cluster *models.Cluster,
+	env *models.Environment,
+	depl *models.Deployment,
+	request *types.FinalizeDeploymentWithErrorsByClusterRequest,
+) {
+	setDeploymentErrors(depl, request)
+
+	_, _ = c.Repo().Environment().UpdateDeployment(depl)
+
+	if depl.IsBranchDeploy() {
+		c.WriteResult(w, r, depl.ToDeploymentType())
+		return
+	}
+
+	client, gi, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// add a check for the MR to be open before creating a note
+	mrClosed, err := isGitlabMRClosed(client, env, int(depl.PullRequestID))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusConflict))
+		return
+	}
+
+	if mrClosed {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("gitlab merge request has been closed"),
+			http.StatusConflict))
+		return
+	}
+
+	pipelineURL, err := getLatestGitlabPipelineURL(client, env, int(depl.PullRequestID))
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	noteBody := getDeploymentErrorsCommentBody(
+		c.Config(), project, cluster, depl, request, getGitlabCommitURL(gi.InstanceURL, depl), pipelineURL,
+	)
+
+	err = createOrUpdateGitlabNote(client, c.Repo(), env, depl, noteBody)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
+
+func setDeploymentErrors(depl *models.Deployment, request *types.FinalizeDeploymentWithErrorsByClusterRequest) {
+	depl.Status = types.DeploymentStatusFailed
+
+	var lastErrors []string
+
+	for resName, errString := range request.Errors {
+		lastErrors = append(lastErrors, fmt.Sprintf("%s: %s", resName, errString))
+	}
+
+	depl.LastErrors = strings.Join(lastErrors, ",")
+}
+
+func getDeploymentErrorsCommentBody(
+	config *config.Config,
+	project *models.Project,
+	cluster *models.Cluster,
+	depl *models.Deployment,
+	request *types.FinalizeDeploymentWithErrorsByClusterRequest,
+	commitURL, buildLogsURL string,
+) string {
+	commentBody := fmt.Sprintf(
+		"## Porter Preview Environments\n"+
+			"❌ Errors encountered while deploying the changes\n"+
+			"||Deployment Information|\n"+
+			"|-|-|\n"+
+			"| Latest SHA | [`%s`](%s) |\n"+
+			"| Build Logs | %s |\n",
+		depl.CommitSHA, commitURL, buildLogsURL,
+	)
+
+	if len(request.SuccessfulResources) > 0 {
+		commentBody += "#### Successfully deployed resources\n"
+
+		for _, res := range request.SuccessfulResources {
+			commentBody += fmt.Sprintf("- [`%s`](%s)\n", res.ReleaseName,
+				getResourceDashboardURL(config, project, cluster, depl, res))
+		}
+	}
+
+	commentBody += "#### Failed resources\n"
+
+	for res, err := range request.Errors {
+		commentBody += fmt.Sprintf("<details>\n  <summary><code>%s</code></summary>\n\n  **Error:** %s\n</details>\n", res, err)
+	}
+
+	return commentBody
+}
+
+func getResourceDashboardURL(
+	config *config.Config,
+	project *models.Project,
+	cluster *models.Cluster,
+	depl *models.Deployment,
+	res *types.SuccessfullyDeployedResource,
+) string {
+	if res.ReleaseType == "job" {
+		return fmt.Sprintf("%s/jobs/%s/%s/%s?project_id=%d", config.ServerConf.ServerURL, cluster.Name,
+			depl.Namespace, res.ReleaseName, project.ID)
+	}
+
+	return fmt.Sprintf("%s/applications/%s/%s/%s?project_id=%d", config.ServerConf.ServerURL, cluster.Name,
+		depl.Namespace, res.ReleaseName, project.ID)
+}
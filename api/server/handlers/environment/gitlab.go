@@ -0,0 +1,180 @@
+package environment
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/xanzy/go-gitlab"
+)
+
+var errGitlabAPI = errors.New("error communicating with the gitlab API")
+
+func getGitlabProjectID(env *models.Environment) string {
+	return fmt.Sprintf("%s/%s", env.GitRepoOwner, env.GitRepoName)
+}
+
+func getGitlabWebhookURLFromUID(serverURL, webhookUID string) string {
+	return fmt.Sprintf("%s/api/gitlab/incoming_webhook/%s", serverURL, webhookUID)
+}
+
+func getGitlabCommitURL(instanceURL string, depl *models.Deployment) string {
+	return fmt.Sprintf("%s/%s/%s/-/commit/%s", strings.TrimSuffix(instanceURL, "/"), depl.RepoOwner,
+		depl.RepoName, depl.CommitSHA)
+}
+
+func isGitlabMRClosed(
+	client *gitlab.Client,
+	env *models.Environment,
+	mrIID int,
+) (bool, error) {
+	mr, _, err := client.MergeRequests.GetMergeRequest(getGitlabProjectID(env), mrIID, &gitlab.GetMergeRequestsOptions{})
+	if err != nil {
+		return false, fmt.Errorf("%v: %w", errGitlabAPI, err)
+	}
+
+	// merge requests can be "opened", "closed", "locked" or "merged"
+	return mr.State != "opened", nil
+}
+
+// getLatestGitlabPipelineURL returns the URL of the latest pipeline run for the merge request
+func getLatestGitlabPipelineURL(
+	client *gitlab.Client,
+	env *models.Environment,
+	mrIID int,
+) (string, error) {
+	pipelines, _, err := client.MergeRequests.ListMergeRequestPipelines(getGitlabProjectID(env), mrIID)
+	if err != nil {
+		return "", fmt.Errorf("%v: %w", errGitlabAPI, err)
+	}
+
+	if len(pipelines) == 0 {
+		return "", fmt.Errorf("no pipelines found for merge request !%d", mrIID)
+	}
+
+	return pipelines[0].WebURL, nil
+}
+
+// createOrUpdateGitlabNote follows the same rules as createOrUpdateComment, using merge request
+// notes instead of pull request comments
+func createOrUpdateGitlabNote(
+	client *gitlab.Client,
+	repo repository.Repository,
+	env *models.Environment,
+	depl *models.Deployment,
+	noteBody string,
+) error {
+	if env.NewCommentsDisabled && depl.GitlabMRNoteID != 0 {
+		_, resp, err := client.Notes.UpdateMergeRequestNote(
+			getGitlabProjectID(env), int(depl.PullRequestID), depl.GitlabMRNoteID,
+			&gitlab.UpdateMergeRequestNoteOptions{
+				Body: gitlab.String(noteBody),
+			},
+		)
+
+		if err == nil {
+			return nil
+		} else if resp == nil || resp.StatusCode != http.StatusNotFound {
+			return fmt.Errorf("%v: %w", errGitlabAPI, err)
+		}
+
+		// perhaps a deleted note, so we create a new one
+	}
+
+	note, _, err := client.Notes.CreateMergeRequestNote(
+		getGitlabProjectID(env), int(depl.PullRequestID),
+		&gitlab.CreateMergeRequestNoteOptions{
+			Body: gitlab.String(noteBody),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("%v: %w", errGitlabAPI, err)
+	}
+
+	depl.GitlabMRNoteID = note.ID
+
+	_, err = repo.Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		return fmt.Errorf("error updating gitlab note ID for deployment with ID: %d: %w", depl.ID, err)
+	}
+
+	return nil
+}
+
+// runGitlabMRPipeline starts a new pipeline for the merge request, which runs the preview
+// environment job added to .gitlab-ci.yml
+func runGitlabMRPipeline(
+	config *config.Config,
+	env *models.Environment,
+	mrIID uint,
+) apierrors.RequestError {
+	if mrIID == 0 {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("branch deployments are not supported for gitlab repositories"), http.StatusBadRequest,
+		)
+	}
+
+	client, _, err := commonutils.GetGitlabClientFromEnvironment(config, env)
+	if err != nil {
+		return apierrors.NewErrInternal(err)
+	}
+
+	mrClosed, err := isGitlabMRClosed(client, env, int(mrIID))
+	if err != nil {
+		return apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("error fetching details of gitlab merge request !%d. Error: %w", mrIID, err),
+			http.StatusConflict,
+		)
+	}
+
+	if mrClosed {
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("GitLab merge request has been closed"),
+			http.StatusConflict)
+	}
+
+	_, _, err = client.MergeRequests.CreateMergeRequestPipeline(getGitlabProjectID(env), int(mrIID))
+	if err != nil {
+		return apierrors.NewErrPassThroughToClient(fmt.Errorf("%v: %w", errGitlabAPI, err), http.StatusConflict)
+	}
+
+	return nil
+}
+
+// getGitlabPorterYAML returns the contents of the porter.yaml file of the repository, reading it from
+// the default branch if no branch is specified
+func getGitlabPorterYAML(config *config.Config, env *models.Environment, branch string) (string, bool, error) {
+	client, _, err := commonutils.GetGitlabClientFromEnvironment(config, env)
+	if err != nil {
+		return "", false, err
+	}
+
+	if branch == "" {
+		project, _, err := client.Projects.GetProject(getGitlabProjectID(env), &gitlab.GetProjectOptions{})
+		if err != nil {
+			return "", false, fmt.Errorf("%v: %w", errGitlabAPI, err)
+		}
+
+		branch = project.DefaultBranch
+	}
+
+	contents, resp, err := client.RepositoryFiles.GetRawFile(getGitlabProjectID(env), "porter.yaml",
+		&gitlab.GetRawFileOptions{
+			Ref: gitlab.String(branch),
+		},
+	)
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return "", false, nil
+	} else if err != nil {
+		return "", false, fmt.Errorf("%v: %w", errGitlabAPI, err)
+	}
+
+	return string(contents), true, nil
+}
@@ -81,6 +81,12 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 				return
 			}
 
+			if env.IsGitlab() {
+				// the status of GitLab deployments is only reported by the CLI
+				wg.Done()
+				continue
+			}
+
 			if _, ok := envToGithubClientMap[env.ID]; !ok {
 				client, err := getGithubClientFromEnvironment(c.Config(), env)
 				if err != nil {
@@ -107,6 +113,10 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 		}
 
 		for _, env := range envList {
+			if env.IsGitlab() {
+				continue
+			}
+
 			if _, ok := envToGithubClientMap[env.ID]; !ok {
 				client, err := getGithubClientFromEnvironment(c.Config(), env)
 				if err != nil {
@@ -138,6 +148,18 @@ func (c *ListDeploymentsByClusterHandler) ServeHTTP(w http.ResponseWriter, r *ht
 			return
 		}
 
+		if env.IsGitlab() {
+			for _, depl := range depls {
+				deployments = append(deployments, depl.ToDeploymentType())
+			}
+
+			c.WriteResult(w, r, map[string]interface{}{
+				"pull_requests": pullRequests,
+				"deployments":   deployments,
+			})
+			return
+		}
+
 		deplInfoMap := make(map[string]bool)
 
 		client, err := getGithubClientFromEnvironment(c.Config(), env)
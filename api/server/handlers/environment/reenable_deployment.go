@@ -62,6 +62,23 @@ func (c *ReenableDeploymentHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if env.IsGitlab() {
+		if apiErr := runGitlabMRPipeline(c.Config(), env, depl.PullRequestID); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+
+		depl.Status = types.DeploymentStatusCreating
+
+		_, err = c.Repo().Environment().UpdateDeployment(depl)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		}
+
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -63,6 +63,24 @@ func (c *TriggerDeploymentWorkflowHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if env.IsGitlab() {
+		if apiErr := runGitlabMRPipeline(c.Config(), env, depl.PullRequestID); apiErr != nil {
+			c.HandleAPIError(w, r, apiErr)
+			return
+		}
+
+		// set the status to updating manually here for the frontend to case on
+		depl.Status = types.DeploymentStatusUpdating
+
+		_, err = c.Repo().Environment().UpdateDeployment(depl)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		}
+
+		return
+	}
+
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -9,6 +9,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -104,6 +105,11 @@ func (c *UpdateDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r *h
 		return
 	}
 
+	if env.IsGitlab() {
+		c.updateGitlabDeployment(w, r, env, depl, request)
+		return
+	}
+
 	// create deployment on GitHub API
 	client, err := getGithubClientFromEnvironment(c.Config(), env)
 
@@ -142,6 +148,63 @@ func (c *UpdateDeploymentByClusterHandler) ServeHTTP(w http.ResponseWriter, r *h
 	depl.GHDeploymentID = ghDeployment.GetID()
 	depl.CommitSHA = request.CommitSHA
 
+	if env.GithubChecksEnabled {
+		// every new commit gets its own check run, so that branch protection rules apply to the latest one
+		checkRunID, err := createGithubCheckRun(client, env, depl, request.ActionID)
+
+		if err != nil {
+			c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		} else {
+			depl.GHCheckRunID = checkRunID
+		}
+	}
+
+	// update the deployment
+	depl, err = c.Repo().Environment().UpdateDeployment(depl)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	c.WriteResult(w, r, depl.ToDeploymentType())
+}
+
+func (c *UpdateDeploymentByClusterHandler) updateGitlabDeployment(
+	w http.ResponseWriter,
+	r *http.Request,
+	env *models.Environment,
+	depl *models.Deployment,
+	request *types.UpdateDeploymentByClusterRequest,
+) {
+	client, _, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env)
+
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if !depl.IsBranchDeploy() {
+		mrClosed, err := isGitlabMRClosed(client, env, int(depl.PullRequestID))
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("error fetching details of gitlab merge request for deployment ID: %d. Error: %w",
+					depl.ID, err), http.StatusConflict,
+			))
+			return
+		}
+
+		if mrClosed {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("GitLab merge request has been closed"),
+				http.StatusConflict))
+			return
+		}
+	}
+
+	depl.Namespace = request.Namespace
+	depl.CommitSHA = request.CommitSHA
+
 	// update the deployment
 	depl, err = c.Repo().Environment().UpdateDeployment(depl)
 
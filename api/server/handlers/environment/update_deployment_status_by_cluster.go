@@ -5,10 +5,12 @@ import (
 	"fmt"
 	"net/http"
 
+	"github.com/google/go-github/v41/github"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -109,29 +111,20 @@ func (c *UpdateDeploymentStatusByClusterHandler) ServeHTTP(w http.ResponseWriter
 		return
 	}
 
-	client, err := getGithubClientFromEnvironment(c.Config(), env)
-
-	if err != nil {
-		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-			fmt.Errorf("unable to get github client: %w", err), http.StatusConflict,
-		))
-		return
-	}
-
 	if !depl.IsBranchDeploy() {
 		// add a check for the PR to be open before creating a comment
-		prClosed, err := isGithubPRClosed(client, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID))
+		prClosed, err := c.isPRClosed(env, depl)
 
 		if err != nil {
 			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
-				fmt.Errorf("error fetching details of github PR for deployment ID: %d. Error: %w",
+				fmt.Errorf("error fetching details of PR for deployment ID: %d. Error: %w",
 					depl.ID, err), http.StatusConflict,
 			))
 			return
 		}
 
 		if prClosed {
-			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("Github PR has been closed"),
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(fmt.Errorf("PR has been closed"),
 				http.StatusConflict))
 			return
 		}
@@ -153,5 +146,50 @@ func (c *UpdateDeploymentStatusByClusterHandler) ServeHTTP(w http.ResponseWriter
 		return
 	}
 
+	if depl.Status == types.DeploymentStatusFailed && env.GithubChecksEnabled && !env.IsGitlab() {
+		c.failCheckRun(w, r, env, depl)
+	}
+
 	c.WriteResult(w, r, depl.ToDeploymentType())
 }
+
+func (c *UpdateDeploymentStatusByClusterHandler) isPRClosed(env *models.Environment, depl *models.Deployment) (bool, error) {
+	if env.IsGitlab() {
+		client, _, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env)
+		if err != nil {
+			return false, fmt.Errorf("unable to get gitlab client: %w", err)
+		}
+
+		return isGitlabMRClosed(client, env, int(depl.PullRequestID))
+	}
+
+	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	if err != nil {
+		return false, fmt.Errorf("unable to get github client: %w", err)
+	}
+
+	return isGithubPRClosed(client, depl.RepoOwner, depl.RepoName, int(depl.PullRequestID))
+}
+
+// failCheckRun completes the check run of a deployment which failed before any resource was applied
+func (c *UpdateDeploymentStatusByClusterHandler) failCheckRun(
+	w http.ResponseWriter,
+	r *http.Request,
+	env *models.Environment,
+	depl *models.Deployment,
+) {
+	client, err := getGithubClientFromEnvironment(c.Config(), env)
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	err = completeGithubCheckRun(client, env, depl, "failure", &github.CheckRunOutput{
+		Title:   github.String("Preview environment deployment failed"),
+		Summary: github.String(fmt.Sprintf("❌ Errors encountered while deploying `%s`, check the build logs", depl.CommitSHA)),
+	})
+
+	if err != nil {
+		c.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+	}
+}
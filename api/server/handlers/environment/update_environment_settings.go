@@ -91,7 +91,14 @@ func (c *UpdateEnvironmentSettingsHandler) ServeHTTP(w http.ResponseWriter, r *h
 
 	changed = !reflect.DeepEqual(env.ToEnvironmentType().GitDeployBranches, newBranches)
 
-	if changed {
+	if changed && env.IsGitlab() && len(newBranches) > 0 {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("branch deployments are not supported for gitlab repositories"), http.StatusBadRequest,
+		))
+		return
+	}
+
+	if changed && !env.IsGitlab() {
 		// let us check if the webhook has access to the "push" event
 		client, err := getGithubClientFromEnvironment(c.Config(), env)
 		if err != nil {
@@ -153,11 +160,33 @@ func (c *UpdateEnvironmentSettingsHandler) ServeHTTP(w http.ResponseWriter, r *h
 		changed = true
 	}
 
+	if request.EnableGithubChecks != nil && *request.EnableGithubChecks && env.IsGitlab() {
+		c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("github checks are not supported for gitlab repositories"), http.StatusBadRequest,
+		))
+		return
+	}
+
+	if request.EnableGithubChecks != nil && *request.EnableGithubChecks != env.GithubChecksEnabled {
+		env.GithubChecksEnabled = *request.EnableGithubChecks
+		changed = true
+	}
+
 	if request.Mode != env.Mode {
 		env.Mode = request.Mode
 		changed = true
 	}
 
+	if request.DeploymentTTLHours != nil && *request.DeploymentTTLHours != env.DeploymentTTLHours {
+		env.DeploymentTTLHours = *request.DeploymentTTLHours
+		changed = true
+	}
+
+	if request.IdleSleepHours != nil && *request.IdleSleepHours != env.IdleSleepHours {
+		env.IdleSleepHours = *request.IdleSleepHours
+		changed = true
+	}
+
 	if len(request.NamespaceLabels) > 0 {
 		var labels []string
 
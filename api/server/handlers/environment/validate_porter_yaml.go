@@ -64,16 +64,33 @@ func (c *ValidatePorterYAMLHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	res := &types.ValidatePorterYAMLResponse{
+		Errors: []string{},
+	}
+
+	if env.IsGitlab() {
+		contents, found, err := getGitlabPorterYAML(c.Config(), env, req.Branch)
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		if !found {
+			res.Errors = append(res.Errors, preview.ErrNoPorterYAMLFile.Error())
+			c.WriteResult(w, r, res)
+			return
+		}
+
+		c.validateContents(w, r, res, contents)
+		return
+	}
+
 	ghClient, err := getGithubClientFromEnvironment(c.Config(), env)
 	if err != nil {
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
 		return
 	}
 
-	res := &types.ValidatePorterYAMLResponse{
-		Errors: []string{},
-	}
-
 	if req.Branch == "" { // get the default branch name
 		repo, _, err := ghClient.Repositories.Get(r.Context(), env.GitRepoOwner, env.GitRepoName)
 		if err != nil {
@@ -108,6 +125,15 @@ func (c *ValidatePorterYAMLHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	c.validateContents(w, r, res, contents)
+}
+
+func (c *ValidatePorterYAMLHandler) validateContents(
+	w http.ResponseWriter,
+	r *http.Request,
+	res *types.ValidatePorterYAMLResponse,
+	contents string,
+) {
 	if strings.TrimSpace(contents) == "" {
 		res.Errors = append(res.Errors, preview.ErrEmptyPorterYAMLFile.Error())
 		c.WriteResult(w, r, res)
@@ -0,0 +1,154 @@
+package environment_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/environment"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newWakeDeploymentFixture creates a project, a cluster and an environment with a single
+// deployment in namespace pr-1, whose workloads are scaled according to isSleeping
+func newWakeDeploymentFixture(t *testing.T, isSleeping bool) (
+	*config.Config, *models.Project, *models.Cluster, *models.Deployment, *kubernetes.Agent,
+) {
+	config := apitest.LoadConfig(t)
+
+	proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := config.Repo.Cluster().CreateCluster(&models.Cluster{ProjectID: proj.ID, Name: "test-cluster"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := config.Repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID:      proj.ID,
+		ClusterID:      cluster.ID,
+		IdleSleepHours: 24,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	depl, err := config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1",
+		Status:        types.DeploymentStatusCreated,
+		LastPushedAt:  time.Now().Add(-48 * time.Hour),
+		IsSleeping:    isSleeping,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	replicas := int32(2)
+	annotations := map[string]string{}
+
+	if isSleeping {
+		replicas = 0
+		annotations[kubernetes.SleepReplicasAnnotation] = "2"
+	}
+
+	agent := kubernetes.GetAgentTesting(&appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "web",
+			Namespace:   "pr-1",
+			Annotations: annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	})
+
+	return config, proj, cluster, depl, agent
+}
+
+func serveWakeDeployment(
+	t *testing.T,
+	config *config.Config,
+	proj *models.Project,
+	cluster *models.Cluster,
+	agent *kubernetes.Agent,
+	deplID string,
+) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPost), "/api/projects/1/clusters/1/deployments/1/wake", nil)
+
+	req = apitest.WithProject(t, req, proj)
+	req = apitest.WithURLParams(t, req, map[string]string{
+		"deployment_id": deplID,
+	})
+
+	ctx := context.WithValue(req.Context(), types.ClusterScope, cluster)
+	ctx = context.WithValue(ctx, authz.KubernetesAgentCtxKey, agent)
+	req = req.WithContext(ctx)
+
+	handler := environment.NewWakeDeploymentHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestWakeDeploymentSuccessful(t *testing.T) {
+	config, proj, cluster, depl, agent := newWakeDeploymentFixture(t, true)
+
+	rr := serveWakeDeployment(t, config, proj, cluster, agent, "1")
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	gotDepl, err := config.Repo.Environment().ReadDeploymentByID(proj.ID, cluster.ID, depl.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, gotDepl.IsSleeping, "deployment should not be sleeping")
+	assert.WithinDuration(t, time.Now(), gotDepl.LastPushedAt, time.Minute, "waking up should count as activity")
+
+	k8sDepl, err := agent.Clientset.AppsV1().Deployments("pr-1").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, int32(2), *k8sDepl.Spec.Replicas, "replicas should be restored")
+}
+
+func TestWakeDeploymentNotSleeping(t *testing.T) {
+	config, proj, cluster, _, agent := newWakeDeploymentFixture(t, false)
+
+	rr := serveWakeDeployment(t, config, proj, cluster, agent, "1")
+
+	apitest.AssertResponseError(t, rr, http.StatusPreconditionFailed, &types.ExternalError{
+		Error: "trying to wake up deployment which is not sleeping",
+	})
+}
+
+func TestWakeDeploymentNotFound(t *testing.T) {
+	config, proj, cluster, _, agent := newWakeDeploymentFixture(t, true)
+
+	rr := serveWakeDeployment(t, config, proj, cluster, agent, "2")
+
+	apitest.AssertResponseError(t, rr, http.StatusNotFound, &types.ExternalError{
+		Error: "Resource not found.",
+	})
+}
@@ -0,0 +1,94 @@
+package notification_webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type NotificationWebhookCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewNotificationWebhookCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *NotificationWebhookCreateHandler {
+	return &NotificationWebhookCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *NotificationWebhookCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateNotificationWebhookRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if err := egress.ValidateHTTPSURL(request.URL); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("invalid webhook URL: %w", err),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	if request.ClusterID != 0 {
+		_, err := p.Repo().Cluster().ReadCluster(project.ID, request.ClusterID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("cluster %d not found in project", request.ClusterID),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+	}
+
+	secret, err := encryption.GenerateRandomBytes(32)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	webhook, err := p.Repo().NotificationWebhook().CreateNotificationWebhook(&models.NotificationWebhook{
+		ProjectID:        project.ID,
+		Name:             request.Name,
+		URL:              request.URL,
+		ClusterID:        request.ClusterID,
+		ReleaseName:      request.ReleaseName,
+		ReleaseNamespace: request.ReleaseNamespace,
+		Secret:           []byte(secret),
+	})
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	p.WriteResult(w, r, &types.CreateNotificationWebhookResponse{
+		NotificationWebhook: webhook.ToNotificationWebhookType(),
+		Secret:              secret,
+	})
+}
@@ -0,0 +1,59 @@
+package notification_webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type NotificationWebhookDeleteHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationWebhookDeleteHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationWebhookDeleteHandler {
+	return &NotificationWebhookDeleteHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationWebhookDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationWebhookID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	webhook, err := p.Repo().NotificationWebhook().ReadNotificationWebhook(project.ID, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("notification webhook %d not found", webhookID)))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	webhook, err = p.Repo().NotificationWebhook().DeleteNotificationWebhook(webhook)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, webhook.ToNotificationWebhookType())
+}
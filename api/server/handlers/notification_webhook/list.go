@@ -0,0 +1,43 @@
+package notification_webhook
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type NotificationWebhookListHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationWebhookListHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationWebhookListHandler {
+	return &NotificationWebhookListHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationWebhookListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhooks, err := p.Repo().NotificationWebhook().ListNotificationWebhooksByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListNotificationWebhooksResponse, 0)
+
+	for _, webhook := range webhooks {
+		res = append(res, webhook.ToNotificationWebhookType())
+	}
+
+	p.WriteResult(w, r, res)
+}
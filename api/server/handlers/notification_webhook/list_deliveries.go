@@ -0,0 +1,66 @@
+package notification_webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type NotificationWebhookListDeliveriesHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewNotificationWebhookListDeliveriesHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *NotificationWebhookListDeliveriesHandler {
+	return &NotificationWebhookListDeliveriesHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *NotificationWebhookListDeliveriesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	webhookID, reqErr := requestutils.GetURLParamUint(r, types.URLParamNotificationWebhookID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	// read the webhook to check that it belongs to the project
+	_, err := p.Repo().NotificationWebhook().ReadNotificationWebhook(project.ID, webhookID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("notification webhook %d not found", webhookID)))
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	deliveries, err := p.Repo().NotificationWebhook().ListNotificationWebhookDeliveries(webhookID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListNotificationWebhookDeliveriesResponse, 0)
+
+	for _, delivery := range deliveries {
+		res = append(res, delivery.ToNotificationWebhookDeliveryType())
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,120 @@
+package opa_policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"gorm.io/gorm"
+)
+
+var collectionNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9_-]*[a-z0-9])?$`)
+
+type OPAPolicyCollectionCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewOPAPolicyCollectionCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *OPAPolicyCollectionCreateHandler {
+	return &OPAPolicyCollectionCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *OPAPolicyCollectionCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	req := &types.CreateOPAPolicyCollectionRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, req); !ok {
+		return
+	}
+
+	if !collectionNameRegex.MatchString(req.Name) {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("name must consist of lowercase alphanumeric characters, '-' or '_'"),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
+
+	// compile the policies before storing them, so that the recommender never sees an invalid collection
+	_, err := opa.CompileCustomPolicyCollection(&types.OPAPolicyCollection{
+		Name:             req.Name,
+		Kind:             req.Kind,
+		Match:            req.Match,
+		MustExist:        req.MustExist,
+		OverrideSeverity: req.OverrideSeverity,
+		Policies:         req.Policies,
+	})
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	matchBytes, err := json.Marshal(req.Match)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	policiesBytes, err := json.Marshal(req.Policies)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	collection, err := p.Repo().OPAPolicyCollection().ReadOPAPolicyCollectionByName(proj.ID, req.Name)
+	isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if isNotFound {
+		collection = &models.OPAPolicyCollection{
+			ProjectID: proj.ID,
+			Name:      req.Name,
+		}
+	}
+
+	collection.Kind = req.Kind
+	collection.MatchBytes = matchBytes
+	collection.MustExist = req.MustExist
+	collection.OverrideSeverity = req.OverrideSeverity
+	collection.PoliciesBytes = policiesBytes
+
+	if isNotFound {
+		collection, err = p.Repo().OPAPolicyCollection().CreateOPAPolicyCollection(collection)
+	} else {
+		collection, err = p.Repo().OPAPolicyCollection().UpdateOPAPolicyCollection(collection)
+	}
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res, err := collection.ToOPAPolicyCollectionType()
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,112 @@
+package opa_policy_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/opa_policy"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+const replicasModule = `package custom.replicas
+
+POLICY_ID := "replicas"
+
+allow {
+	input.values.replicaCount >= 2
+}
+`
+
+// metadataModule calls a network builtin, which is not available to the policies of projects
+const metadataModule = `package custom.replicas
+
+POLICY_ID := "replicas"
+
+allow {
+	http.send({"method": "get", "url": "http://169.254.169.254/latest/meta-data/"}).status_code == 200
+}
+`
+
+func createCollection(t *testing.T, config *config.Config, proj *models.Project, module string) *httptest.ResponseRecorder {
+	req, rr := apitest.GetRequestAndRecorder(t, http.MethodPost, "/api/projects/1/opa_policies",
+		&types.CreateOPAPolicyCollectionRequest{
+			Name:  "replicas",
+			Kind:  "helm_release",
+			Match: types.OPAPolicyMatch{ChartName: "web"},
+			Policies: []*types.OPAPolicy{
+				{Name: "custom.replicas", Module: module},
+			},
+		})
+
+	req = apitest.WithProject(t, req, proj)
+
+	handler := opa_policy.NewOPAPolicyCollectionCreateHandler(
+		config,
+		shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+		shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestCreateOPAPolicyCollectionRejectsNetworkBuiltins(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := createCollection(t, config, proj, metadataModule)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	collections, err := config.Repo.OPAPolicyCollection().ListOPAPolicyCollectionsByProjectID(proj.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, collections, "rejected collection should not be stored")
+}
+
+func TestUpdateOPAPolicyCollectionRejectsNetworkBuiltins(t *testing.T) {
+	config := apitest.LoadConfig(t)
+
+	proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rr := createCollection(t, config, proj, replicasModule)
+
+	if !assert.Equal(t, http.StatusOK, rr.Result().StatusCode) {
+		t.FailNow()
+	}
+
+	// collections are updated by uploading a collection with the same name
+	rr = createCollection(t, config, proj, metadataModule)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Result().StatusCode)
+
+	collection, err := config.Repo.OPAPolicyCollection().ReadOPAPolicyCollectionByName(proj.ID, "replicas")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res, err := collection.ToOPAPolicyCollectionType()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if assert.Len(t, res.Policies, 1) {
+		assert.Equal(t, replicasModule, res.Policies[0].Module, "stored policy should not be updated")
+	}
+}
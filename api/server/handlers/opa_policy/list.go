@@ -0,0 +1,50 @@
+package opa_policy
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type OPAPolicyCollectionListHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewOPAPolicyCollectionListHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *OPAPolicyCollectionListHandler {
+	return &OPAPolicyCollectionListHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *OPAPolicyCollectionListHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	collections, err := p.Repo().OPAPolicyCollection().ListOPAPolicyCollectionsByProjectID(proj.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	res := make(types.ListOPAPolicyCollectionsResponse, 0)
+
+	for _, collection := range collections {
+		collectionType, err := collection.ToOPAPolicyCollectionType()
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		res = append(res, collectionType)
+	}
+
+	p.WriteResult(w, r, res)
+}
@@ -0,0 +1,50 @@
+package opa_policy
+
+import (
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type OPAAdmissionUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewOPAAdmissionUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *OPAAdmissionUpdateHandler {
+	return &OPAAdmissionUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *OPAAdmissionUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	req := &types.UpdateOPAAdmissionRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, req); !ok {
+		return
+	}
+
+	if req.Mode == "disabled" {
+		proj.OPAAdmissionMode = ""
+	} else {
+		proj.OPAAdmissionMode = req.Mode
+	}
+
+	proj, err := p.Repo().Project().UpdateProject(proj)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, proj.ToProjectType())
+}
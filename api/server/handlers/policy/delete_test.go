@@ -0,0 +1,132 @@
+package policy_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/policy"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+)
+
+func TestDeletePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policyID  string
+		setup     func(t *testing.T, config *config.Config, proj *models.Project)
+		expStatus int
+		expErr    string
+		expExists bool
+	}{
+		{
+			name:      "deletes unassigned policies",
+			policyID:  "custom-policy",
+			expStatus: http.StatusOK,
+		},
+		{
+			name:     "does not delete policies assigned to collaborators",
+			policyID: "custom-policy",
+			setup: func(t *testing.T, config *config.Config, proj *models.Project) {
+				_, err := config.Repo.Project().CreateProjectRole(proj, &models.Role{
+					Role: types.Role{
+						UserID:    7,
+						ProjectID: proj.ID,
+						Kind:      types.RoleCustom,
+						PolicyUID: "custom-policy",
+					},
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expStatus: http.StatusConflict,
+			expErr:    "policy is assigned to user 7 and cannot be deleted",
+			expExists: true,
+		},
+		{
+			name:     "does not delete policies assigned to active API tokens",
+			policyID: "custom-policy",
+			setup: func(t *testing.T, config *config.Config, proj *models.Project) {
+				_, err := config.Repo.APIToken().CreateAPIToken(&models.APIToken{
+					UniqueID:  "token-1",
+					ProjectID: proj.ID,
+					Name:      "ci",
+					PolicyUID: "custom-policy",
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expStatus: http.StatusConflict,
+			expErr:    "policy is assigned to API token ci and cannot be deleted",
+			expExists: true,
+		},
+		{
+			name:     "deletes policies assigned to revoked API tokens",
+			policyID: "custom-policy",
+			setup: func(t *testing.T, config *config.Config, proj *models.Project) {
+				_, err := config.Repo.APIToken().CreateAPIToken(&models.APIToken{
+					UniqueID:  "token-1",
+					ProjectID: proj.ID,
+					Name:      "ci",
+					PolicyUID: "custom-policy",
+					Revoked:   true,
+				})
+				if err != nil {
+					t.Fatal(err)
+				}
+			},
+			expStatus: http.StatusOK,
+		},
+		{
+			name:      "returns not found for missing policies",
+			policyID:  "missing-policy",
+			expStatus: http.StatusNotFound,
+			expExists: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, proj, _ := newPolicyFixture(t)
+
+			if tc.setup != nil {
+				tc.setup(t, config, proj)
+			}
+
+			req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbDelete), "/api/projects/1/policy/"+tc.policyID, nil)
+
+			req = apitest.WithProject(t, req, proj)
+			req = apitest.WithURLParams(t, req, map[string]string{
+				string(types.URLParamPolicyID): tc.policyID,
+			})
+
+			handler := policy.NewPolicyDeleteHandler(
+				config,
+				shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+			)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expStatus, rr.Result().StatusCode)
+
+			if tc.expErr != "" {
+				assert.Contains(t, rr.Body.String(), tc.expErr)
+			}
+
+			_, err := config.Repo.Policy().ReadPolicy(proj.ID, "custom-policy")
+
+			if tc.expExists {
+				assert.NoError(t, err, "policy should not be deleted")
+			} else {
+				assert.True(t, errors.Is(err, gorm.ErrRecordNotFound), "policy should be deleted")
+			}
+		})
+	}
+}
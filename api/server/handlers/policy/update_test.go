@@ -0,0 +1,149 @@
+package policy_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/handlers/policy"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+// newPolicyFixture creates a project with a custom policy whose id is "custom-policy", and which
+// grants read access to the project
+func newPolicyFixture(t *testing.T) (*config.Config, *models.Project, *models.Policy) {
+	config := apitest.LoadConfig(t)
+
+	proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policyBytes, err := json.Marshal(types.ViewerPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policyModel, err := config.Repo.Policy().CreatePolicy(&models.Policy{
+		UniqueID:    "custom-policy",
+		ProjectID:   proj.ID,
+		Name:        "readers",
+		PolicyBytes: policyBytes,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return config, proj, policyModel
+}
+
+func TestUpdatePolicy(t *testing.T) {
+	tests := []struct {
+		name      string
+		policyID  string
+		req       *types.UpdatePolicy
+		expStatus int
+		expName   string
+		expPolicy []*types.PolicyDocument
+	}{
+		{
+			name:     "updates the name and the documents of the policy",
+			policyID: "custom-policy",
+			req: &types.UpdatePolicy{
+				Name:   "developers",
+				Policy: types.DeveloperPolicy,
+			},
+			expStatus: http.StatusOK,
+			expName:   "developers",
+			expPolicy: types.DeveloperPolicy,
+		},
+		{
+			name:     "keeps the name when it is not set",
+			policyID: "custom-policy",
+			req: &types.UpdatePolicy{
+				Policy: types.DeveloperPolicy,
+			},
+			expStatus: http.StatusOK,
+			expName:   "readers",
+			expPolicy: types.DeveloperPolicy,
+		},
+		{
+			name:     "rejects preset policy names",
+			policyID: "custom-policy",
+			req: &types.UpdatePolicy{
+				Name:   "Admin",
+				Policy: types.DeveloperPolicy,
+			},
+			expStatus: http.StatusBadRequest,
+			expName:   "readers",
+			expPolicy: types.ViewerPolicy,
+		},
+		{
+			name:     "rejects invalid policies",
+			policyID: "custom-policy",
+			req: &types.UpdatePolicy{
+				Policy: []*types.PolicyDocument{
+					{Scope: types.ClusterScope, Verbs: types.ReadVerbGroup()},
+				},
+			},
+			expStatus: http.StatusBadRequest,
+			expName:   "readers",
+			expPolicy: types.ViewerPolicy,
+		},
+		{
+			name:     "returns not found for missing policies",
+			policyID: "missing-policy",
+			req: &types.UpdatePolicy{
+				Policy: types.DeveloperPolicy,
+			},
+			expStatus: http.StatusNotFound,
+			expName:   "readers",
+			expPolicy: types.ViewerPolicy,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			config, proj, _ := newPolicyFixture(t)
+
+			req, rr := apitest.GetRequestAndRecorder(t, string(types.HTTPVerbPut), "/api/projects/1/policy/"+tc.policyID, tc.req)
+
+			req = apitest.WithProject(t, req, proj)
+			req = apitest.WithURLParams(t, req, map[string]string{
+				string(types.URLParamPolicyID): tc.policyID,
+			})
+
+			handler := policy.NewPolicyUpdateHandler(
+				config,
+				shared.NewDefaultRequestDecoderValidator(config.Logger, config.Alerter),
+				shared.NewDefaultResultWriter(config.Logger, config.Alerter),
+			)
+
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, tc.expStatus, rr.Result().StatusCode)
+
+			stored, err := config.Repo.Policy().ReadPolicy(proj.ID, "custom-policy")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			apiPolicy, err := stored.ToAPIPolicyType()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, tc.expName, apiPolicy.Name)
+
+			expPolicyBytes, _ := json.Marshal(tc.expPolicy)
+			gotPolicyBytes, _ := json.Marshal(apiPolicy.Policy)
+
+			assert.JSONEq(t, string(expPolicyBytes), string(gotPolicyBytes), "stored policy documents not equal")
+		})
+	}
+}
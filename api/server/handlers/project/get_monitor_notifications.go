@@ -0,0 +1,46 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type MonitorNotificationsGetHandler struct {
+	handlers.PorterHandlerWriter
+}
+
+func NewMonitorNotificationsGetHandler(
+	config *config.Config,
+	writer shared.ResultWriter,
+) *MonitorNotificationsGetHandler {
+	return &MonitorNotificationsGetHandler{
+		PorterHandlerWriter: handlers.NewDefaultPorterHandler(config, nil, writer),
+	}
+}
+
+func (p *MonitorNotificationsGetHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	conf, err := p.Repo().MonitorNotificationConfig().ReadMonitorNotificationConfigByProjectID(proj.ID)
+
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// monitor notifications are disabled until they are configured
+			p.WriteResult(w, r, (&models.MonitorNotificationConfig{}).ToMonitorNotificationConfigType())
+			return
+		}
+
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, conf.ToMonitorNotificationConfigType())
+}
@@ -58,6 +58,7 @@ func (p *CollaboratorsListHandler) ServeHTTP(w http.ResponseWriter, r *http.Requ
 			UserID:    roleMap[user.ID].UserID,
 			Email:     user.Email,
 			ProjectID: roleMap[user.ID].ProjectID,
+			PolicyUID: roleMap[user.ID].PolicyUID,
 		})
 	}
 
@@ -0,0 +1,71 @@
+package project
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
+)
+
+type MonitorNotificationsUpdateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewMonitorNotificationsUpdateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *MonitorNotificationsUpdateHandler {
+	return &MonitorNotificationsUpdateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *MonitorNotificationsUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.UpdateMonitorNotificationConfigRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	conf, err := p.Repo().MonitorNotificationConfig().ReadMonitorNotificationConfigByProjectID(proj.ID)
+	isNotFound := errors.Is(err, gorm.ErrRecordNotFound)
+
+	if err != nil && !isNotFound {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if isNotFound {
+		conf = &models.MonitorNotificationConfig{
+			ProjectID: proj.ID,
+		}
+	}
+
+	conf.Enabled = request.Enabled
+	conf.Email = request.Email
+	conf.MinSeverity = request.MinSeverity
+	conf.MutedCategories = strings.Join(request.MutedCategories, ",")
+
+	if isNotFound {
+		conf, err = p.Repo().MonitorNotificationConfig().CreateMonitorNotificationConfig(conf)
+	} else {
+		conf, err = p.Repo().MonitorNotificationConfig().UpdateMonitorNotificationConfig(conf)
+	}
+
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	p.WriteResult(w, r, conf.ToMonitorNotificationConfigType())
+}
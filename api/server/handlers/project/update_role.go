@@ -1,6 +1,8 @@
 package project
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 
 	"github.com/porter-dev/porter/api/server/handlers"
@@ -9,6 +11,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"gorm.io/gorm"
 )
 
 type RoleUpdateHandler struct {
@@ -43,6 +46,44 @@ func (p *RoleUpdateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	}
 
 	role.Kind = types.RoleKind(request.Kind)
+	role.PolicyUID = ""
+
+	switch role.Kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+	case types.RoleCustom:
+		if request.PolicyUID == "" {
+			p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("policy_uid is required for custom roles"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+
+		// custom roles can only be assigned policies which are stored in the project
+		if _, err := p.Repo().Policy().ReadPolicy(proj.ID, request.PolicyUID); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+					fmt.Errorf("policy %s not found in project", request.PolicyUID),
+					http.StatusBadRequest,
+				))
+
+				return
+			}
+
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		role.PolicyUID = request.PolicyUID
+	default:
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("role kind %s is not supported", request.Kind),
+			http.StatusBadRequest,
+		))
+
+		return
+	}
 
 	role, err = p.Repo().Project().UpdateProjectRole(proj.ID, role)
 
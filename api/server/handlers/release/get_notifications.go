@@ -47,6 +47,7 @@ func (c *GetNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 			Enabled: true,
 			Success: true,
 			Failure: true,
+			Events:  []string{},
 		},
 	}
 
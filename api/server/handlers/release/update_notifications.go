@@ -1,7 +1,10 @@
 package release
 
 import (
+	"fmt"
 	"net/http"
+	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
@@ -38,6 +41,17 @@ func (c *UpdateNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if request.Payload.NotifLimit != "" {
+		if limit, err := time.ParseDuration(request.Payload.NotifLimit); err != nil || limit < 0 {
+			c.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+				fmt.Errorf("notif_limit must be a duration such as 30m"),
+				http.StatusBadRequest,
+			))
+
+			return
+		}
+	}
+
 	release, err := c.Repo().Release().ReadRelease(cluster.ID, name, namespace)
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
@@ -46,6 +60,7 @@ func (c *UpdateNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		}
 
 		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
 	}
 
 	// either create a new notification config or update the current one
@@ -53,6 +68,9 @@ func (c *UpdateNotificationHandler) ServeHTTP(w http.ResponseWriter, r *http.Req
 		Enabled: request.Payload.Enabled,
 		Success: request.Payload.Success,
 		Failure: request.Payload.Failure,
+		Events:  strings.Join(request.Payload.Events, ","),
+
+		NotifLimit: request.Payload.NotifLimit,
 	}
 
 	if release.NotificationConfig == 0 {
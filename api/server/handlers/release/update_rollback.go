@@ -3,15 +3,18 @@ package release
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 
 	semver "github.com/Masterminds/semver/v3"
 	"github.com/porter-dev/porter/api/server/authz"
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/stefanmcshane/helm/pkg/release"
 )
 
@@ -59,11 +62,38 @@ func (c *RollbackReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
+
+	if !cluster.NotificationsDisabled {
+		notifConf, err := commonutils.GetNotificationConfig(c.Config(), rel)
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
+		commonutils.GetDeploymentNotifier(c.Config(), cluster.ProjectID, notifConf).Notify(&notifier.NotifyOpts{
+			ProjectID:   cluster.ProjectID,
+			ClusterID:   cluster.ID,
+			ClusterName: cluster.Name,
+			Status:      notifier.StatusRollback,
+			Info:        fmt.Sprintf("rolled back to version %d", request.Revision),
+			Name:        helmRelease.Name,
+			Namespace:   helmRelease.Namespace,
+			URL: fmt.Sprintf(
+				"%s/applications/%s/%s/%s?project_id=%d",
+				c.Config().ServerConf.ServerURL,
+				url.PathEscape(cluster.Name),
+				helmRelease.Namespace,
+				helmRelease.Name,
+				cluster.ProjectID,
+			),
+			Version: request.Revision,
+		})
+	}
+
 	// update the github actions env if the release exists and is built from source
 	if cName := helmRelease.Chart.Metadata.Name; cName == "job" || cName == "web" || cName == "worker" {
-		rel, err := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
-
-		if err == nil && rel != nil {
+		if releaseErr == nil && rel != nil {
 			err = UpdateReleaseRepo(c.Config(), rel, helmRelease)
 
 			if err != nil {
@@ -11,12 +11,12 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/slack"
 	"github.com/porter-dev/porter/internal/stacks"
 	"github.com/stefanmcshane/helm/pkg/release"
 )
@@ -41,6 +41,7 @@ func NewUpgradeReleaseHandler(
 
 func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	proj, _ := r.Context().Value(types.ProjectScope).(*models.Project)
 	cluster, _ := r.Context().Value(types.ClusterScope).(*models.Cluster)
 	helmRelease, _ := r.Context().Value(types.ReleaseScope).(*release.Release)
 
@@ -62,11 +63,18 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	validator, err := commonutils.GetReleaseValidator(c.Config(), proj, cluster)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	conf := &helm.UpgradeReleaseConfig{
 		Name:       helmRelease.Name,
 		Cluster:    cluster,
 		Repo:       c.Repo(),
 		Registries: registries,
+		Validator:  validator,
 	}
 
 	// if the chart version is set, load a chart from the repo
@@ -160,8 +168,6 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		helmRelease = newHelmRelease
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(cluster.ProjectID)
-
 	rel, releaseErr := c.Repo().Release().ReadRelease(cluster.ID, helmRelease.Name, helmRelease.Namespace)
 
 	var notifConf *types.NotificationConfig
@@ -176,7 +182,7 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	deplNotifier := commonutils.GetDeploymentNotifier(c.Config(), cluster.ProjectID, notifConf)
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   cluster.ProjectID,
@@ -210,6 +216,8 @@ func (c *UpgradeReleaseHandler) ServeHTTP(w http.ResponseWriter, r *http.Request
 		return
 	}
 
+	commonutils.SetReleaseValidatorWarnings(w, validator)
+
 	if helmRelease.Chart != nil && helmRelease.Chart.Metadata.Name != "job" {
 		notifyOpts.Status = notifier.StatusHelmDeployed
 		notifyOpts.Version = helmRelease.Version
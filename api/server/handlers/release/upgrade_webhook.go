@@ -9,13 +9,13 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/helm"
 	"github.com/porter-dev/porter/internal/notifier"
-	"github.com/porter-dev/porter/internal/notifier/slack"
 	"gorm.io/gorm"
 )
 
@@ -128,16 +128,27 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	project, err := c.Repo().Project().ReadProject(release.ProjectID)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	validator, err := commonutils.GetReleaseValidator(c.Config(), project, cluster)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	conf := &helm.UpgradeReleaseConfig{
 		Name:       release.Name,
 		Cluster:    cluster,
 		Repo:       c.Repo(),
 		Registries: registries,
 		Values:     rel.Config,
+		Validator:  validator,
 	}
 
-	slackInts, _ := c.Repo().SlackIntegration().ListSlackIntegrationsByProjectID(release.ProjectID)
-
 	var notifConf *types.NotificationConfig
 	notifConf = nil
 	if release != nil && release.NotificationConfig != 0 {
@@ -150,7 +161,7 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		notifConf = conf.ToNotificationConfigType()
 	}
 
-	deplNotifier := slack.NewDeploymentNotifier(notifConf, slackInts...)
+	deplNotifier := commonutils.GetDeploymentNotifier(c.Config(), release.ProjectID, notifConf)
 
 	notifyOpts := &notifier.NotifyOpts{
 		ProjectID:   release.ProjectID,
@@ -207,6 +218,8 @@ func (c *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		),
 	}))
 
+	commonutils.SetReleaseValidatorWarnings(w, validator)
+
 	c.WriteResult(w, r, nil)
 
 	err = postUpgrade(c.Config(), cluster.ProjectID, cluster.ID, rel)
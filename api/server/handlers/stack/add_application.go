@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -125,6 +126,12 @@ func (p *StackAddApplicationHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 		return
 	}
 
+	validator, err := commonutils.GetReleaseValidator(p.Config(), proj, cluster)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	helmAgent, err := p.GetHelmAgent(r, cluster, "")
 	if err != nil {
 		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -142,6 +149,7 @@ func (p *StackAddApplicationHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 			namespace:     namespace,
 			cluster:       cluster,
 			registries:    registries,
+			validator:     validator,
 			helmAgent:     helmAgent,
 			request:       req,
 			stackName:     stack.Name,
@@ -203,4 +211,6 @@ func (p *StackAddApplicationHandler) ServeHTTP(w http.ResponseWriter, r *http.Re
 			return
 		}
 	}
+
+	commonutils.SetReleaseValidatorWarnings(w, validator)
 }
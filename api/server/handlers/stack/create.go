@@ -10,6 +10,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers/release"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/encryption"
@@ -171,6 +172,12 @@ func (p *StackCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 
+		validator, err := commonutils.GetReleaseValidator(p.Config(), proj, cluster)
+		if err != nil {
+			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+			return
+		}
+
 		helmAgent, err := p.GetHelmAgent(r, cluster, "")
 		if err != nil {
 			p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
@@ -188,6 +195,7 @@ func (p *StackCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				namespace:     namespace,
 				cluster:       cluster,
 				registries:    registries,
+				validator:     validator,
 				helmAgent:     helmAgent,
 				request:       appResource,
 				stackName:     stack.Name,
@@ -240,6 +248,8 @@ func (p *StackCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 		}
+
+		commonutils.SetReleaseValidatorWarnings(w, validator)
 	}
 
 	if revision.Status != string(types.StackRevisionStatusFailed) && len(revision.Reason) == 0 {
@@ -17,6 +17,7 @@ type applyAppResourceOpts struct {
 	helmAgent  *helm.Agent
 	request    *types.CreateStackAppResourceRequest
 	registries []*models.Registry
+	validator  helm.ReleaseValidator
 
 	// stack related info
 	stackName     string
@@ -41,6 +42,7 @@ func applyAppResource(opts *applyAppResourceOpts) (*release.Release, error) {
 		Cluster:    opts.cluster,
 		Repo:       opts.config.Repo,
 		Registries: opts.registries,
+		Validator:  opts.validator,
 	}
 
 	if conf.Values == nil {
@@ -74,6 +76,7 @@ type updateAppResourceTagOpts struct {
 	namespace  string
 	cluster    *models.Cluster
 	registries []*models.Registry
+	validator  helm.ReleaseValidator
 
 	// stack related info
 	stackName     string
@@ -98,6 +101,7 @@ func updateAppResourceTag(opts *updateAppResourceTagOpts) error {
 		Repo:       opts.config.Repo,
 		Registries: opts.registries,
 		Values:     rel.Config,
+		Validator:  opts.validator,
 
 		// stack related info
 		StackName:     opts.stackName,
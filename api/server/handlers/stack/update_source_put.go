@@ -9,6 +9,7 @@ import (
 	"github.com/porter-dev/porter/api/server/handlers"
 	"github.com/porter-dev/porter/api/server/shared"
 	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
 	"github.com/porter-dev/porter/api/server/shared/config"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
@@ -91,6 +92,12 @@ func (p *StackPutSourceConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	validator, err := commonutils.GetReleaseValidator(p.Config(), proj, cluster)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
 	deployErrs := make([]string, 0)
 
 	// read the stack again to get the latest revision info
@@ -122,6 +129,7 @@ func (p *StackPutSourceConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 			namespace:     namespace,
 			cluster:       cluster,
 			registries:    registries,
+			validator:     validator,
 			stackName:     stack.Name,
 			stackRevision: stack.Revisions[0].RevisionNumber,
 		})
@@ -158,5 +166,7 @@ func (p *StackPutSourceConfigHandler) ServeHTTP(w http.ResponseWriter, r *http.R
 		return
 	}
 
+	commonutils.SetReleaseValidatorWarnings(w, validator)
+
 	p.WriteResult(w, r, stack.ToStackType())
 }
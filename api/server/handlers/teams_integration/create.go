@@ -0,0 +1,77 @@
+package teams_integration
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type TeamsIntegrationCreateHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewTeamsIntegrationCreateHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *TeamsIntegrationCreateHandler {
+	return &TeamsIntegrationCreateHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *TeamsIntegrationCreateHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	user, _ := r.Context().Value(types.UserScope).(*models.User)
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+
+	request := &types.CreateTeamsIntegrationRequest{}
+
+	if ok := p.DecodeAndValidate(w, r, request); !ok {
+		return
+	}
+
+	if err := validateWebhookURL(request.Webhook); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(err, http.StatusBadRequest))
+		return
+	}
+
+	teamsInt, err := p.Repo().TeamsIntegration().CreateTeamsIntegration(&ints.TeamsIntegration{
+		UserID:    user.ID,
+		ProjectID: project.ID,
+		Channel:   request.Channel,
+		Webhook:   []byte(request.Webhook),
+	})
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	w.WriteHeader(http.StatusCreated)
+
+	p.WriteResult(w, r, teamsInt.ToTeamsIntegrationType())
+}
+
+// validateWebhookURL only accepts the incoming webhook URLs issued by Microsoft Teams or Power Automate, so that
+// notifications cannot be used to send requests to arbitrary hosts
+func validateWebhookURL(webhook string) error {
+	u, err := url.Parse(webhook)
+	if err != nil {
+		return fmt.Errorf("webhook is not a valid URL")
+	}
+
+	if u.Scheme != "https" || !(strings.HasSuffix(u.Hostname(), ".webhook.office.com") ||
+		strings.HasSuffix(u.Hostname(), ".logic.azure.com")) {
+		return fmt.Errorf("webhook must be a Microsoft Teams incoming webhook URL")
+	}
+
+	return nil
+}
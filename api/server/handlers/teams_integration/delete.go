@@ -0,0 +1,56 @@
+package teams_integration
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type TeamsIntegrationDeleteHandler struct {
+	handlers.PorterHandler
+}
+
+func NewTeamsIntegrationDeleteHandler(
+	config *config.Config,
+) *TeamsIntegrationDeleteHandler {
+	return &TeamsIntegrationDeleteHandler{
+		PorterHandler: handlers.NewDefaultPorterHandler(config, nil, nil),
+	}
+}
+
+func (p *TeamsIntegrationDeleteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	project, _ := r.Context().Value(types.ProjectScope).(*models.Project)
+	integrationID, reqErr := requestutils.GetURLParamUint(r, types.URLParamTeamsIntegrationID)
+
+	if reqErr != nil {
+		p.HandleAPIError(w, r, reqErr)
+		return
+	}
+
+	teamsInts, err := p.Repo().TeamsIntegration().ListTeamsIntegrationsByProjectID(project.ID)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	for _, teamsInt := range teamsInts {
+		if teamsInt.ID == integrationID {
+			err = p.Repo().TeamsIntegration().DeleteTeamsIntegration(teamsInt.ID)
+			if err != nil {
+				p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+				return
+			}
+
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+	}
+
+	p.HandleAPIError(w, r, apierrors.NewErrNotFound(fmt.Errorf("teams integration %d not found", integrationID)))
+}
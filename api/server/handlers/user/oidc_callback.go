@@ -0,0 +1,302 @@
+package user
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/server/authn"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/analytics"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+// errOIDCLoginRejected is returned for identities which are not allowed to log in, and whose
+// message is shown on the login page
+type errOIDCLoginRejected struct {
+	msg string
+}
+
+func (e *errOIDCLoginRejected) Error() string {
+	return e.msg
+}
+
+type UserOAuthOIDCCallbackHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCCallbackHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCCallbackHandler {
+	return &UserOAuthOIDCCallbackHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCCallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	oidcConf := p.Config().OIDCConf
+
+	if oidcConf == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("OIDC login is not configured"),
+			http.StatusNotFound,
+		))
+
+		return
+	}
+
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	if _, ok := session.Values["state"]; !ok {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state not found in session")))
+		return
+	}
+
+	if r.URL.Query().Get("state") != session.Values["state"] {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("state does not match")))
+		return
+	}
+
+	if errMsg := r.URL.Query().Get("error"); errMsg != "" {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(fmt.Sprintf("Login failed: %s", errMsg)), 302)
+		return
+	}
+
+	nonce, _ := session.Values["oidc_nonce"].(string)
+
+	if nonce == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("nonce not found in session")))
+		return
+	}
+
+	// the nonce can only be used once
+	delete(session.Values, "oidc_nonce")
+
+	if err := session.Save(r, w); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	oauthConf, err := oidcConf.OAuth2Config(r.Context())
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	token, err := oauthConf.Exchange(r.Context(), r.URL.Query().Get("code"))
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+
+	if !ok || rawIDToken == "" {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("token response has no id_token")))
+		return
+	}
+
+	identity, err := oidcConf.VerifyIDToken(r.Context(), rawIDToken, nonce)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrForbidden(err))
+		return
+	}
+
+	user, err := upsertOIDCUser(p.Config(), identity)
+
+	var rejectedErr *errOIDCLoginRejected
+
+	if err != nil && errors.As(err, &rejectedErr) {
+		http.Redirect(w, r, "/login?error="+url.QueryEscape(err.Error()), 302)
+		return
+	} else if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// project roles are granted from the group claims on every login, so that changes made in
+	// the provider are applied the next time the user logs in
+	applyOIDCRoleMappings(p.Config(), user, identity.Groups)
+
+	p.Config().AnalyticsClient.Identify(analytics.CreateSegmentIdentifyUser(user))
+
+	// save the user as authenticated in the session
+	redirect, err := authn.SaveUserAuthenticated(w, r, p.Config(), user)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// non-fatal send email verification
+	if !user.EmailVerified {
+		err = startEmailVerification(p.Config(), w, r, user)
+
+		if err != nil {
+			p.HandleAPIErrorNoWrite(w, r, apierrors.NewErrInternal(err))
+		}
+	}
+
+	if redirect != "" {
+		http.Redirect(w, r, redirect, http.StatusFound)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard", 302)
+}
+
+// upsertOIDCUser returns the user of the OIDC identity, and creates the user on their first login
+func upsertOIDCUser(config *config.Config, identity *oauth.OIDCIdentity) (*models.User, error) {
+	// the email restriction can only be applied to emails which the provider has verified, since
+	// the email claim may otherwise be set to any address by the user
+	if config.ServerConf.AdminEmail != "" && !identity.EmailVerified {
+		return nil, &errOIDCLoginRejected{"Your email address has not been verified by the identity provider."}
+	}
+
+	if err := checkUserRestrictions(config.ServerConf, identity.Email); err != nil {
+		return nil, &errOIDCLoginRejected{err.Error()}
+	}
+
+	if group := config.ServerConf.OIDCRestrictedGroup; group != "" && !hasOIDCGroup(identity.Groups, group) {
+		return nil, &errOIDCLoginRejected{"You are not a member of the group which is allowed to log in."}
+	}
+
+	user, err := config.Repo.User().ReadUserByOIDCUserID(identity.Subject)
+
+	if err == nil {
+		return user, nil
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("unexpected error occurred: %w", err)
+	}
+
+	// check if a user with that email address already exists
+	user, err = config.Repo.User().ReadUserByEmail(identity.Email)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		user = &models.User{
+			Email:         identity.Email,
+			EmailVerified: !config.Metadata.Email || identity.EmailVerified,
+			OIDCUserID:    identity.Subject,
+		}
+
+		user, err = config.Repo.User().CreateUser(user)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := addUserToDefaultProject(config, user); err != nil {
+			return nil, err
+		}
+
+		return user, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	// existing users are only linked to the identity if the operator allows it, and the provider
+	// has verified that the email belongs to the user
+	if !config.OIDCConf.LinkExistingUsers || !identity.EmailVerified || user.OIDCUserID != "" {
+		return nil, &errOIDCLoginRejected{"email already registered"}
+	}
+
+	user.OIDCUserID = identity.Subject
+	user.EmailVerified = true
+
+	return config.Repo.User().UpdateUser(user)
+}
+
+// applyOIDCRoleMappings assigns the roles which are mapped to the groups of the user. When several
+// groups map to the same project, the first mapping wins. Roles are granted and updated, but are
+// not removed when the user leaves a group. Errors are logged, since they should not prevent
+// the user from logging in.
+func applyOIDCRoleMappings(config *config.Config, user *models.User, groups []string) {
+	appliedProjects := make(map[uint]bool)
+
+	for _, mapping := range config.OIDCConf.RoleMappings {
+		if appliedProjects[mapping.ProjectID] || !hasOIDCGroup(groups, mapping.Group) {
+			continue
+		}
+
+		appliedProjects[mapping.ProjectID] = true
+
+		if err := applyOIDCRoleMapping(config, user, mapping); err != nil {
+			config.Logger.Warn().Msgf(
+				"could not assign role %s in project %d to user %d from OIDC group %s: %v",
+				mapping.Role, mapping.ProjectID, user.ID, mapping.Group, err,
+			)
+		}
+	}
+}
+
+func applyOIDCRoleMapping(config *config.Config, user *models.User, mapping *oauth.OIDCRoleMapping) error {
+	project, err := config.Repo.Project().ReadProject(mapping.ProjectID)
+	if err != nil {
+		return err
+	}
+
+	kind := types.RoleKind(mapping.Role)
+	policyUID := ""
+
+	switch kind {
+	case types.RoleAdmin, types.RoleDeveloper, types.RoleViewer:
+	default:
+		// any other role is the id of a policy, which is assigned as a custom role
+		if _, err := config.Repo.Policy().ReadPolicy(project.ID, mapping.Role); err != nil {
+			return fmt.Errorf("policy %s not found in project: %w", mapping.Role, err)
+		}
+
+		kind = types.RoleCustom
+		policyUID = mapping.Role
+	}
+
+	role, err := config.Repo.Project().ReadProjectRole(project.ID, user.ID)
+
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		_, err = config.Repo.Project().CreateProjectRole(project, &models.Role{
+			Role: types.Role{
+				UserID:    user.ID,
+				ProjectID: project.ID,
+				Kind:      kind,
+				PolicyUID: policyUID,
+			},
+		})
+
+		return err
+	} else if err != nil {
+		return err
+	}
+
+	if role.Kind == kind && role.PolicyUID == policyUID {
+		return nil
+	}
+
+	role.Kind = kind
+	role.PolicyUID = policyUID
+
+	_, err = config.Repo.Project().UpdateProjectRole(project.ID, role)
+
+	return err
+}
+
+func hasOIDCGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if g == group {
+			return true
+		}
+	}
+
+	return false
+}
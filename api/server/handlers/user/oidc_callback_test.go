@@ -0,0 +1,61 @@
+package user
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpsertOIDCUserAdminEmail(t *testing.T) {
+	tests := []struct {
+		description   string
+		email         string
+		emailVerified bool
+		expRejected   bool
+	}{
+		{
+			description:   "verified admin email is allowed",
+			email:         "admin@getporter.dev",
+			emailVerified: true,
+		},
+		{
+			description:   "unverified admin email is rejected",
+			email:         "admin@getporter.dev",
+			emailVerified: false,
+			expRejected:   true,
+		},
+		{
+			description:   "verified email which is not the admin email is rejected",
+			email:         "other@getporter.dev",
+			emailVerified: true,
+			expRejected:   true,
+		},
+	}
+
+	for _, test := range tests {
+		conf := apitest.LoadConfig(t)
+		conf.Metadata = &config.Metadata{}
+		conf.ServerConf.AdminEmail = "admin@getporter.dev"
+
+		user, err := upsertOIDCUser(conf, &oauth.OIDCIdentity{
+			Subject:       "subject",
+			Email:         test.email,
+			EmailVerified: test.emailVerified,
+		})
+
+		var rejectedErr *errOIDCLoginRejected
+
+		if test.expRejected {
+			assert.True(t, errors.As(err, &rejectedErr), "[ %s ]: expected login to be rejected, got %v", test.description, err)
+			continue
+		}
+
+		if assert.NoError(t, err, test.description) {
+			assert.Equal(t, test.email, user.Email, test.description)
+		}
+	}
+}
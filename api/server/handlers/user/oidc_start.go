@@ -0,0 +1,71 @@
+package user
+
+import (
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/oauth"
+)
+
+type UserOAuthOIDCHandler struct {
+	handlers.PorterHandlerReadWriter
+}
+
+func NewUserOAuthOIDCHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *UserOAuthOIDCHandler {
+	return &UserOAuthOIDCHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+	}
+}
+
+func (p *UserOAuthOIDCHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if p.Config().OIDCConf == nil {
+		p.HandleAPIError(w, r, apierrors.NewErrPassThroughToClient(
+			fmt.Errorf("OIDC login is not configured"),
+			http.StatusNotFound,
+		))
+
+		return
+	}
+
+	oauthConf, err := p.Config().OIDCConf.OAuth2Config(r.Context())
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	state := oauth.CreateRandomState()
+
+	if err := p.PopulateOAuthSession(w, r, state, false, false, "", 0); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	// the nonce binds the ID token to this login attempt, and is checked in the callback
+	session, err := p.Config().Store.Get(r, p.Config().ServerConf.CookieName)
+	if err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	nonce := oauth.CreateRandomState()
+	session.Values["oidc_nonce"] = nonce
+
+	if err := session.Save(r, w); err != nil {
+		p.HandleAPIError(w, r, apierrors.NewErrInternal(err))
+		return
+	}
+
+	url := oauthConf.AuthCodeURL(state, oauth2.SetAuthURLParam("nonce", nonce))
+
+	http.Redirect(w, r, url, 302)
+}
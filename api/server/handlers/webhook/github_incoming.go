@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/bradleyfalzon/ghinstallation/v2"
 	"github.com/google/go-github/v41/github"
@@ -19,6 +20,7 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/requestutils"
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
 )
 
@@ -145,6 +147,7 @@ func (c *GithubIncomingWebhookHandler) processPullRequestEvent(event *github.Pul
 			CommitSHA:     event.GetPullRequest().GetHead().GetSHA()[:7],
 			PRBranchFrom:  event.GetPullRequest().GetHead().GetRef(),
 			PRBranchInto:  event.GetPullRequest().GetBase().GetRef(),
+			LastPushedAt:  time.Now(),
 		}
 
 		_, err = c.Repo().Environment().CreateDeployment(depl)
@@ -184,7 +187,14 @@ func (c *GithubIncomingWebhookHandler) processPullRequestEvent(event *github.Pul
 		}
 
 		if event.GetAction() == "synchronize" {
-			_, err := client.Actions.CreateWorkflowDispatchEventByFileName(
+			err := c.recordPush(r, env, depl)
+			if err != nil {
+				return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, prNumber: %d] "+
+					"error recording push to deployment: %w", webhookID, owner, repo, env.ID, depl.ID,
+					event.GetPullRequest().GetNumber(), err)
+			}
+
+			_, err = client.Actions.CreateWorkflowDispatchEventByFileName(
 				r.Context(), owner, repo, fmt.Sprintf("porter_%s_env.yml", env.Name),
 				github.CreateWorkflowDispatchEventRequest{
 					Ref: event.GetPullRequest().GetHead().GetRef(),
@@ -398,6 +408,7 @@ func (c *GithubIncomingWebhookHandler) processPushEvent(event *github.PushEvent,
 			CommitSHA:     event.GetAfter()[:7],
 			PRBranchFrom:  branch,
 			PRBranchInto:  branch,
+			LastPushedAt:  time.Now(),
 		})
 		if err != nil {
 			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, branch: %s] "+
@@ -410,6 +421,12 @@ func (c *GithubIncomingWebhookHandler) processPushEvent(event *github.PushEvent,
 			"error reading deployment: %w", webhookID, owner, repo, env.ID, branch, err)
 	} else {
 		deplID = depl.ID
+
+		err = c.recordPush(r, env, depl)
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, branch: %s] "+
+				"error recording push to deployment: %w", webhookID, owner, repo, env.ID, depl.ID, branch, err)
+		}
 	}
 
 	// FIXME: we should case on if env mode is auto or manual
@@ -434,6 +451,49 @@ func (c *GithubIncomingWebhookHandler) processPushEvent(event *github.PushEvent,
 	return nil
 }
 
+func (c *GithubIncomingWebhookHandler) recordPush(
+	r *http.Request,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	return recordPush(r, c.Repo(), c.KubernetesAgentGetter, env, depl)
+}
+
+// recordPush updates the time of the last push to the deployment, and wakes up the deployment
+// if it was put to sleep because it was idle
+func recordPush(
+	r *http.Request,
+	repo repository.Repository,
+	agentGetter authz.KubernetesAgentGetter,
+	env *models.Environment,
+	depl *models.Deployment,
+) error {
+	if depl.IsSleeping && depl.Namespace != "" {
+		cluster, err := repo.Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+		if err != nil {
+			return fmt.Errorf("error reading cluster: %w", err)
+		}
+
+		agent, err := agentGetter.GetAgent(r, cluster, "")
+		if err != nil {
+			return err
+		}
+
+		err = agent.WakeNamespace(depl.Namespace)
+
+		if err != nil {
+			return fmt.Errorf("error waking up namespace '%s': %w", depl.Namespace, err)
+		}
+	}
+
+	depl.IsSleeping = false
+	depl.LastPushedAt = time.Now()
+
+	_, err := repo.Environment().UpdateDeployment(depl)
+
+	return err
+}
+
 func isSystemNamespace(namespace string) bool {
 	return namespace == "cert-manager" || namespace == "ingress-nginx" ||
 		namespace == "kube-node-lease" || namespace == "kube-public" ||
@@ -0,0 +1,271 @@
+package webhook
+
+import (
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apierrors"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/xanzy/go-gitlab"
+	"gorm.io/gorm"
+)
+
+type GitlabIncomingWebhookHandler struct {
+	handlers.PorterHandlerReadWriter
+	authz.KubernetesAgentGetter
+}
+
+func NewGitlabIncomingWebhookHandler(
+	config *config.Config,
+	decoderValidator shared.RequestDecoderValidator,
+	writer shared.ResultWriter,
+) *GitlabIncomingWebhookHandler {
+	return &GitlabIncomingWebhookHandler{
+		PorterHandlerReadWriter: handlers.NewDefaultPorterHandler(config, decoderValidator, writer),
+		KubernetesAgentGetter:   authz.NewOutOfClusterAgentGetter(config),
+	}
+}
+
+func (c *GitlabIncomingWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token := r.Header.Get("X-Gitlab-Token")
+	secret := c.Config().ServerConf.GitlabIncomingWebhookSecret
+
+	// webhooks are rejected when no secret is configured, since an empty token would match it
+	if secret == "" || subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+		c.HandleAPIError(w, r, apierrors.NewErrForbidden(fmt.Errorf("invalid gitlab webhook token")))
+		return
+	}
+
+	payload, err := io.ReadAll(r.Body)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error reading webhook payload: %w", err)))
+		return
+	}
+
+	event, err := gitlab.ParseWebhook(gitlab.HookEventType(r), payload)
+	if err != nil {
+		c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error parsing webhook: %w", err)))
+		return
+	}
+
+	switch event := event.(type) {
+	case *gitlab.MergeEvent:
+		err = c.processMergeRequestEvent(event, r)
+
+		if err != nil {
+			c.HandleAPIError(w, r, apierrors.NewErrInternal(fmt.Errorf("error processing merge request webhook event: %w", err)))
+			return
+		}
+	}
+}
+
+func (c *GitlabIncomingWebhookHandler) processMergeRequestEvent(event *gitlab.MergeEvent, r *http.Request) error {
+	// get the webhook id from the request
+	webhookID, reqErr := requestutils.GetURLParamString(r, types.URLParamIncomingWebhookID)
+
+	if reqErr != nil {
+		return fmt.Errorf(reqErr.Error())
+	}
+
+	// the path of a project is its namespace path followed by the project path
+	pathWithNamespace := event.Project.PathWithNamespace
+	idx := strings.LastIndex(pathWithNamespace, "/")
+
+	if idx < 0 {
+		return fmt.Errorf("[webhookID: %s] invalid project path '%s'", webhookID, pathWithNamespace)
+	}
+
+	owner := pathWithNamespace[:idx]
+	repo := pathWithNamespace[idx+1:]
+
+	env, err := c.Repo().Environment().ReadEnvironmentByWebhookIDOwnerRepoName(webhookID, owner, repo)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s] error reading environment: %w", webhookID, owner, repo, err)
+	}
+
+	mr := event.ObjectAttributes
+
+	envType := env.ToEnvironmentType()
+
+	if len(envType.GitRepoBranches) > 0 {
+		found := false
+
+		for _, br := range envType.GitRepoBranches {
+			if br == mr.SourceBranch {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return nil
+		}
+	}
+
+	if env.Mode == "auto" && (mr.Action == "open" || mr.Action == "reopen") {
+		// the merge request pipeline is started by GitLab, so we only have to create the deployment
+		// for it to show up in the dashboard until the pipeline reports back
+		_, err := c.Repo().Environment().ReadDeploymentByGitDetails(env.ID, owner, repo, uint(mr.IID))
+
+		if err == nil {
+			return nil
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrIID: %d] "+
+				"error reading deployment: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+		}
+
+		commitSHA := mr.LastCommit.ID
+
+		if len(commitSHA) > 7 {
+			commitSHA = commitSHA[:7]
+		}
+
+		_, err = c.Repo().Environment().CreateDeployment(&models.Deployment{
+			EnvironmentID: env.ID,
+			Namespace:     "",
+			Status:        types.DeploymentStatusCreating,
+			PullRequestID: uint(mr.IID),
+			PRName:        mr.Title,
+			RepoName:      repo,
+			RepoOwner:     owner,
+			CommitSHA:     commitSHA,
+			PRBranchFrom:  mr.SourceBranch,
+			PRBranchInto:  mr.TargetBranch,
+			LastPushedAt:  time.Now(),
+		})
+
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrIID: %d] "+
+				"error creating new deployment: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+		}
+
+		return nil
+	} else if mr.Action != "update" && mr.Action != "close" && mr.Action != "merge" {
+		return nil
+	}
+
+	depl, err := c.Repo().Environment().ReadDeploymentByGitDetails(env.ID, owner, repo, uint(mr.IID))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, mrIID: %d] "+
+			"error reading deployment: %w", webhookID, owner, repo, env.ID, mr.IID, err)
+	}
+
+	if depl.Status == types.DeploymentStatusInactive {
+		return nil
+	}
+
+	if mr.Action == "close" || mr.Action == "merge" {
+		err = c.deleteDeployment(r, depl, env)
+
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrIID: %d] "+
+				"error deleting deployment: %w", webhookID, owner, repo, env.ID, depl.ID, mr.IID, err)
+		}
+
+		return nil
+	}
+
+	// "update" events with an old revision are sent when new commits are pushed to the merge request
+	if mr.OldRev != "" {
+		err = recordPush(r, c.Repo(), c.KubernetesAgentGetter, env, depl)
+
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrIID: %d] "+
+				"error recording push to deployment: %w", webhookID, owner, repo, env.ID, depl.ID, mr.IID, err)
+		}
+	}
+
+	shouldUpdate := false
+
+	if mr.Title != depl.PRName {
+		depl.PRName = mr.Title
+		shouldUpdate = true
+	}
+
+	if mr.TargetBranch != depl.PRBranchInto {
+		depl.PRBranchInto = mr.TargetBranch
+		shouldUpdate = true
+	}
+
+	if shouldUpdate {
+		_, err := c.Repo().Environment().UpdateDeployment(depl)
+		if err != nil {
+			return fmt.Errorf("[webhookID: %s, owner: %s, repo: %s, environmentID: %d, deploymentID: %d, mrIID: %d] "+
+				"error updating deployment to reflect changes in the merge request %w", webhookID, owner, repo, env.ID,
+				depl.ID, mr.IID, err)
+		}
+	}
+
+	return nil
+}
+
+func (c *GitlabIncomingWebhookHandler) deleteDeployment(
+	r *http.Request,
+	depl *models.Deployment,
+	env *models.Environment,
+) error {
+	// cancel the pipelines still running for the merge request, as a best-effort operation
+	if client, _, err := commonutils.GetGitlabClientFromEnvironment(c.Config(), env); err == nil {
+		pID := fmt.Sprintf("%s/%s", env.GitRepoOwner, env.GitRepoName)
+
+		pipelines, _, err := client.MergeRequests.ListMergeRequestPipelines(pID, int(depl.PullRequestID))
+
+		if err == nil {
+			for _, pipeline := range pipelines {
+				if pipeline.Status == "running" || pipeline.Status == "pending" || pipeline.Status == "created" {
+					client.Pipelines.CancelPipelineBuild(pID, pipeline.ID)
+				}
+			}
+		}
+	}
+
+	cluster, err := c.Repo().Cluster().ReadCluster(env.ProjectID, env.ClusterID)
+	if err != nil {
+		return fmt.Errorf("[projectID: %d, clusterID: %d] error reading cluster when deleting existing deployment: %w",
+			env.ProjectID, env.ClusterID, err)
+	}
+
+	agent, err := c.GetAgent(r, cluster, "")
+	if err != nil {
+		return err
+	}
+
+	// make sure we do not delete any kubernetes "system" namespaces
+	if depl.Namespace != "" && !isSystemNamespace(depl.Namespace) {
+		err = agent.DeleteNamespace(depl.Namespace)
+
+		if err != nil {
+			return fmt.Errorf("[owner: %s, repo: %s, environmentID: %d, deploymentID: %d] error deleting namespace '%s': %w",
+				env.GitRepoOwner, env.GitRepoName, env.ID, depl.ID, depl.Namespace, err)
+		}
+	}
+
+	_, err = c.Repo().Environment().DeleteDeployment(depl)
+
+	if err != nil {
+		return fmt.Errorf("[owner: %s, repo: %s, environmentID: %d, deploymentID: %d] error deleting deployment: %w",
+			env.GitRepoOwner, env.GitRepoName, env.ID, depl.ID, err)
+	}
+
+	return nil
+}
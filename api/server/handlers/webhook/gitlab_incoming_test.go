@@ -0,0 +1,284 @@
+package webhook_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/authz"
+	"github.com/porter-dev/porter/api/server/handlers/webhook"
+	"github.com/porter-dev/porter/api/server/shared"
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	testGitlabWebhookSecret = "gitlab-secret"
+	testGitlabWebhookID     = "webhook-id"
+)
+
+// gitlabFixture is a project with a cluster and an environment for the acme/api repository,
+// which has a deployment for merge request 7 in namespace pr-7
+type gitlabFixture struct {
+	config *config.Config
+	env    *models.Environment
+	depl   *models.Deployment
+	agent  *kubernetes.Agent
+}
+
+func newGitlabFixture(t *testing.T) *gitlabFixture {
+	config := apitest.LoadConfig(t)
+	config.ServerConf.GitlabIncomingWebhookSecret = testGitlabWebhookSecret
+
+	proj, err := config.Repo.Project().CreateProject(&models.Project{Name: "test-project"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cluster, err := config.Repo.Cluster().CreateCluster(&models.Cluster{ProjectID: proj.ID, Name: "test-cluster"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := config.Repo.Environment().CreateEnvironment(&models.Environment{
+		ProjectID:    proj.ID,
+		ClusterID:    cluster.ID,
+		GitRepoOwner: "acme",
+		GitRepoName:  "api",
+		Mode:         "auto",
+		WebhookID:    testGitlabWebhookID,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	depl, err := config.Repo.Environment().CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-7",
+		Status:        types.DeploymentStatusCreated,
+		PullRequestID: 7,
+		PRName:        "Add billing",
+		RepoOwner:     "acme",
+		RepoName:      "api",
+		PRBranchFrom:  "billing",
+		PRBranchInto:  "main",
+		LastPushedAt:  time.Now().Add(-time.Hour),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	agent := kubernetes.GetAgentTesting(&v1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "pr-7",
+		},
+	})
+
+	return &gitlabFixture{config, env, depl, agent}
+}
+
+// gitlabMergeEvent returns the payload of a merge request webhook for the given project path
+func gitlabMergeEvent(t *testing.T, pathWithNamespace string, iid int, action, title, oldRev string) []byte {
+	event := map[string]interface{}{
+		"object_kind": "merge_request",
+		"project": map[string]interface{}{
+			"path_with_namespace": pathWithNamespace,
+		},
+		"object_attributes": map[string]interface{}{
+			"iid":           iid,
+			"title":         title,
+			"action":        action,
+			"oldrev":        oldRev,
+			"source_branch": "billing",
+			"target_branch": "main",
+			"last_commit": map[string]interface{}{
+				"id": "0123456789abcdef",
+			},
+		},
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return payload
+}
+
+func serveGitlabWebhook(t *testing.T, f *gitlabFixture, token, webhookID string, payload []byte) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/api/webhooks/gitlab/"+webhookID, bytes.NewReader(payload))
+	req.Header.Set("X-Gitlab-Event", "Merge Request Hook")
+	req.Header.Set("X-Gitlab-Token", token)
+
+	req = apitest.WithURLParams(t, req, map[string]string{
+		string(types.URLParamIncomingWebhookID): webhookID,
+	})
+
+	req = req.WithContext(context.WithValue(req.Context(), authz.KubernetesAgentCtxKey, f.agent))
+
+	rr := httptest.NewRecorder()
+
+	handler := webhook.NewGitlabIncomingWebhookHandler(
+		f.config,
+		shared.NewDefaultRequestDecoderValidator(f.config.Logger, f.config.Alerter),
+		shared.NewDefaultResultWriter(f.config.Logger, f.config.Alerter),
+	)
+
+	handler.ServeHTTP(rr, req)
+
+	return rr
+}
+
+func TestGitlabWebhookSecret(t *testing.T) {
+	tests := []struct {
+		name         string
+		configSecret string
+		token        string
+	}{
+		{
+			name:         "wrong token",
+			configSecret: testGitlabWebhookSecret,
+			token:        "wrong-secret",
+		},
+		{
+			name:         "missing token",
+			configSecret: testGitlabWebhookSecret,
+			token:        "",
+		},
+		{
+			name:         "no secret configured",
+			configSecret: "",
+			token:        "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newGitlabFixture(t)
+			f.config.ServerConf.GitlabIncomingWebhookSecret = tt.configSecret
+
+			rr := serveGitlabWebhook(t, f, tt.token, testGitlabWebhookID,
+				gitlabMergeEvent(t, "acme/api", 7, "close", "Add billing", ""))
+
+			apitest.AssertResponseForbidden(t, rr)
+
+			// the deployment is not touched
+			_, err := f.config.Repo.Environment().ReadDeploymentByGitDetails(f.env.ID, "acme", "api", 7)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGitlabWebhookMergeRequestOpen(t *testing.T) {
+	f := newGitlabFixture(t)
+
+	rr := serveGitlabWebhook(t, f, testGitlabWebhookSecret, testGitlabWebhookID,
+		gitlabMergeEvent(t, "acme/api", 8, "open", "Add invoices", ""))
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	depl, err := f.config.Repo.Environment().ReadDeploymentByGitDetails(f.env.ID, "acme", "api", 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, types.DeploymentStatusCreating, depl.Status)
+	assert.Equal(t, "Add invoices", depl.PRName)
+	assert.Equal(t, "billing", depl.PRBranchFrom)
+	assert.Equal(t, "main", depl.PRBranchInto)
+	assert.Equal(t, "0123456", depl.CommitSHA)
+
+	// reopening the merge request does not create a second deployment
+	rr = serveGitlabWebhook(t, f, testGitlabWebhookSecret, testGitlabWebhookID,
+		gitlabMergeEvent(t, "acme/api", 8, "reopen", "Add invoices", ""))
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	depls, err := f.config.Repo.Environment().ListDeployments(f.env.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, depls, 2)
+}
+
+func TestGitlabWebhookMergeRequestUpdate(t *testing.T) {
+	f := newGitlabFixture(t)
+	lastPushedAt := f.depl.LastPushedAt
+
+	rr := serveGitlabWebhook(t, f, testGitlabWebhookSecret, testGitlabWebhookID,
+		gitlabMergeEvent(t, "acme/api", 7, "update", "Add billing v2", "fedcba9876543210"))
+
+	assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+	depl, err := f.config.Repo.Environment().ReadDeploymentByGitDetails(f.env.ID, "acme", "api", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Add billing v2", depl.PRName)
+	assert.True(t, depl.LastPushedAt.After(lastPushedAt), "push to the merge request should be recorded")
+}
+
+func TestGitlabWebhookMergeRequestClosed(t *testing.T) {
+	for _, action := range []string{"close", "merge"} {
+		t.Run(action, func(t *testing.T) {
+			f := newGitlabFixture(t)
+
+			rr := serveGitlabWebhook(t, f, testGitlabWebhookSecret, testGitlabWebhookID,
+				gitlabMergeEvent(t, "acme/api", 7, action, "Add billing", ""))
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+			_, err := f.config.Repo.Environment().ReadDeploymentByGitDetails(f.env.ID, "acme", "api", 7)
+			assert.Error(t, err, "deployment should be deleted")
+
+			_, err = f.agent.Clientset.CoreV1().Namespaces().Get(context.Background(), "pr-7", metav1.GetOptions{})
+			assert.Error(t, err, "namespace of the deployment should be deleted")
+		})
+	}
+}
+
+func TestGitlabWebhookUnknownEnvironment(t *testing.T) {
+	tests := []struct {
+		name      string
+		webhookID string
+		project   string
+	}{
+		{
+			name:      "unknown repository",
+			webhookID: testGitlabWebhookID,
+			project:   "acme/billing",
+		},
+		{
+			name:      "unknown webhook",
+			webhookID: "other-webhook-id",
+			project:   "acme/api",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newGitlabFixture(t)
+
+			rr := serveGitlabWebhook(t, f, testGitlabWebhookSecret, tt.webhookID,
+				gitlabMergeEvent(t, tt.project, 7, "close", "Add billing", ""))
+
+			assert.Equal(t, http.StatusOK, rr.Result().StatusCode)
+
+			// the deployment of the environment is not touched
+			_, err := f.config.Repo.Environment().ReadDeploymentByGitDetails(f.env.ID, "acme", "api", 7)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -490,6 +490,56 @@ func GetBaseRoutes(
 		Router:   r,
 	})
 
+	// GET /api/oauth/login/oidc
+	oidcLoginStartEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/login/oidc",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginStartHandler := user.NewUserOAuthOIDCHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginStartEndpoint,
+		Handler:  oidcLoginStartHandler,
+		Router:   r,
+	})
+
+	// GET /api/oauth/oidc/callback
+	oidcLoginCallbackEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: "/oauth/oidc/callback",
+			},
+			Scopes: []types.PermissionScope{},
+		},
+	)
+
+	oidcLoginCallbackHandler := user.NewUserOAuthOIDCCallbackHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: oidcLoginCallbackEndpoint,
+		Handler:  oidcLoginCallbackHandler,
+		Router:   r,
+	})
+
 	// GET /api/internal/credentials
 	getCredentialsEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -542,5 +592,32 @@ func GetBaseRoutes(
 		})
 	}
 
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+		// POST /api/gitlab/incoming_webhook/{webhook_id} -> webhook.NewGitlabIncomingWebhook
+		gitlabIncomingWebhookEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: fmt.Sprintf("/gitlab/incoming_webhook/{%s}", types.URLParamIncomingWebhookID),
+				},
+				Scopes: []types.PermissionScope{},
+			},
+		)
+
+		gitlabIncomingWebhookHandler := webhook.NewGitlabIncomingWebhookHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: gitlabIncomingWebhookEndpoint,
+			Handler:  gitlabIncomingWebhookHandler,
+			Router:   r,
+		})
+	}
+
 	return routes
 }
@@ -288,7 +288,7 @@ func getClusterRoutes(
 		Router:   r,
 	})
 
-	if config.ServerConf.GithubIncomingWebhookSecret != "" {
+	if config.ServerConf.GithubIncomingWebhookSecret != "" || config.ServerConf.GitlabIncomingWebhookSecret != "" {
 
 		// GET /api/projects/{project_id}/clusters/{cluster_id}/environments -> environment.NewListEnvironmentHandler
 		listEnvEndpoint := factory.NewAPIEndpoint(
@@ -650,6 +650,36 @@ func getClusterRoutes(
 			Router:   r,
 		})
 
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/deployments/{deployment_id}/wake -> environment.NewWakeDeploymentHandler
+		wakeDeploymentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbUpdate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent:       basePath,
+					RelativePath: relPath + "/deployments/{deployment_id}/wake",
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		wakeDeploymentHandler := environment.NewWakeDeploymentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: wakeDeploymentEndpoint,
+			Handler:  wakeDeploymentHandler,
+			Router:   r,
+		})
+
 		// POST /api/projects/{project_id}/clusters/{cluster_id}/deployments/{deployment_id}/trigger_workflow -> environment.NewTriggerDeploymentWorkflowHandler
 		triggerDeploymentWorkflowEndpoint := factory.NewAPIEndpoint(
 			&types.APIRequestMetadata{
@@ -802,6 +832,85 @@ func getClusterRoutes(
 
 	}
 
+	if config.ServerConf.GitlabIncomingWebhookSecret != "" {
+
+		// POST /api/projects/{project_id}/clusters/{cluster_id}/integrations/gitlab/{integration_id}/repos/{owner}/{name}/environment ->
+		// environment.NewCreateGitlabEnvironmentHandler
+		createGitlabEnvironmentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbCreate,
+				Method: types.HTTPVerbPost,
+				Path: &types.Path{
+					Parent: basePath,
+					RelativePath: fmt.Sprintf(
+						"%s/integrations/gitlab/{%s}/repos/{%s}/{%s}/environment",
+						relPath,
+						types.URLParamIntegrationID,
+						types.URLParamGitRepoOwner,
+						types.URLParamGitRepoName,
+					),
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.GitlabIntegrationScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		createGitlabEnvironmentHandler := environment.NewCreateGitlabEnvironmentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: createGitlabEnvironmentEndpoint,
+			Handler:  createGitlabEnvironmentHandler,
+			Router:   r,
+		})
+
+		// DELETE /api/projects/{project_id}/clusters/{cluster_id}/integrations/gitlab/{integration_id}/repos/{owner}/{name}/environment ->
+		// environment.NewDeleteGitlabEnvironmentHandler
+		deleteGitlabEnvironmentEndpoint := factory.NewAPIEndpoint(
+			&types.APIRequestMetadata{
+				Verb:   types.APIVerbDelete,
+				Method: types.HTTPVerbDelete,
+				Path: &types.Path{
+					Parent: basePath,
+					RelativePath: fmt.Sprintf(
+						"%s/integrations/gitlab/{%s}/repos/{%s}/{%s}/environment",
+						relPath,
+						types.URLParamIntegrationID,
+						types.URLParamGitRepoOwner,
+						types.URLParamGitRepoName,
+					),
+				},
+				Scopes: []types.PermissionScope{
+					types.UserScope,
+					types.ProjectScope,
+					types.ClusterScope,
+					types.GitlabIntegrationScope,
+					types.PreviewEnvironmentScope,
+				},
+			},
+		)
+
+		deleteGitlabEnvironmentHandler := environment.NewDeleteGitlabEnvironmentHandler(
+			config,
+			factory.GetDecoderValidator(),
+			factory.GetResultWriter(),
+		)
+
+		routes = append(routes, &router.Route{
+			Endpoint: deleteGitlabEnvironmentEndpoint,
+			Handler:  deleteGitlabEnvironmentHandler,
+			Router:   r,
+		})
+	}
+
 	// GET /api/projects/{project_id}/clusters/{cluster_id}/namespaces -> cluster.NewClusterListNamespacesHandler
 	listNamespacesEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -1604,5 +1713,34 @@ func getClusterRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/clusters/{cluster_id}/deployment_events/notify -> cluster.NewNotifyDeploymentEventHandler
+	notifyDeploymentEventEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/deployment_events/notify",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.ClusterScope,
+			},
+		},
+	)
+
+	notifyDeploymentEventHandler := cluster.NewNotifyDeploymentEventHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: notifyDeploymentEventEndpoint,
+		Handler:  notifyDeploymentEventHandler,
+		Router:   r,
+	})
+
 	return routes, newPath
 }
@@ -6,11 +6,13 @@ import (
 	"github.com/go-chi/chi"
 	apiContract "github.com/porter-dev/porter/api/server/handlers/api_contract"
 	"github.com/porter-dev/porter/api/server/handlers/api_token"
+	"github.com/porter-dev/porter/api/server/handlers/audit"
 	"github.com/porter-dev/porter/api/server/handlers/billing"
 	"github.com/porter-dev/porter/api/server/handlers/cluster"
 	"github.com/porter-dev/porter/api/server/handlers/gitinstallation"
 	"github.com/porter-dev/porter/api/server/handlers/helmrepo"
 	"github.com/porter-dev/porter/api/server/handlers/infra"
+	"github.com/porter-dev/porter/api/server/handlers/opa_policy"
 	"github.com/porter-dev/porter/api/server/handlers/policy"
 	"github.com/porter-dev/porter/api/server/handlers/project"
 	"github.com/porter-dev/porter/api/server/handlers/registry"
@@ -1064,6 +1066,437 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	//  PUT /api/projects/{project_id}/policy/{policy_id} -> policy.NewPolicyUpdateHandler
+	policyUpdateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/policy/{%s}", relPath, types.URLParamPolicyID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	policyUpdateHandler := policy.NewPolicyUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: policyUpdateEndpoint,
+		Handler:  policyUpdateHandler,
+		Router:   r,
+	})
+
+	//  DELETE /api/projects/{project_id}/policy/{policy_id} -> policy.NewPolicyDeleteHandler
+	policyDeleteEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/policy/{%s}", relPath, types.URLParamPolicyID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	policyDeleteHandler := policy.NewPolicyDeleteHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: policyDeleteEndpoint,
+		Handler:  policyDeleteHandler,
+		Router:   r,
+	})
+
+	//  GET /api/projects/{project_id}/opa_policies -> opa_policy.NewOPAPolicyCollectionListHandler
+	opaPolicyListEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/opa_policies",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	opaPolicyListHandler := opa_policy.NewOPAPolicyCollectionListHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: opaPolicyListEndpoint,
+		Handler:  opaPolicyListHandler,
+		Router:   r,
+	})
+
+	//  POST /api/projects/{project_id}/opa_policies -> opa_policy.NewOPAPolicyCollectionCreateHandler
+	opaPolicyCreateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/opa_policies",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	opaPolicyCreateHandler := opa_policy.NewOPAPolicyCollectionCreateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: opaPolicyCreateEndpoint,
+		Handler:  opaPolicyCreateHandler,
+		Router:   r,
+	})
+
+	//  DELETE /api/projects/{project_id}/opa_policies/{opa_policy_name} -> opa_policy.NewOPAPolicyCollectionDeleteHandler
+	opaPolicyDeleteEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/opa_policies/{%s}", relPath, types.URLParamOPAPolicyCollectionName),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	opaPolicyDeleteHandler := opa_policy.NewOPAPolicyCollectionDeleteHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: opaPolicyDeleteEndpoint,
+		Handler:  opaPolicyDeleteHandler,
+		Router:   r,
+	})
+
+	//  PUT /api/projects/{project_id}/opa_admission -> opa_policy.NewOPAAdmissionUpdateHandler
+	opaAdmissionUpdateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/opa_admission",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	opaAdmissionUpdateHandler := opa_policy.NewOPAAdmissionUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: opaAdmissionUpdateEndpoint,
+		Handler:  opaAdmissionUpdateHandler,
+		Router:   r,
+	})
+
+	//  PUT /api/projects/{project_id}/notification_settings -> project.NewNotificationSettingsUpdateHandler
+	notificationSettingsUpdateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/notification_settings",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	notificationSettingsUpdateHandler := project.NewNotificationSettingsUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: notificationSettingsUpdateEndpoint,
+		Handler:  notificationSettingsUpdateHandler,
+		Router:   r,
+	})
+
+	//  PUT /api/projects/{project_id}/audit_settings -> project.NewAuditSettingsUpdateHandler
+	auditSettingsUpdateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/audit_settings",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	auditSettingsUpdateHandler := project.NewAuditSettingsUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: auditSettingsUpdateEndpoint,
+		Handler:  auditSettingsUpdateHandler,
+		Router:   r,
+	})
+
+	//  GET /api/projects/{project_id}/audit_events -> audit.NewListAuditEventsHandler
+	listAuditEventsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/audit_events",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listAuditEventsHandler := audit.NewListAuditEventsHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listAuditEventsEndpoint,
+		Handler:  listAuditEventsHandler,
+		Router:   r,
+	})
+
+	//  POST /api/projects/{project_id}/audit_sinks -> audit.NewCreateAuditSinkHandler
+	createAuditSinkEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbCreate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/audit_sinks",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	createAuditSinkHandler := audit.NewCreateAuditSinkHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: createAuditSinkEndpoint,
+		Handler:  createAuditSinkHandler,
+		Router:   r,
+	})
+
+	//  GET /api/projects/{project_id}/audit_sinks -> audit.NewListAuditSinksHandler
+	listAuditSinksEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/audit_sinks",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	listAuditSinksHandler := audit.NewListAuditSinksHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: listAuditSinksEndpoint,
+		Handler:  listAuditSinksHandler,
+		Router:   r,
+	})
+
+	//  DELETE /api/projects/{project_id}/audit_sinks/{audit_sink_id} -> audit.NewDeleteAuditSinkHandler
+	deleteAuditSinkEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbDelete,
+			Method: types.HTTPVerbDelete,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/audit_sinks/{%s}", relPath, types.URLParamAuditSinkID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	deleteAuditSinkHandler := audit.NewDeleteAuditSinkHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: deleteAuditSinkEndpoint,
+		Handler:  deleteAuditSinkHandler,
+		Router:   r,
+	})
+
+	//  POST /api/projects/{project_id}/audit_sinks/{audit_sink_id}/replay -> audit.NewReplayAuditSinkHandler
+	replayAuditSinkEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/audit_sinks/{%s}/replay", relPath, types.URLParamAuditSinkID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	replayAuditSinkHandler := audit.NewReplayAuditSinkHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: replayAuditSinkEndpoint,
+		Handler:  replayAuditSinkHandler,
+		Router:   r,
+	})
+
+	//  GET /api/projects/{project_id}/monitor_notifications -> project.NewMonitorNotificationsGetHandler
+	getMonitorNotificationsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbGet,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/monitor_notifications",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	getMonitorNotificationsHandler := project.NewMonitorNotificationsGetHandler(
+		config,
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: getMonitorNotificationsEndpoint,
+		Handler:  getMonitorNotificationsHandler,
+		Router:   r,
+	})
+
+	//  PUT /api/projects/{project_id}/monitor_notifications -> project.NewMonitorNotificationsUpdateHandler
+	updateMonitorNotificationsEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: relPath + "/monitor_notifications",
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	updateMonitorNotificationsHandler := project.NewMonitorNotificationsUpdateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: updateMonitorNotificationsEndpoint,
+		Handler:  updateMonitorNotificationsHandler,
+		Router:   r,
+	})
+
 	//  POST /api/projects/{project_id}/api_token -> api_token.NewAPITokenCreateHandler
 	apiTokenCreateEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
@@ -1180,6 +1613,93 @@ func getProjectRoutes(
 		Router:   r,
 	})
 
+	// POST /api/projects/{project_id}/api_token/{api_token_id}/rotate -> api_token.NewAPITokenRotateHandler
+	apiTokenRotateEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPost,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/api_token/{%s}/rotate", relPath, types.URLParamTokenID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	apiTokenRotateHandler := api_token.NewAPITokenRotateHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: apiTokenRotateEndpoint,
+		Handler:  apiTokenRotateHandler,
+		Router:   r,
+	})
+
+	// GET /api/projects/{project_id}/api_token/stale -> api_token.NewAPITokenListStaleHandler
+	apiTokenListStaleEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbList,
+			Method: types.HTTPVerbGet,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/api_token/stale", relPath),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	apiTokenListStaleHandler := api_token.NewAPITokenListStaleHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: apiTokenListStaleEndpoint,
+		Handler:  apiTokenListStaleHandler,
+		Router:   r,
+	})
+
+	//  PUT /api/projects/{project_id}/api_token/{api_token_id}/policy -> api_token.NewAPITokenUpdatePolicyHandler
+	apiTokenUpdatePolicyEndpoint := factory.NewAPIEndpoint(
+		&types.APIRequestMetadata{
+			Verb:   types.APIVerbUpdate,
+			Method: types.HTTPVerbPut,
+			Path: &types.Path{
+				Parent:       basePath,
+				RelativePath: fmt.Sprintf("%s/api_token/{%s}/policy", relPath, types.URLParamTokenID),
+			},
+			Scopes: []types.PermissionScope{
+				types.UserScope,
+				types.ProjectScope,
+				types.SettingsScope,
+			},
+		},
+	)
+
+	apiTokenUpdatePolicyHandler := api_token.NewAPITokenUpdatePolicyHandler(
+		config,
+		factory.GetDecoderValidator(),
+		factory.GetResultWriter(),
+	)
+
+	routes = append(routes, &router.Route{
+		Endpoint: apiTokenUpdatePolicyEndpoint,
+		Handler:  apiTokenUpdatePolicyHandler,
+		Router:   r,
+	})
+
 	//  POST /api/projects/{project_id}/helmrepos -> helmrepo.NewHelmRepoCreateHandler
 	hrCreateEndpoint := factory.NewAPIEndpoint(
 		&types.APIRequestMetadata{
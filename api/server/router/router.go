@@ -39,6 +39,9 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 	projectIntegrationRegisterer := NewProjectIntegrationScopedRegisterer()
 	projectOAuthRegisterer := NewProjectOAuthScopedRegisterer()
 	slackIntegrationRegisterer := NewSlackIntegrationScopedRegisterer()
+	teamsIntegrationRegisterer := NewTeamsIntegrationScopedRegisterer()
+	discordIntegrationRegisterer := NewDiscordIntegrationScopedRegisterer()
+	notificationWebhookRegisterer := NewNotificationWebhookScopedRegisterer()
 	projRegisterer := NewProjectScopedRegisterer(
 		clusterRegisterer,
 		registryRegisterer,
@@ -49,6 +52,9 @@ func NewAPIRouter(config *config.Config) *chi.Mux {
 		projectIntegrationRegisterer,
 		projectOAuthRegisterer,
 		slackIntegrationRegisterer,
+		teamsIntegrationRegisterer,
+		discordIntegrationRegisterer,
+		notificationWebhookRegisterer,
 	)
 	statusRegisterer := NewStatusScopedRegisterer()
 
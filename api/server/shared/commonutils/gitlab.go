@@ -1,8 +1,13 @@
 package commonutils
 
 import (
+	"fmt"
+
 	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/internal/models"
 	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/xanzy/go-gitlab"
 	"golang.org/x/oauth2"
 )
 
@@ -18,3 +23,43 @@ func GetGitlabOAuthConf(conf *config.Config, giIntegration *ints.GitlabIntegrati
 		Scopes:      []string{"api", "profile", "email"},
 	}
 }
+
+// GetGitlabClientFromEnvironment returns a GitLab client for the repository of a GitLab preview environment,
+// authenticated with the OAuth token of the user who created the environment
+func GetGitlabClientFromEnvironment(
+	conf *config.Config,
+	env *models.Environment,
+) (*gitlab.Client, *ints.GitlabIntegration, error) {
+	gi, err := conf.Repo.GitlabIntegration().ReadGitlabIntegration(env.ProjectID, env.GitlabIntegrationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading gitlab integration: %w", err)
+	}
+
+	giAppOAuth, err := conf.Repo.GitlabAppOAuthIntegration().ReadGitlabAppOAuthIntegration(
+		env.GitlabUserID, env.ProjectID, env.GitlabIntegrationID,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading gitlab oauth integration: %w", err)
+	}
+
+	oauthInt, err := conf.Repo.OAuthIntegration().ReadOAuthIntegration(env.ProjectID, giAppOAuth.OAuthIntegrationID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading oauth integration: %w", err)
+	}
+
+	accessToken, _, err := oauth.GetAccessToken(
+		oauthInt.SharedOAuthModel,
+		GetGitlabOAuthConf(conf, gi),
+		oauth.MakeUpdateGitlabAppOAuthIntegrationFunction(env.ProjectID, giAppOAuth, conf.Repo),
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error getting gitlab access token: %w", err)
+	}
+
+	client, err := gitlab.NewOAuthClient(accessToken, gitlab.WithBaseURL(gi.InstanceURL))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, gi, nil
+}
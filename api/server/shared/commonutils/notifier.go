@@ -0,0 +1,99 @@
+package commonutils
+
+import (
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/pipeline"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+// GetNotificationConfig returns the notification config of a release, or nil if the release does not
+// exist or has no notification config
+func GetNotificationConfig(conf *config.Config, rel *models.Release) (*types.NotificationConfig, error) {
+	if rel == nil || rel.NotificationConfig == 0 {
+		return nil, nil
+	}
+
+	notifConf, err := conf.Repo.NotificationConfig().ReadNotificationConfig(rel.NotificationConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return notifConf.ToNotificationConfigType(), nil
+}
+
+// GetDeploymentNotifier returns the notifier for the deployment events of a project, which notifies the
+// notification webhooks and the Slack, Teams and Discord integrations of the project. Integrations which
+// cannot be read are skipped, since notifications should never block a deployment.
+func GetDeploymentNotifier(conf *config.Config, projectID uint, notifConf *types.NotificationConfig) notifier.Notifier {
+	webhooks, _ := conf.Repo.NotificationWebhook().ListNotificationWebhooksByProjectID(projectID)
+	channels, _ := pipeline.ListChannels(conf.Repo, projectID, notifConf)
+
+	// webhooks are consumed by other services, so they receive every event without throttling
+	return notifier.NewMultiNotifier(
+		webhook.NewDeploymentNotifier(notifConf, conf.Repo.NotificationWebhook(), webhooks...),
+		pipeline.New(
+			conf.Repo.NotificationPipeline(),
+			projectID,
+			getPipelineOptions(conf, projectID, notifConf),
+			channels...,
+		),
+	)
+}
+
+// GetIncidentNotifiers returns the incident notifiers for the notification webhooks and the Slack, Teams
+// and Discord integrations of the project of the cluster. Webhooks are notified first, since the remaining
+// notifiers are skipped once one of them fails.
+func GetIncidentNotifiers(
+	conf *config.Config,
+	cluster *models.Cluster,
+	notifConf *types.NotificationConfig,
+) ([]notifier.IncidentNotifier, error) {
+	webhooks, err := conf.Repo.NotificationWebhook().ListNotificationWebhooksByProjectID(cluster.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+
+	channels, err := pipeline.ListChannels(conf.Repo, cluster.ProjectID, notifConf)
+	if err != nil {
+		return nil, err
+	}
+
+	// incidents are only sent to Slack when the Slack app is configured
+	if conf.SlackConf == nil {
+		for _, ch := range channels {
+			if ch.Kind == pipeline.ChannelSlack {
+				ch.Incident = nil
+			}
+		}
+	}
+
+	opts := getPipelineOptions(conf, cluster.ProjectID, notifConf)
+	opts.ClusterName = cluster.Name
+
+	return []notifier.IncidentNotifier{
+		webhook.NewIncidentNotifier(cluster, conf.Repo.NotificationWebhook(), webhooks...),
+		pipeline.New(conf.Repo.NotificationPipeline(), cluster.ProjectID, opts, channels...),
+	}, nil
+}
+
+func getPipelineOptions(conf *config.Config, projectID uint, notifConf *types.NotificationConfig) *pipeline.Options {
+	opts := &pipeline.Options{
+		NotificationConfig: notifConf,
+		DedupWindow:        pipeline.GetDedupWindow(notifConf),
+		ChannelLimit:       conf.ServerConf.NotificationChannelLimit,
+		Logger:             conf.Logger,
+	}
+
+	if project, err := conf.Repo.Project().ReadProject(projectID); err == nil {
+		opts.Digest = notifier.DigestFrequency(project.NotificationDigest)
+
+		if project.NotificationChannelLimit != 0 {
+			opts.ChannelLimit = project.NotificationChannelLimit
+		}
+	}
+
+	return opts
+}
@@ -0,0 +1,97 @@
+package commonutils
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/porter-dev/porter/api/server/shared/config"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/helm"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/pkg/logger"
+	"github.com/stefanmcshane/helm/pkg/release"
+)
+
+// GetReleaseValidator returns the validator which evaluates the built-in and custom policies of the project
+// against releases before they are deployed, or nil if admission is disabled for the project. Custom
+// policies are compiled without the network and runtime builtins, as when they are uploaded.
+func GetReleaseValidator(conf *config.Config, project *models.Project, cluster *models.Cluster) (helm.ReleaseValidator, error) {
+	if project.OPAAdmissionMode == "" {
+		return nil, nil
+	}
+
+	var policies config.ReleasePolicies = &opa.KubernetesPolicies{
+		Policies: make(map[string]opa.KubernetesOPAQueryCollection),
+	}
+
+	if conf.OPAPolicies != nil {
+		policies = conf.OPAPolicies
+	}
+
+	collections, err := conf.Repo.OPAPolicyCollection().ListOPAPolicyCollectionsByProjectID(project.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing policy collections: %w", err)
+	}
+
+	collectionTypes := make([]*types.OPAPolicyCollection, 0)
+
+	for _, collection := range collections {
+		collectionType, err := collection.ToOPAPolicyCollectionType()
+		if err != nil {
+			return nil, fmt.Errorf("error reading policy collection %s: %w", collection.Name, err)
+		}
+
+		collectionTypes = append(collectionTypes, collectionType)
+	}
+
+	return &releaseValidator{
+		policies:    policies,
+		collections: collectionTypes,
+		cluster:     cluster,
+		mode:        project.OPAAdmissionMode,
+		logger:      conf.Logger,
+	}, nil
+}
+
+// releaseValidator evaluates the policies of a project against the releases deployed to a cluster,
+// keeping the warnings of the releases which were deployed in audit mode
+type releaseValidator struct {
+	policies    config.ReleasePolicies
+	collections []*types.OPAPolicyCollection
+	cluster     *models.Cluster
+	mode        string
+	logger      *logger.Logger
+
+	// releases may be validated concurrently, such as when images are updated in batches
+	mu       sync.Mutex
+	warnings []string
+}
+
+func (v *releaseValidator) ValidateRelease(rel *release.Release) error {
+	warnings, err := v.policies.ValidateRelease(rel, v.collections, v.cluster, v.mode, v.logger)
+
+	v.mu.Lock()
+	v.warnings = append(v.warnings, warnings...)
+	v.mu.Unlock()
+
+	return err
+}
+
+// SetReleaseValidatorWarnings adds the policy violations of the releases which were deployed in audit
+// mode to the response as Warning headers, so they must be set before the response is written
+func SetReleaseValidatorWarnings(w http.ResponseWriter, validator helm.ReleaseValidator) {
+	v, ok := validator.(*releaseValidator)
+
+	if !ok {
+		return
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	for _, warning := range v.warnings {
+		w.Header().Add("Warning", fmt.Sprintf("299 - %q", warning))
+	}
+}
@@ -0,0 +1,107 @@
+package commonutils_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/apitest"
+	"github.com/porter-dev/porter/api/server/shared/commonutils"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/stefanmcshane/helm/pkg/chart"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"github.com/stretchr/testify/assert"
+)
+
+const replicasModule = `package custom.replicas
+
+POLICY_ID := "replicas"
+
+POLICY_TITLE := "Releases should run at least 2 replicas"
+
+allow {
+	input.values.replicaCount >= 2
+}
+`
+
+func TestReleaseValidatorWarnings(t *testing.T) {
+	tests := []struct {
+		name         string
+		mode         string
+		replicas     int
+		wantErr      bool
+		wantWarnings []string
+	}{
+		{
+			name:     "audit returns failures as warnings",
+			mode:     "audit",
+			replicas: 1,
+			wantWarnings: []string{
+				`299 - "Releases should run at least 2 replicas (helm_release/default/web/replicas): "`,
+			},
+		},
+		{
+			name:     "audit without failures",
+			mode:     "audit",
+			replicas: 2,
+		},
+		{
+			name:     "enforce rejects failures",
+			mode:     "enforce",
+			replicas: 1,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := apitest.LoadConfig(t)
+
+			proj, err := config.Repo.Project().CreateProject(&models.Project{
+				Name:             "test-project",
+				OPAAdmissionMode: tt.mode,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			policiesBytes, err := json.Marshal([]*types.OPAPolicy{{Name: "custom.replicas", Module: replicasModule}})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = config.Repo.OPAPolicyCollection().CreateOPAPolicyCollection(&models.OPAPolicyCollection{
+				ProjectID:     proj.ID,
+				Name:          "replicas",
+				Kind:          "helm_release",
+				MatchBytes:    []byte(`{"chart_name": "web"}`),
+				PoliciesBytes: policiesBytes,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			validator, err := commonutils.GetReleaseValidator(config, proj, &models.Cluster{ProjectID: proj.ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			err = validator.ValidateRelease(&release.Release{
+				Name:      "web",
+				Namespace: "default",
+				Chart: &chart.Chart{
+					Metadata: &chart.Metadata{Name: "web", Version: "0.1.0"},
+				},
+				Config: map[string]interface{}{"replicaCount": tt.replicas},
+			})
+
+			assert.Equal(t, tt.wantErr, err != nil, "unexpected error: %v", err)
+
+			rr := httptest.NewRecorder()
+			commonutils.SetReleaseValidatorWarnings(rr, validator)
+
+			assert.Equal(t, tt.wantWarnings, rr.Header().Values("Warning"))
+		})
+	}
+}
@@ -6,11 +6,13 @@ import (
 	"github.com/porter-dev/porter/api/server/shared/apierrors/alerter"
 	"github.com/porter-dev/porter/api/server/shared/config/env"
 	"github.com/porter-dev/porter/api/server/shared/websocket"
+	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/internal/analytics"
 	"github.com/porter-dev/porter/internal/auth/token"
 	"github.com/porter-dev/porter/internal/billing"
 	"github.com/porter-dev/porter/internal/helm/urlcache"
 	"github.com/porter-dev/porter/internal/integrations/powerdns"
+	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/nats"
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/oauth"
@@ -18,6 +20,7 @@ import (
 	"github.com/porter-dev/porter/internal/repository/credentials"
 	"github.com/porter-dev/porter/pkg/logger"
 	"github.com/porter-dev/porter/provisioner/client"
+	"github.com/stefanmcshane/helm/pkg/release"
 	"golang.org/x/oauth2"
 	"gorm.io/gorm"
 )
@@ -68,6 +71,9 @@ type Config struct {
 	// GoogleConf is the configuration for a Google OAuth client
 	GoogleConf *oauth2.Config
 
+	// OIDCConf is the client for a generic OpenID Connect login provider
+	OIDCConf *oauth.OIDCClient
+
 	// SlackConf is the configuration for a Slack OAuth client
 	SlackConf *oauth2.Config
 
@@ -105,10 +111,29 @@ type Config struct {
 	// NATS contains the required config for connecting to a NATS cluster for streaming
 	NATS nats.NATS
 
+	// OPAPolicies are the built-in OPA policies evaluated at deploy time, if OPA_CONFIG_FILE_DIR is set
+	OPAPolicies ReleasePolicies
+
 	// EnableCAPIProvisioner enables CAPI Provisioner, which requires config for ClusterControlPlaneClient and NATS, if set to true
 	EnableCAPIProvisioner bool
 }
 
+// ReleasePolicies evaluates policies against releases before they are deployed. It is implemented by
+// *opa.KubernetesPolicies, which is not referenced here since internal/opa depends on this package
+// through the Helm and Kubernetes agents.
+type ReleasePolicies interface {
+	// ValidateRelease evaluates the policies, along with the custom policy collections of a project,
+	// against a release rendered by a dry run. Failures are returned as an error in enforce mode and
+	// as warnings in audit mode.
+	ValidateRelease(
+		rel *release.Release,
+		collections []*types.OPAPolicyCollection,
+		cluster *models.Cluster,
+		mode string,
+		logger *logger.Logger,
+	) ([]string, error)
+}
+
 type ConfigLoader interface {
 	LoadConfig() (*Config, error)
 }
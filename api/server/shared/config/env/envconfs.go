@@ -29,6 +29,11 @@ type ServerConf struct {
 	IsTesting            bool          `env:"IS_TESTING,default=false"`
 	AppRootDomain        string        `env:"APP_ROOT_DOMAIN,default=porter.run"`
 
+	// TrustedProxies is a list of IP addresses or CIDR ranges of the load balancers in front of
+	// the server, separated by ";". The client IP is only read from the X-Forwarded-For header
+	// of requests coming from these addresses.
+	TrustedProxies []string `env:"TRUSTED_PROXIES"`
+
 	DefaultApplicationHelmRepoURL string `env:"HELM_APP_REPO_URL,default=https://charts.dev.getporter.dev"`
 	DefaultAddonHelmRepoURL       string `env:"HELM_ADD_ON_REPO_URL,default=https://chart-addons.dev.getporter.dev"`
 
@@ -55,6 +60,28 @@ type ServerConf struct {
 	GoogleClientSecret     string `env:"GOOGLE_CLIENT_SECRET"`
 	GoogleRestrictedDomain string `env:"GOOGLE_RESTRICTED_DOMAIN"`
 
+	// OIDCDiscoveryURL is the URL of the OpenID configuration of a generic OIDC provider used
+	// for login, such as https://idp.example.com/.well-known/openid-configuration
+	OIDCDiscoveryURL string `env:"OIDC_DISCOVERY_URL"`
+	OIDCClientID     string `env:"OIDC_CLIENT_ID"`
+	OIDCClientSecret string `env:"OIDC_CLIENT_SECRET"`
+	OIDCProviderName string `env:"OIDC_PROVIDER_NAME,default=SSO"`
+	OIDCScopes       string `env:"OIDC_SCOPES,default=openid profile email"`
+	OIDCEmailClaim   string `env:"OIDC_EMAIL_CLAIM,default=email"`
+	OIDCGroupsClaim  string `env:"OIDC_GROUPS_CLAIM,default=groups"`
+
+	// OIDCRestrictedGroup only allows the members of this OIDC group to log in
+	OIDCRestrictedGroup string `env:"OIDC_RESTRICTED_GROUP"`
+
+	// OIDCRoleMappings assigns project roles to the members of OIDC groups on every login, as a
+	// comma-separated list of group=project_id:role, where role is admin, developer, viewer or the
+	// id of a policy of the project
+	OIDCRoleMappings string `env:"OIDC_ROLE_MAPPINGS"`
+
+	// OIDCLinkExistingUsers allows users who signed up with another login method to log in through
+	// the OIDC provider, if the provider has verified their email
+	OIDCLinkExistingUsers bool `env:"OIDC_LINK_EXISTING_USERS,default=false"`
+
 	SendgridAPIKey                     string `env:"SENDGRID_API_KEY"`
 	SendgridPWResetTemplateID          string `env:"SENDGRID_PW_RESET_TEMPLATE_ID"`
 	SendgridPWGHTemplateID             string `env:"SENDGRID_PW_GH_TEMPLATE_ID"`
@@ -67,6 +94,10 @@ type ServerConf struct {
 	SlackClientID     string `env:"SLACK_CLIENT_ID"`
 	SlackClientSecret string `env:"SLACK_CLIENT_SECRET"`
 
+	// NotificationChannelLimit is the default maximum number of notifications sent to a Slack, Teams
+	// or Discord channel per hour
+	NotificationChannelLimit uint `env:"NOTIFICATION_CHANNEL_LIMIT,default=30"`
+
 	BillingPrivateKey       string `env:"BILLING_PRIVATE_KEY"`
 	BillingPrivateServerURL string `env:"BILLING_PRIVATE_URL"`
 	BillingPublicServerURL  string `env:"BILLING_PUBLIC_URL"`
@@ -114,6 +145,14 @@ type ServerConf struct {
 	// Enable gitlab integration
 	EnableGitlab bool `env:"ENABLE_GITLAB,default=false"`
 
+	// GitlabIncomingWebhookSecret is the token sent by GitLab with merge request webhooks of
+	// preview environments. GitLab preview environments are disabled if it is not set.
+	GitlabIncomingWebhookSecret string `env:"GITLAB_INCOMING_WEBHOOK_SECRET"`
+
+	// OPAConfigFileDir is the directory of the built-in OPA policies, which are enforced at deploy time along
+	// with the custom policies of projects with policy admission enabled
+	OPAConfigFileDir string `env:"OPA_CONFIG_FILE_DIR"`
+
 	// DisableRegistrySecretsInjection is used to denote if Porter should not inject
 	// imagePullSecrets into a kubernetes deployment (Porter application)
 	DisablePullSecretsInjection bool `env:"DISABLE_PULL_SECRETS_INJECTION,default=false"`
@@ -9,6 +9,7 @@ import (
 	"os"
 	"path/filepath"
 	"strconv"
+	"strings"
 
 	gorillaws "github.com/gorilla/websocket"
 	"github.com/porter-dev/api-contracts/generated/go/porter/v1/porterv1connect"
@@ -27,6 +28,7 @@ import (
 	"github.com/porter-dev/porter/internal/notifier"
 	"github.com/porter-dev/porter/internal/notifier/sendgrid"
 	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/opa"
 	"github.com/porter-dev/porter/internal/repository/credentials"
 	"github.com/porter-dev/porter/internal/repository/gorm"
 	"github.com/porter-dev/porter/provisioner/client"
@@ -156,6 +158,28 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		})
 	}
 
+	if sc.OIDCDiscoveryURL != "" && sc.OIDCClientID != "" {
+		roleMappings, err := oauth.ParseOIDCRoleMappings(sc.OIDCRoleMappings)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse OIDC_ROLE_MAPPINGS: %w", err)
+		}
+
+		res.OIDCConf = oauth.NewOIDCClient(&oauth.OIDCConfig{
+			Config: oauth.Config{
+				ClientID:     sc.OIDCClientID,
+				ClientSecret: sc.OIDCClientSecret,
+				Scopes:       strings.Fields(sc.OIDCScopes),
+				BaseURL:      sc.ServerURL,
+			},
+			DiscoveryURL:      sc.OIDCDiscoveryURL,
+			ProviderName:      sc.OIDCProviderName,
+			EmailClaim:        sc.OIDCEmailClaim,
+			GroupsClaim:       sc.OIDCGroupsClaim,
+			RoleMappings:      roleMappings,
+			LinkExistingUsers: sc.OIDCLinkExistingUsers,
+		})
+	}
+
 	// TODO: remove this as part of POR-1055
 	if sc.GithubClientID != "" && sc.GithubClientSecret != "" {
 		res.GithubConf = oauth.NewGithubClient(&oauth.Config{
@@ -293,6 +317,14 @@ func (e *EnvConfigLoader) LoadConfig() (res *config.Config, err error) {
 		res.PowerDNSClient = powerdns.NewClient(sc.PowerDNSAPIServerURL, sc.PowerDNSAPIKey, sc.AppRootDomain)
 	}
 
+	if sc.OPAConfigFileDir != "" {
+		res.OPAPolicies, err = opa.LoadPolicies(sc.OPAConfigFileDir)
+
+		if err != nil {
+			return res, fmt.Errorf("error loading OPA policies: %w", err)
+		}
+	}
+
 	res.EnableCAPIProvisioner = sc.EnableCAPIProvisioner
 	if sc.EnableCAPIProvisioner {
 		if sc.ClusterControlPlaneAddress == "" {
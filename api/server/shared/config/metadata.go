@@ -10,6 +10,8 @@ type Metadata struct {
 	BasicLogin         bool   `json:"basic_login"`
 	GithubLogin        bool   `json:"github_login"`
 	GoogleLogin        bool   `json:"google_login"`
+	OIDCLogin          bool   `json:"oidc_login"`
+	OIDCProviderName   string `json:"oidc_provider_name,omitempty"`
 	SlackNotifications bool   `json:"slack_notifications"`
 	Email              bool   `json:"email"`
 	Analytics          bool   `json:"analytics"`
@@ -27,6 +29,8 @@ func MetadataFromConf(sc *env.ServerConf, version string) *Metadata {
 		GithubLogin:             sc.GithubClientID != "" && sc.GithubClientSecret != "" && sc.GithubLoginEnabled,
 		BasicLogin:              sc.BasicLoginEnabled,
 		GoogleLogin:             sc.GoogleClientID != "" && sc.GoogleClientSecret != "",
+		OIDCLogin:               sc.OIDCDiscoveryURL != "" && sc.OIDCClientID != "",
+		OIDCProviderName:        sc.OIDCProviderName,
 		SlackNotifications:      sc.SlackClientID != "" && sc.SlackClientSecret != "",
 		Email:                   sc.SendgridAPIKey != "",
 		Analytics:               sc.SegmentClientKey != "",
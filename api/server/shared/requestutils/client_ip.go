@@ -0,0 +1,71 @@
+package requestutils
+
+import (
+	"net"
+	"net/http"
+	"strings"
+)
+
+// GetClientIP returns the address of the client which made the request. Since the
+// X-Forwarded-For header can be set by the client, it is only read when the request comes
+// from one of the trusted proxies: the header is read from right to left, skipping the
+// addresses of trusted proxies, and the first other address is returned. The remote address
+// is returned otherwise.
+func GetClientIP(r *http.Request, trustedProxies []string) string {
+	clientIP := r.RemoteAddr
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		clientIP = host
+	}
+
+	if !isTrustedProxy(clientIP, trustedProxies) {
+		return clientIP
+	}
+
+	forwarded := r.Header.Values("X-Forwarded-For")
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		addrs := strings.Split(forwarded[i], ",")
+
+		for j := len(addrs) - 1; j >= 0; j-- {
+			addr := strings.TrimSpace(addrs[j])
+
+			// proxies only append valid addresses, so anything else was set by the client
+			if net.ParseIP(addr) == nil {
+				return clientIP
+			}
+
+			clientIP = addr
+
+			if !isTrustedProxy(addr, trustedProxies) {
+				return clientIP
+			}
+		}
+	}
+
+	return clientIP
+}
+
+// isTrustedProxy returns true if the address matches one of the IP addresses or CIDR
+// ranges of the trusted proxies
+func isTrustedProxy(addr string, trustedProxies []string) bool {
+	ip := net.ParseIP(addr)
+
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range trustedProxies {
+		proxy = strings.TrimSpace(proxy)
+
+		if strings.Contains(proxy, "/") {
+			if _, ipNet, err := net.ParseCIDR(proxy); err == nil && ipNet.Contains(ip) {
+				return true
+			}
+		} else if proxyIP := net.ParseIP(proxy); proxyIP != nil && proxyIP.Equal(ip) {
+			return true
+		}
+	}
+
+	return false
+}
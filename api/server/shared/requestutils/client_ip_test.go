@@ -0,0 +1,78 @@
+package requestutils_test
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/api/server/shared/requestutils"
+	"github.com/stretchr/testify/assert"
+)
+
+type getClientIPTest struct {
+	description    string
+	remoteAddr     string
+	forwardedFor   []string
+	trustedProxies []string
+	expIP          string
+}
+
+var getClientIPTests = []getClientIPTest{
+	{
+		description: "no forwarded header",
+		remoteAddr:  "203.0.113.7:5123",
+		expIP:       "203.0.113.7",
+	},
+	{
+		description:  "forwarded header from an untrusted remote address is ignored",
+		remoteAddr:   "203.0.113.7:5123",
+		forwardedFor: []string{"198.51.100.1"},
+		expIP:        "203.0.113.7",
+	},
+	{
+		description:    "right-most address appended by a trusted proxy",
+		remoteAddr:     "10.0.0.5:5123",
+		forwardedFor:   []string{"198.51.100.1, 203.0.113.7"},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "203.0.113.7",
+	},
+	{
+		description:    "addresses of chained trusted proxies are skipped",
+		remoteAddr:     "10.0.0.5:5123",
+		forwardedFor:   []string{"198.51.100.1, 203.0.113.7", "10.0.1.2"},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "203.0.113.7",
+	},
+	{
+		description:    "trusted proxy as a single address",
+		remoteAddr:     "10.0.0.5:5123",
+		forwardedFor:   []string{"203.0.113.7"},
+		trustedProxies: []string{"10.0.0.5"},
+		expIP:          "203.0.113.7",
+	},
+	{
+		description:    "invalid forwarded address",
+		remoteAddr:     "10.0.0.5:5123",
+		forwardedFor:   []string{"not-an-ip"},
+		trustedProxies: []string{"10.0.0.0/8"},
+		expIP:          "10.0.0.5",
+	},
+}
+
+func TestGetClientIP(t *testing.T) {
+	for _, test := range getClientIPTests {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.RemoteAddr = test.remoteAddr
+
+		for _, forwarded := range test.forwardedFor {
+			req.Header.Add("X-Forwarded-For", forwarded)
+		}
+
+		assert.Equal(
+			t,
+			test.expIP,
+			requestutils.GetClientIP(req, test.trustedProxies),
+			"[ %s ]: client ip not equal",
+			test.description,
+		)
+	}
+}
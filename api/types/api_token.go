@@ -12,6 +12,15 @@ type APITokenMeta struct {
 	PolicyName string `json:"policy_name"`
 	PolicyUID  string `json:"policy_uid"`
 	Name       string `json:"name"`
+	Revoked    bool   `json:"revoked"`
+
+	LastUsedAt        *time.Time `json:"last_used_at,omitempty"`
+	LastUsedIP        string     `json:"last_used_ip,omitempty"`
+	LastUsedUserAgent string     `json:"last_used_user_agent,omitempty"`
+
+	// PreviousSecretExpiresAt is set after a rotation, and is the time at which the token
+	// secret from before the rotation stops working
+	PreviousSecretExpiresAt *time.Time `json:"previous_secret_expires_at,omitempty"`
 }
 
 type APIToken struct {
@@ -21,8 +30,30 @@ type APIToken struct {
 	Token  string            `json:"token,omitempty"`
 }
 
+// CreateAPIToken creates a token which is either bound to an existing policy of the project, or to
+// a new policy which is created from the given policy documents
 type CreateAPIToken struct {
-	PolicyUID string    `json:"policy_uid" form:"required"`
-	ExpiresAt time.Time `json:"expires_at"`
-	Name      string    `json:"name" form:"required"`
+	PolicyUID string            `json:"policy_uid"`
+	Policy    []*PolicyDocument `json:"policy,omitempty"`
+	ExpiresAt time.Time         `json:"expires_at"`
+	Name      string            `json:"name" form:"required"`
+}
+
+type ListAPITokensResponse []*APITokenMeta
+
+type ListStaleAPITokensRequest struct {
+	// UnusedFor is the duration for which a token has not been used before it is considered
+	// stale, defaults to 30 days
+	UnusedFor string `schema:"unused_for"`
+}
+
+type RotateAPITokenRequest struct {
+	// Overlap is the duration for which the secret from before the rotation keeps working,
+	// defaults to 24 hours
+	Overlap string `json:"overlap"`
+}
+
+// UpdateAPITokenPolicyRequest assigns another policy, such as a custom role, to an API token
+type UpdateAPITokenPolicyRequest struct {
+	PolicyUID string `json:"policy_uid" form:"required"`
 }
@@ -0,0 +1,165 @@
+package types
+
+import "time"
+
+type AuditActorKind string
+
+const (
+	AuditActorUser     AuditActorKind = "user"
+	AuditActorAPIToken AuditActorKind = "api_token"
+)
+
+type AuditOutcome string
+
+const (
+	AuditOutcomeSuccess   AuditOutcome = "success"
+	AuditOutcomeFailure   AuditOutcome = "failure"
+	AuditOutcomeForbidden AuditOutcome = "forbidden"
+)
+
+type AuditActor struct {
+	Kind       AuditActorKind `json:"kind"`
+	UserID     uint           `json:"user_id,omitempty"`
+	Name       string         `json:"name"`
+	APITokenID string         `json:"api_token_id,omitempty"`
+	ClientIP   string         `json:"client_ip,omitempty"`
+}
+
+type AuditEvent struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+
+	Actor *AuditActor `json:"actor"`
+
+	ClusterID uint   `json:"cluster_id,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+
+	Verb     APIVerb `json:"verb"`
+	Method   string  `json:"method"`
+	Endpoint string  `json:"endpoint"`
+	Path     string  `json:"path"`
+
+	ResourceScope PermissionScope `json:"resource_scope"`
+	Resource      string          `json:"resource"`
+
+	RequestSummary string `json:"request_summary,omitempty"`
+
+	StatusCode int          `json:"status_code"`
+	Outcome    AuditOutcome `json:"outcome"`
+}
+
+type ListAuditEventsRequest struct {
+	*PaginationRequest
+
+	Actor     string       `schema:"actor"`
+	ClusterID uint         `schema:"cluster_id"`
+	Namespace string       `schema:"namespace"`
+	Verb      APIVerb      `schema:"verb"`
+	Outcome   AuditOutcome `schema:"outcome"`
+	Resource  string       `schema:"resource"`
+	Since     *time.Time   `schema:"since"`
+	Until     *time.Time   `schema:"until"`
+}
+
+type ListAuditEventsResponse struct {
+	Events     []*AuditEvent       `json:"events" form:"required"`
+	Pagination *PaginationResponse `json:"pagination"`
+}
+
+// UpdateAuditSettingsRequest configures the audit log of a project
+type UpdateAuditSettingsRequest struct {
+	// RetentionDays is the number of days for which audit events are kept, or 0 to use the
+	// default retention
+	RetentionDays uint `json:"retention_days" form:"max=3650"`
+}
+
+const URLParamAuditSinkID URLParam = "audit_sink_id"
+
+type AuditSinkKind string
+
+const (
+	AuditSinkSyslog AuditSinkKind = "syslog"
+	AuditSinkS3     AuditSinkKind = "s3"
+	AuditSinkHTTPS  AuditSinkKind = "https"
+)
+
+// SyslogAuditSinkConfig sends audit events as RFC 5424 messages over TCP, framed with octet
+// counting as described in RFC 6587
+type SyslogAuditSinkConfig struct {
+	// Address is the host and port of the syslog server
+	Address string `json:"address" form:"required"`
+
+	TLS bool `json:"tls"`
+
+	// CACertificate is an optional PEM-encoded certificate which is trusted in addition to the
+	// system roots when TLS is enabled
+	CACertificate string `json:"ca_certificate,omitempty"`
+
+	// AppName is the APP-NAME of the syslog messages, defaults to "porter"
+	AppName string `json:"app_name,omitempty"`
+}
+
+// S3AuditSinkConfig writes batches of audit events as NDJSON objects to an S3-compatible bucket
+type S3AuditSinkConfig struct {
+	Bucket string `json:"bucket" form:"required"`
+	Region string `json:"region" form:"required"`
+
+	// Endpoint is the URL of an S3-compatible service, or empty to use AWS
+	Endpoint       string `json:"endpoint,omitempty"`
+	ForcePathStyle bool   `json:"force_path_style"`
+
+	// Prefix is prepended to the key of each object
+	Prefix string `json:"prefix,omitempty"`
+
+	AccessKeyID     string `json:"access_key_id" form:"required"`
+	SecretAccessKey string `json:"secret_access_key" form:"required"`
+}
+
+// HTTPSAuditSinkConfig pushes batches of audit events as NDJSON to an HTTPS endpoint
+type HTTPSAuditSinkConfig struct {
+	URL string `json:"url" form:"required,url"`
+
+	// Authorization is the optional value of the Authorization header
+	Authorization string `json:"authorization,omitempty"`
+
+	// Secret optionally signs each batch in the X-Porter-Signature header
+	Secret string `json:"secret,omitempty"`
+}
+
+// AuditSinkConfig holds the configuration of the kind of the sink
+type AuditSinkConfig struct {
+	Syslog *SyslogAuditSinkConfig `json:"syslog,omitempty"`
+	S3     *S3AuditSinkConfig     `json:"s3,omitempty"`
+	HTTPS  *HTTPSAuditSinkConfig  `json:"https,omitempty"`
+}
+
+type AuditSink struct {
+	ID        uint          `json:"id"`
+	CreatedAt time.Time     `json:"created_at"`
+	ProjectID uint          `json:"project_id"`
+	Name      string        `json:"name"`
+	Kind      AuditSinkKind `json:"kind"`
+
+	// LastEventID is the ID of the last audit event which was delivered to the sink
+	LastEventID    uint       `json:"last_event_id"`
+	LastExportedAt *time.Time `json:"last_exported_at,omitempty"`
+
+	FailureCount  uint       `json:"failure_count"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	LastError     string     `json:"last_error,omitempty"`
+}
+
+type CreateAuditSinkRequest struct {
+	*AuditSinkConfig
+
+	Name string        `json:"name" form:"required,max=255"`
+	Kind AuditSinkKind `json:"kind" form:"required,oneof=syslog s3 https"`
+}
+
+type ListAuditSinksResponse []*AuditSink
+
+// ReplayAuditSinkRequest resends every audit event which was created at or after Since
+type ReplayAuditSinkRequest struct {
+	Since time.Time `json:"since" form:"required"`
+}
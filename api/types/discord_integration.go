@@ -0,0 +1,21 @@
+package types
+
+const (
+	URLParamDiscordIntegrationID = "discord_integration_id"
+)
+
+type DiscordIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the Discord channel that the webhook posts to
+	Channel string `json:"channel"`
+}
+
+type CreateDiscordIntegrationRequest struct {
+	Channel string `json:"channel" form:"required,max=255"`
+	Webhook string `json:"webhook" form:"required,url"`
+}
+
+type ListDiscordIntegrationsResponse []*DiscordIntegration
@@ -11,19 +11,25 @@ type Environment struct {
 	GitRepoName       string   `json:"git_repo_name"`
 	GitRepoBranches   []string `json:"git_repo_branches"`
 
+	GitlabIntegrationID uint `json:"gitlab_integration_id,omitempty"`
+
 	Name                 string            `json:"name"`
 	Mode                 string            `json:"mode"`
 	DeploymentCount      uint              `json:"deployment_count"`
 	LastDeploymentStatus string            `json:"last_deployment_status"`
 	NewCommentsDisabled  bool              `json:"new_comments_disabled"`
+	GithubChecksEnabled  bool              `json:"github_checks_enabled"`
 	NamespaceLabels      map[string]string `json:"namespace_labels,omitempty"`
 	GitDeployBranches    []string          `json:"git_deploy_branches"`
+	DeploymentTTLHours   uint              `json:"deployment_ttl_hours"`
+	IdleSleepHours       uint              `json:"idle_sleep_hours"`
 }
 
 type CreateEnvironmentRequest struct {
 	Name               string            `json:"name" form:"required"`
 	Mode               string            `json:"mode" form:"oneof=auto manual" default:"manual"`
 	DisableNewComments bool              `json:"disable_new_comments"`
+	EnableGithubChecks bool              `json:"enable_github_checks"`
 	GitRepoBranches    []string          `json:"git_repo_branches"`
 	NamespaceLabels    map[string]string `json:"namespace_labels"`
 	GitDeployBranches  []string          `json:"git_deploy_branches"`
@@ -31,6 +37,7 @@ type CreateEnvironmentRequest struct {
 
 type GitHubMetadata struct {
 	DeploymentID int64  `json:"gh_deployment_id"`
+	CheckRunID   int64  `json:"gh_check_run_id,omitempty"`
 	PRName       string `json:"gh_pr_name"`
 	RepoName     string `json:"gh_repo_name"`
 	RepoOwner    string `json:"gh_repo_owner"`
@@ -64,6 +71,8 @@ type Deployment struct {
 	InstallationID     uint             `json:"gh_installation_id"`
 	LastWorkflowRunURL string           `json:"last_workflow_run_url"`
 	LastErrors         string           `json:"last_errors"`
+	LastPushedAt       time.Time        `json:"last_pushed_at"`
+	IsSleeping         bool             `json:"is_sleeping"`
 }
 
 type CreateGHDeploymentRequest struct {
@@ -205,4 +214,16 @@ type UpdateEnvironmentSettingsRequest struct {
 	GitRepoBranches    []string          `json:"git_repo_branches"`
 	NamespaceLabels    map[string]string `json:"namespace_labels"`
 	GitDeployBranches  []string          `json:"git_deploy_branches"`
+
+	// EnableGithubChecks reports deployments as GitHub check runs. Checks are left unchanged
+	// when it is not set.
+	EnableGithubChecks *bool `json:"enable_github_checks,omitempty"`
+
+	// DeploymentTTLHours must be between 24 (1 day) and 720 (30 days), or 0 to use the TTL
+	// configured for the whole instance. The TTL is left unchanged when it is not set.
+	DeploymentTTLHours *uint `json:"deployment_ttl_hours,omitempty" form:"omitempty,eq=0|min=24,max=720"`
+
+	// IdleSleepHours is the number of hours without pushes after which the deployments are
+	// scaled to zero, or 0 to disable idle sleep. Idle sleep is left unchanged when it is not set.
+	IdleSleepHours *uint `json:"idle_sleep_hours,omitempty" form:"omitempty,max=720"`
 }
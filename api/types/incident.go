@@ -19,6 +19,17 @@ const (
 	InvolvedObjectPod        InvolvedObjectKind = "pod"
 )
 
+// IncidentReason is an optional classification of the cause of an incident, which is used to
+// emit the matching deployment event
+type IncidentReason string
+
+const (
+	IncidentReasonOOMKilled        IncidentReason = "oom_killed"
+	IncidentReasonReadinessTimeout IncidentReason = "readiness_timeout"
+	IncidentReasonHPAMaxedOut      IncidentReason = "hpa_maxed_out"
+	IncidentReasonJobFailed        IncidentReason = "job_failed"
+)
+
 type IncidentStatus string
 
 const (
@@ -44,6 +55,7 @@ type IncidentMeta struct {
 	ShouldViewLogs          bool               `json:"should_view_logs"`
 	Revision                string             `json:"revision"`
 	PorterDocLink           string             `json:"porter_doc_link"`
+	Reason                  IncidentReason     `json:"reason,omitempty"`
 }
 
 type PaginationRequest struct {
@@ -33,3 +33,25 @@ type MonitorTestResult struct {
 
 	Severity MonitorTestSeverity `json:"severity"`
 }
+
+// MonitorNotificationConfig configures the notifications sent when a monitor of the project
+// starts failing or recovers
+type MonitorNotificationConfig struct {
+	Enabled bool `json:"enabled"`
+
+	// Email sends the notifications to the members of the project, in addition to Slack
+	Email bool `json:"email"`
+
+	// MinSeverity is the lowest severity of the monitors which are notified
+	MinSeverity MonitorTestSeverity `json:"min_severity"`
+
+	// MutedCategories are the monitor categories which are never notified
+	MutedCategories []string `json:"muted_categories"`
+}
+
+type UpdateMonitorNotificationConfigRequest struct {
+	Enabled         bool     `json:"enabled"`
+	Email           bool     `json:"email"`
+	MinSeverity     string   `json:"min_severity" form:"omitempty,oneof=critical high low"`
+	MutedCategories []string `json:"muted_categories" form:"omitempty,dive,required,excludesall=0x2C"`
+}
@@ -0,0 +1,55 @@
+package types
+
+import "time"
+
+const URLParamNotificationWebhookID URLParam = "notification_webhook_id"
+
+type NotificationWebhook struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	ProjectID uint      `json:"project_id"`
+
+	Name string `json:"name"`
+	URL  string `json:"url"`
+
+	// if the cluster ID is set, the webhook only receives the events of a single release
+	ClusterID        uint   `json:"cluster_id,omitempty"`
+	ReleaseName      string `json:"release_name,omitempty"`
+	ReleaseNamespace string `json:"release_namespace,omitempty"`
+}
+
+type CreateNotificationWebhookRequest struct {
+	Name string `json:"name" form:"required,max=255"`
+
+	// the URL must use https, and must not resolve to a private address
+	URL string `json:"url" form:"required,url"`
+
+	ClusterID        uint   `json:"cluster_id"`
+	ReleaseName      string `json:"release_name" form:"required_with=ClusterID"`
+	ReleaseNamespace string `json:"release_namespace" form:"required_with=ClusterID"`
+}
+
+// CreateNotificationWebhookResponse contains the secret which signs the payloads, which is only
+// returned when the webhook is created
+type CreateNotificationWebhookResponse struct {
+	*NotificationWebhook
+
+	Secret string `json:"secret"`
+}
+
+type ListNotificationWebhooksResponse []*NotificationWebhook
+
+type NotificationWebhookDelivery struct {
+	ID                    uint       `json:"id"`
+	CreatedAt             time.Time  `json:"created_at"`
+	NotificationWebhookID uint       `json:"notification_webhook_id"`
+	DeliveryID            string     `json:"delivery_id"`
+	Event                 string     `json:"event"`
+	Attempts              uint       `json:"attempts"`
+	StatusCode            int        `json:"status_code,omitempty"`
+	Error                 string     `json:"error,omitempty"`
+	Success               bool       `json:"success"`
+	CompletedAt           *time.Time `json:"completed_at,omitempty"`
+}
+
+type ListNotificationWebhookDeliveriesResponse []*NotificationWebhookDelivery
@@ -0,0 +1,70 @@
+package types
+
+import "time"
+
+const URLParamOPAPolicyCollectionName URLParam = "opa_policy_name"
+
+// OPAPolicyMatch selects the objects a policy collection is evaluated against. Which fields are
+// used depends on the kind of the collection.
+type OPAPolicyMatch struct {
+	// KubernetesService restricts the collection to clusters of a service, like `eks`
+	KubernetesService string `json:"kubernetes_service,omitempty"`
+
+	// parameters for Helm releases
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	ChartName string `json:"chart_name,omitempty"`
+
+	// labels for pods, daemonsets and resource kinds
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// parameters for CRDs and generic resources
+	Group    string `json:"group,omitempty"`
+	Version  string `json:"version,omitempty"`
+	Resource string `json:"resource,omitempty"`
+
+	// Related lists the kinds of the objects in the same namespace which are passed to the policies
+	// under input.related, like `pod_disruption_budget`. Only supported for resource kinds.
+	Related []string `json:"related,omitempty"`
+}
+
+// OPAPolicy is a single rego module. Name is the package of the module, which is queried
+// by the recommender.
+type OPAPolicy struct {
+	Name   string `json:"name" form:"required"`
+	Module string `json:"module" form:"required"`
+}
+
+type OPAPolicyCollection struct {
+	ID        uint      `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	ProjectID uint      `json:"project_id"`
+
+	Name             string         `json:"name"`
+	Kind             string         `json:"kind"`
+	Match            OPAPolicyMatch `json:"match"`
+	MustExist        bool           `json:"must_exist"`
+	OverrideSeverity string         `json:"override_severity,omitempty"`
+	Policies         []*OPAPolicy   `json:"policies"`
+}
+
+// CreateOPAPolicyCollectionRequest creates a policy collection, or replaces the collection
+// with the same name
+type CreateOPAPolicyCollectionRequest struct {
+	Name             string         `json:"name" form:"required,max=63"`
+	Kind             string         `json:"kind" form:"required,oneof=helm_release pod crd_list daemonset deployment statefulset service ingress horizontal_pod_autoscaler pod_disruption_budget network_policy resource"`
+	Match            OPAPolicyMatch `json:"match"`
+	MustExist        bool           `json:"must_exist"`
+	OverrideSeverity string         `json:"override_severity" form:"omitempty,oneof=critical high low"`
+	Policies         []*OPAPolicy   `json:"policies" form:"required,min=1,dive,required"`
+}
+
+type ListOPAPolicyCollectionsResponse []*OPAPolicyCollection
+
+// UpdateOPAAdmissionRequest sets whether policies are evaluated before releases in the project are
+// deployed. In enforce mode, releases which fail a policy are rejected, while in audit mode they are
+// deployed and the failures are returned as Warning headers of the response.
+type UpdateOPAAdmissionRequest struct {
+	Mode string `json:"mode" form:"required,oneof=enforce audit disabled"`
+}
@@ -28,11 +28,22 @@ type NameOrUInt struct {
 	UInt uint   `json:"uint"`
 }
 
+// PolicyDocument grants the verbs on the resources of a scope. The names of the resources may be
+// glob patterns such as "team-a-*", and namespaces and releases can also be matched by a Kubernetes
+// label selector on their labels, such as "team=a,env in (staging,prod)". Resources which do not
+// exist are never matched by a label selector. The labels of a release are the labels of the Helm
+// storage secret of its latest revision.
 type PolicyDocument struct {
-	Scope     PermissionScope                     `json:"scope"`
-	Resources []NameOrUInt                        `json:"resources"`
-	Verbs     []APIVerb                           `json:"verbs"`
-	Children  map[PermissionScope]*PolicyDocument `json:"children"`
+	Scope            PermissionScope                     `json:"scope"`
+	Resources        []NameOrUInt                        `json:"resources"`
+	ResourceSelector string                              `json:"resource_selector,omitempty"`
+	Verbs            []APIVerb                           `json:"verbs"`
+	Children         map[PermissionScope]*PolicyDocument `json:"children"`
+
+	// ReadOnlyParents only requires the scopes above a requested resource to grant get, so that a
+	// policy can grant write access to a single cluster, namespace or release without granting write
+	// access to the project or cluster which contains it. It is only read from the root document.
+	ReadOnlyParents bool `json:"read_only_parents,omitempty"`
 }
 
 type ScopeTree map[PermissionScope]ScopeTree
@@ -180,6 +191,11 @@ type CreatePolicy struct {
 	Policy []*PolicyDocument `json:"policy" form:"required"`
 }
 
+type UpdatePolicy struct {
+	Name   string            `json:"name"`
+	Policy []*PolicyDocument `json:"policy" form:"required"`
+}
+
 const URLParamPolicyID URLParam = "policy_id"
 
 type APIPolicyMeta struct {
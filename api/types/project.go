@@ -10,6 +10,24 @@ type Project struct {
 	APITokensEnabled       bool    `json:"api_tokens_enabled"`
 	StacksEnabled          bool    `json:"stacks_enabled"`
 	CapiProvisionerEnabled bool    `json:"capi_provisioner_enabled"`
+	OPAAdmissionMode       string  `json:"opa_admission_mode,omitempty"`
+
+	NotificationDigest       string `json:"notification_digest,omitempty"`
+	NotificationChannelLimit uint   `json:"notification_channel_limit,omitempty"`
+
+	AuditLogRetentionDays uint `json:"audit_log_retention_days,omitempty"`
+}
+
+// UpdateNotificationSettingsRequest configures how notifications are sent to the Slack, Teams and
+// Discord channels of a project
+type UpdateNotificationSettingsRequest struct {
+	// Digest is either "hourly" or "daily" to batch low severity notifications into digests, or
+	// empty to send them immediately
+	Digest string `json:"digest" form:"omitempty,oneof=hourly daily"`
+
+	// ChannelLimit is the maximum number of notifications sent to a channel per hour, or 0 to use
+	// the default limit
+	ChannelLimit uint `json:"channel_limit" form:"max=1000"`
 }
 
 type FeatureFlags struct {
@@ -57,6 +75,7 @@ type Collaborator struct {
 	UserID    uint   `json:"user_id"`
 	Email     string `json:"email"`
 	ProjectID uint   `json:"project_id"`
+	PolicyUID string `json:"policy_uid,omitempty"`
 }
 
 type ListCollaboratorsResponse []*Collaborator
@@ -64,6 +83,9 @@ type ListCollaboratorsResponse []*Collaborator
 type UpdateRoleRequest struct {
 	UserID uint   `json:"user_id,required"`
 	Kind   string `json:"kind,required"`
+
+	// PolicyUID is the unique id of the policy to assign, and is required when kind is "custom"
+	PolicyUID string `json:"policy_uid"`
 }
 
 type UpdateRoleResponse struct {
@@ -51,6 +51,14 @@ type UpdateNotificationConfigRequest struct {
 		Enabled bool `json:"enabled"`
 		Success bool `json:"success"`
 		Failure bool `json:"failure"`
+
+		// Events lists the deployment events to notify. If empty, the success and failure settings
+		// are used instead.
+		Events []string `json:"events" form:"omitempty,dive,oneof=helm_deployed pod_crashed helm_failed rollback job_failed job_succeeded readiness_timeout oom_killed hpa_maxed_out"`
+
+		// NotifLimit is the duration during which repeated notifications about the release are
+		// dropped, such as "30m"
+		NotifLimit string `json:"notif_limit"`
 	} `json:"payload"`
 }
 
@@ -183,9 +191,27 @@ type NotificationConfig struct {
 	Success bool `json:"success"`
 	Failure bool `json:"failure"`
 
+	// Events lists the deployment events which are notified, overriding the success and
+	// failure settings when not empty
+	Events []string `json:"events"`
+
 	NotifLimit string `json:"notif_limit"`
 }
 
+// NotifyDeploymentEventRequest is sent by the agent for the deployment events which are not
+// reported as incidents, such as successful job runs
+type NotifyDeploymentEventRequest struct {
+	ReleaseName      string `json:"release_name" form:"required"`
+	ReleaseNamespace string `json:"release_namespace" form:"required"`
+	Event            string `json:"event" form:"required,oneof=rollback job_failed job_succeeded readiness_timeout oom_killed hpa_maxed_out"`
+
+	// JobName is the name of the job run, for job events
+	JobName string `json:"job_name"`
+
+	// Info is any additional information about the event, such as the reason of a failure
+	Info string `json:"info"`
+}
+
 type GetNotificationConfigResponse struct {
 	*NotificationConfig
 }
@@ -80,6 +80,11 @@ const RequestScopeCtxKey = "requestscopes"
 type RequestAction struct {
 	Verb     APIVerb
 	Resource NameOrUInt
+
+	// Labels are the labels of the resource, which are only loaded for namespaces and releases
+	// when a policy matches them by a label selector. Labels are nil if the resource does not
+	// exist, and an empty map if it exists without labels.
+	Labels map[string]string
 }
 
 var RequestCtxWebsocketKey = "websocket"
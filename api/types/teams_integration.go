@@ -0,0 +1,21 @@
+package types
+
+const (
+	URLParamTeamsIntegrationID = "teams_integration_id"
+)
+
+type TeamsIntegration struct {
+	ID uint `json:"id"`
+
+	ProjectID uint `json:"project_id"`
+
+	// The name of the Teams channel that the webhook posts to
+	Channel string `json:"channel"`
+}
+
+type CreateTeamsIntegrationRequest struct {
+	Channel string `json:"channel" form:"required,max=255"`
+	Webhook string `json:"webhook" form:"required,url"`
+}
+
+type ListTeamsIntegrationsResponse []*TeamsIntegration
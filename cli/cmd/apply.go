@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/url"
 	"os"
@@ -27,7 +28,6 @@ import (
 	switchboardModels "github.com/porter-dev/switchboard/pkg/models"
 	"github.com/porter-dev/switchboard/pkg/parser"
 	switchboardTypes "github.com/porter-dev/switchboard/pkg/types"
-	switchboardWorker "github.com/porter-dev/switchboard/pkg/worker"
 	"github.com/rs/zerolog"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v2"
@@ -52,6 +52,11 @@ environment variables will always take precendence over values specified in the
 
 By default, this command expects to be run from a local git repository.
 
+To preview the changes that would be made without building, pushing or deploying anything, use
+the --dry-run flag:
+
+  %s
+
 The following are the environment variables that can be used to set certain values while
 applying a configuration:
   PORTER_CLUSTER              Cluster ID that contains the project
@@ -61,9 +66,17 @@ applying a configuration:
   PORTER_SOURCE_REPO          The URL of the Helm charts registry
   PORTER_SOURCE_VERSION       The version of the Helm chart to use
   PORTER_TAG                  The Docker image tag to use (like the git commit hash)
+
+Resources which do not depend on each other can be applied concurrently by setting the
+--parallelism flag, in which case the output of each resource is prefixed with its name.
+
+When the --atomic flag is set, the revision of every release is recorded before applying, and all
+releases are rolled back to their previous revision if any resource fails. Releases which were
+created during the apply are deleted.
 	`,
 		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply\":"),
 		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply -f porter.yaml --dry-run"),
 	),
 	Run: func(cmd *cobra.Command, args []string) {
 		err := checkLoginAndRun(args, apply)
@@ -94,15 +107,88 @@ var applyValidateCmd = &cobra.Command{
 	},
 }
 
-var porterYAML string
+// applySchemaCmd represents the "porter apply schema" command
+var applySchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Prints the JSON Schema of porter.yaml",
+	Long: fmt.Sprintf(`
+%s
+
+Prints the JSON Schema of porter.yaml, which editors can use to validate and autocomplete
+porter.yaml files locally. By default, the schema accepts both the v1 and v2beta1 formats and
+validates a file against the format given by its version field. For example:
+
+  %s
+
+To only print the schema of a single format, use the --version flag:
+
+  %s
+
+Editors which use the YAML language server (such as VS Code with the YAML extension) pick up
+the schema when the following comment is added to the top of porter.yaml:
+
+  # yaml-language-server: $schema=porter.schema.json
+	`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter apply schema\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply schema > porter.schema.json"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter apply schema --version v2beta1"),
+	),
+	Run: func(*cobra.Command, []string) {
+		schema, err := previewInt.GetSchema(applySchemaVersion)
+		if err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "Error: %s\n", err.Error())
+			os.Exit(1)
+		}
+
+		fmt.Println(string(schema))
+	},
+}
+
+var (
+	porterYAML         string
+	applySchemaVersion string
+	applyDryRun        bool
+	applyParallelism   int
+	applyAtomic        bool
+)
 
 func init() {
 	rootCmd.AddCommand(applyCmd)
 
 	applyCmd.AddCommand(applyValidateCmd)
+	applyCmd.AddCommand(applySchemaCmd)
 
 	applyCmd.PersistentFlags().StringVarP(&porterYAML, "file", "f", "", "path to porter.yaml")
 	applyCmd.MarkFlagRequired("file")
+
+	applySchemaCmd.Flags().StringVar(
+		&applySchemaVersion,
+		"version",
+		"",
+		fmt.Sprintf("only print the schema of the given porter.yaml version, one of: %s",
+			strings.Join(previewInt.SchemaVersions, ", ")),
+	)
+
+	applyCmd.Flags().BoolVar(
+		&applyDryRun,
+		"dry-run",
+		false,
+		"print the changes that would be made to each resource without building or deploying anything",
+	)
+
+	applyCmd.Flags().IntVar(
+		&applyParallelism,
+		"parallelism",
+		1,
+		"the maximum number of resources that are applied concurrently, once their dependencies have been applied",
+	)
+
+	applyCmd.Flags().BoolVar(
+		&applyAtomic,
+		"atomic",
+		false,
+		"if any resource fails to apply, roll back every release to the revision it had before the apply",
+	)
 }
 
 func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string) error {
@@ -126,7 +212,7 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 	if previewVersion.Version == "v2beta1" {
 		ns := os.Getenv("PORTER_NAMESPACE")
 
-		applier, err := previewV2Beta1.NewApplier(client, fileBytes, ns)
+		applier, err := previewV2Beta1.NewApplier(client, fileBytes, ns, applyDryRun)
 		if err != nil {
 			return err
 		}
@@ -158,7 +244,11 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 		return fmt.Errorf("error getting working directory: %w", err)
 	}
 
-	worker := switchboardWorker.NewWorker()
+	if applyDryRun {
+		return applyPlan(resGroup, basePath)
+	}
+
+	worker := preview.NewParallelWorker(applyParallelism)
 	worker.RegisterDriver("deploy", NewDeployDriver)
 	worker.RegisterDriver("build-image", preview.NewBuildDriver)
 	worker.RegisterDriver("push-image", preview.NewPushDriver)
@@ -190,18 +280,72 @@ func apply(_ *types.GetAuthenticatedUserResponse, client *api.Client, _ []string
 	cloneEnvGroupHook := NewCloneEnvGroupHook(client, resGroup)
 	worker.RegisterHook("cloneenvgroup", cloneEnvGroupHook)
 
+	if applyAtomic {
+		atomicRollbackHook := NewAtomicRollbackHook(client, resGroup)
+		worker.RegisterHook("atomicrollback", atomicRollbackHook)
+	}
+
 	return worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
 		BasePath: basePath,
 	})
 }
 
+// applyPlan resolves every resource in the resource group using the dry-run drivers, and prints
+// the diff between the rendered values and the currently deployed releases
+func applyPlan(resGroup *switchboardTypes.ResourceGroup, basePath string) error {
+	color.New(color.FgBlue, color.Bold).Println("Running in dry-run mode: no images will be built and no releases will be modified")
+
+	plan := preview.NewPlan()
+
+	worker := preview.NewParallelWorker(applyParallelism)
+	worker.RegisterDriver("deploy", preview.NewPlanDeployDriver(plan, "deploy"))
+	worker.RegisterDriver("build-image", preview.NewPlanImageDriver(plan, "build-image"))
+	worker.RegisterDriver("push-image", preview.NewPlanImageDriver(plan, "push-image"))
+	worker.RegisterDriver("update-config", preview.NewPlanDeployDriver(plan, "update-config"))
+	worker.RegisterDriver("random-string", preview.NewRandomStringDriver)
+	worker.RegisterDriver("env-group", preview.NewPlanEnvGroupDriver(plan))
+	worker.RegisterDriver("os-env", preview.NewOSEnvDriver)
+
+	worker.SetDefaultDriver("deploy")
+
+	// no hooks are registered in dry-run mode, so a plan never reports to the API
+	err := worker.Apply(resGroup, &switchboardTypes.ApplyOpts{
+		BasePath: basePath,
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Println()
+
+	plan.Print()
+
+	return nil
+}
+
 func applyValidate() error {
 	fileBytes, err := ioutil.ReadFile(porterYAML)
 	if err != nil {
 		return fmt.Errorf("error reading porter.yaml: %w", err)
 	}
 
-	validationErrors := previewInt.Validate(string(fileBytes))
+	var previewVersion struct {
+		Version string `json:"version"`
+	}
+
+	err = yaml.Unmarshal(fileBytes, &previewVersion)
+
+	if err != nil {
+		return fmt.Errorf("error unmarshaling porter.yaml: %w", err)
+	}
+
+	var validationErrors []error
+
+	if previewVersion.Version == "v2beta1" {
+		validationErrors = previewInt.ValidateV2Beta1(string(fileBytes))
+	} else {
+		validationErrors = previewInt.Validate(string(fileBytes))
+	}
 
 	if len(validationErrors) > 0 {
 		errString := "the following error(s) were found while validating the porter.yaml file:"
@@ -217,7 +361,8 @@ func applyValidate() error {
 }
 
 func hasDeploymentHookEnvVars() bool {
-	if ghIDStr := os.Getenv("PORTER_GIT_INSTALLATION_ID"); ghIDStr == "" {
+	// preview environments of GitLab repositories set PORTER_GITLAB_INTEGRATION_ID instead
+	if os.Getenv("PORTER_GIT_INSTALLATION_ID") == "" && os.Getenv("PORTER_GITLAB_INTEGRATION_ID") == "" {
 		return false
 	}
 
@@ -258,6 +403,7 @@ type DeployDriver struct {
 	output      map[string]interface{}
 	lookupTable *map[string]drivers.Driver
 	logger      *zerolog.Logger
+	out         io.Writer
 }
 
 func NewDeployDriver(resource *switchboardModels.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
@@ -265,6 +411,7 @@ func NewDeployDriver(resource *switchboardModels.Resource, opts *drivers.SharedD
 		lookupTable: opts.DriverLookupTable,
 		logger:      opts.Logger,
 		output:      make(map[string]interface{}),
+		out:         os.Stdout,
 	}
 
 	target, err := preview.GetTarget(resource.Name, resource.Target)
@@ -284,6 +431,10 @@ func NewDeployDriver(resource *switchboardModels.Resource, opts *drivers.SharedD
 	return driver, nil
 }
 
+func (d *DeployDriver) SetOutput(out io.Writer) {
+	d.out = out
+}
+
 func (d *DeployDriver) ShouldApply(_ *switchboardModels.Resource) bool {
 	return true
 }
@@ -302,7 +453,7 @@ func (d *DeployDriver) Apply(resource *switchboardModels.Resource) (*switchboard
 	shouldCreate := err != nil
 
 	if err != nil {
-		color.New(color.FgYellow).Printf("Could not read release %s/%s (%s): attempting creation\n", d.target.Namespace, resource.Name, err.Error())
+		color.New(color.FgYellow).Fprintf(d.out, "Could not read release %s/%s (%s): attempting creation\n", d.target.Namespace, resource.Name, err.Error())
 	}
 
 	if d.source.IsApplication {
@@ -388,7 +539,7 @@ func (d *DeployDriver) applyApplication(resource *switchboardModels.Resource, cl
 	tag := os.Getenv("PORTER_TAG")
 
 	if tag == "" {
-		color.New(color.FgYellow).Printf("for resource %s, since PORTER_TAG is not set, the Docker image tag will default to"+
+		color.New(color.FgYellow).Fprintf(d.out, "for resource %s, since PORTER_TAG is not set, the Docker image tag will default to"+
 			" the git repo SHA\n", resourceName)
 
 		commit, err := git.LastCommit()
@@ -398,7 +549,7 @@ func (d *DeployDriver) applyApplication(resource *switchboardModels.Resource, cl
 
 		tag = commit.Sha[:7]
 
-		color.New(color.FgYellow).Printf("for resource %s, using tag %s\n", resourceName, tag)
+		color.New(color.FgYellow).Fprintf(d.out, "for resource %s, using tag %s\n", resourceName, tag)
 	}
 
 	// if the method is registry and a tag is defined, we use the provided tag
@@ -447,7 +598,7 @@ func (d *DeployDriver) applyApplication(resource *switchboardModels.Resource, cl
 			return nil, fmt.Errorf("error updating application from resource %s: %w", resourceName, err)
 		}
 	} else {
-		color.New(color.FgYellow).Printf("Skipping creation for resource %s as onlyCreate is set to true\n", resourceName)
+		color.New(color.FgYellow).Fprintf(d.out, "Skipping creation for resource %s as onlyCreate is set to true\n", resourceName)
 	}
 
 	if err = d.assignOutput(resource, client); err != nil {
@@ -455,7 +606,7 @@ func (d *DeployDriver) applyApplication(resource *switchboardModels.Resource, cl
 	}
 
 	if d.source.Name == "job" && appConfig.WaitForJob && (shouldCreate || !appConfig.OnlyCreate) {
-		color.New(color.FgYellow).Printf("Waiting for job '%s' to finish\n", resourceName)
+		color.New(color.FgYellow).Fprintf(d.out, "Waiting for job '%s' to finish\n", resourceName)
 
 		err = wait.WaitForJob(client, &wait.WaitOpts{
 			ProjectID: d.target.Project,
@@ -487,9 +638,9 @@ func (d *DeployDriver) applyApplication(resource *switchboardModels.Resource, cl
 
 func (d *DeployDriver) createApplication(resource *switchboardModels.Resource, client *api.Client, sharedOpts *deploy.SharedOpts, appConf *previewInt.ApplicationConfig) (*switchboardModels.Resource, error) {
 	// create new release
-	color.New(color.FgGreen).Printf("Creating %s release: %s\n", d.source.Name, resource.Name)
+	color.New(color.FgGreen).Fprintf(d.out, "Creating %s release: %s\n", d.source.Name, resource.Name)
 
-	color.New(color.FgBlue).Printf("for resource %s, using registry %s\n", resource.Name, d.target.RegistryURL)
+	color.New(color.FgBlue).Fprintf(d.out, "for resource %s, using registry %s\n", resource.Name, d.target.RegistryURL)
 
 	// attempt to get repo suffix from environment variables
 	var repoSuffix string
@@ -558,7 +709,7 @@ func (d *DeployDriver) createApplication(resource *switchboardModels.Resource, c
 }
 
 func (d *DeployDriver) updateApplication(resource *switchboardModels.Resource, client *api.Client, sharedOpts *deploy.SharedOpts, appConf *previewInt.ApplicationConfig) (*switchboardModels.Resource, error) {
-	color.New(color.FgGreen).Println("Updating existing release:", resource.Name)
+	color.New(color.FgGreen).Fprintln(d.out, "Updating existing release:", resource.Name)
 
 	if len(appConf.Build.Env) > 0 {
 		sharedOpts.AdditionalEnv = appConf.Build.Env
@@ -643,11 +794,7 @@ func (d *DeployDriver) Output() (map[string]interface{}, error) {
 }
 
 func (d *DeployDriver) getApplicationConfig(resource *switchboardModels.Resource) (*previewInt.ApplicationConfig, error) {
-	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
-		RawConf:      resource.Config,
-		LookupTable:  *d.lookupTable,
-		Dependencies: resource.Dependencies,
-	})
+	populatedConf, err := preview.ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -669,18 +816,14 @@ func (d *DeployDriver) getApplicationConfig(resource *switchboardModels.Resource
 }
 
 func (d *DeployDriver) getAddonConfig(resource *switchboardModels.Resource) (map[string]interface{}, error) {
-	return drivers.ConstructConfig(&drivers.ConstructConfigOpts{
-		RawConf:      resource.Config,
-		LookupTable:  *d.lookupTable,
-		Dependencies: resource.Dependencies,
-	})
+	return preview.ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
 }
 
 type DeploymentHook struct {
-	client                                                                    *api.Client
-	resourceGroup                                                             *switchboardTypes.ResourceGroup
-	gitInstallationID, projectID, clusterID, prID, actionID, envID            uint
-	branchFrom, branchInto, namespace, repoName, repoOwner, prName, commitSHA string
+	client                                                                              *api.Client
+	resourceGroup                                                                       *switchboardTypes.ResourceGroup
+	gitInstallationID, gitlabIntegrationID, projectID, clusterID, prID, actionID, envID uint
+	branchFrom, branchInto, namespace, repoName, repoOwner, prName, commitSHA           string
 }
 
 func NewDeploymentHook(client *api.Client, resourceGroup *switchboardTypes.ResourceGroup, namespace string) (*DeploymentHook, error) {
@@ -690,13 +833,22 @@ func NewDeploymentHook(client *api.Client, resourceGroup *switchboardTypes.Resou
 		namespace:     namespace,
 	}
 
-	ghIDStr := os.Getenv("PORTER_GIT_INSTALLATION_ID")
-	ghID, err := strconv.Atoi(ghIDStr)
-	if err != nil {
-		return nil, err
-	}
+	if giIDStr := os.Getenv("PORTER_GITLAB_INTEGRATION_ID"); giIDStr != "" {
+		giID, err := strconv.Atoi(giIDStr)
+		if err != nil {
+			return nil, err
+		}
+
+		res.gitlabIntegrationID = uint(giID)
+	} else {
+		ghIDStr := os.Getenv("PORTER_GIT_INSTALLATION_ID")
+		ghID, err := strconv.Atoi(ghIDStr)
+		if err != nil {
+			return nil, err
+		}
 
-	res.gitInstallationID = uint(ghID)
+		res.gitInstallationID = uint(ghID)
+	}
 
 	prIDStr := os.Getenv("PORTER_PULL_REQUEST_ID")
 	prID, err := strconv.Atoi(prIDStr)
@@ -773,7 +925,8 @@ func (t *DeploymentHook) PreApply() error {
 	for _, env := range envs {
 		if strings.EqualFold(env.GitRepoOwner, t.repoOwner) &&
 			strings.EqualFold(env.GitRepoName, t.repoName) &&
-			env.GitInstallationID == t.gitInstallationID {
+			env.GitInstallationID == t.gitInstallationID &&
+			env.GitlabIntegrationID == t.gitlabIntegrationID {
 			t.envID = env.ID
 			deplEnv = env
 			break
@@ -1187,6 +1340,158 @@ func (t *CloneEnvGroupHook) OnError(error) {}
 
 func (t *CloneEnvGroupHook) OnConsolidatedErrors(map[string]error) {}
 
+// releaseRevision is the state of a release before porter.yaml was applied
+type releaseRevision struct {
+	target      *previewInt.Target
+	releaseName string
+
+	// the revision of the release before the apply, or 0 if the release did not exist
+	revision int
+}
+
+// AtomicRollbackHook records the revision of every release managed by the resource group before
+// the apply, and rolls all of them back if any resource fails to apply or if a post-apply hook
+// fails after the releases have been upgraded
+type AtomicRollbackHook struct {
+	client     *api.Client
+	resGroup   *switchboardTypes.ResourceGroup
+	revisions  []*releaseRevision
+	rolledBack bool
+}
+
+func NewAtomicRollbackHook(client *api.Client, resourceGroup *switchboardTypes.ResourceGroup) *AtomicRollbackHook {
+	return &AtomicRollbackHook{
+		client:   client,
+		resGroup: resourceGroup,
+	}
+}
+
+func (t *AtomicRollbackHook) PreApply() error {
+	for _, res := range t.resGroup.Resources {
+		// only the deploy and update-config drivers create or upgrade releases
+		if res.Driver != "" && res.Driver != "deploy" && res.Driver != "update-config" {
+			continue
+		}
+
+		target, err := preview.GetTarget(res.Name, res.Target)
+		if err != nil {
+			return err
+		}
+
+		rev := &releaseRevision{
+			target:      target,
+			releaseName: getReleaseName(res),
+		}
+
+		release, err := t.client.GetRelease(
+			context.Background(),
+			target.Project,
+			target.Cluster,
+			target.Namespace,
+			rev.releaseName,
+		)
+
+		if err != nil && !preview.IsReleaseNotFound(err) {
+			// we cannot tell whether the release exists, so we refuse to apply rather than risk
+			// deleting an existing release during a rollback
+			return fmt.Errorf("error reading release %s/%s before apply: %w", target.Namespace, rev.releaseName, err)
+		}
+
+		if err == nil {
+			if release.Release == nil {
+				return fmt.Errorf("could not determine the revision of release %s/%s before apply",
+					target.Namespace, rev.releaseName)
+			}
+
+			rev.revision = release.Version
+		}
+
+		t.revisions = append(t.revisions, rev)
+	}
+
+	return nil
+}
+
+func (t *AtomicRollbackHook) DataQueries() map[string]interface{} {
+	return nil
+}
+
+func (t *AtomicRollbackHook) PostApply(map[string]interface{}) error {
+	return nil
+}
+
+func (t *AtomicRollbackHook) OnError(error) {}
+
+func (t *AtomicRollbackHook) OnConsolidatedErrors(map[string]error) {
+	if t.rolledBack {
+		return
+	}
+
+	t.rolledBack = true
+
+	color.New(color.FgYellow, color.Bold).Println("Rolling back all releases since --atomic is set")
+
+	for _, rev := range t.revisions {
+		err := t.rollback(rev)
+
+		if err != nil {
+			color.New(color.FgRed).Fprintf(os.Stderr, "Error rolling back release %s/%s: %s\n",
+				rev.target.Namespace, rev.releaseName, err.Error())
+		}
+	}
+}
+
+func (t *AtomicRollbackHook) rollback(rev *releaseRevision) error {
+	release, err := t.client.GetRelease(
+		context.Background(),
+		rev.target.Project,
+		rev.target.Cluster,
+		rev.target.Namespace,
+		rev.releaseName,
+	)
+
+	if preview.IsReleaseNotFound(err) {
+		// the release does not exist, so there is nothing to roll back
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("error reading release: %w", err)
+	} else if release.Release == nil {
+		return fmt.Errorf("could not determine the current revision of the release")
+	}
+
+	if rev.revision == 0 {
+		color.New(color.FgYellow).Printf("Deleting release %s/%s which was created during this apply\n",
+			rev.target.Namespace, rev.releaseName)
+
+		return t.client.DeleteRelease(
+			context.Background(),
+			rev.target.Project,
+			rev.target.Cluster,
+			rev.target.Namespace,
+			rev.releaseName,
+		)
+	}
+
+	if release.Version == rev.revision {
+		// the release was not modified during this apply
+		return nil
+	}
+
+	color.New(color.FgYellow).Printf("Rolling back release %s/%s from revision %d to revision %d\n",
+		rev.target.Namespace, rev.releaseName, release.Version, rev.revision)
+
+	return t.client.RollbackRelease(
+		context.Background(),
+		rev.target.Project,
+		rev.target.Cluster,
+		rev.target.Namespace,
+		rev.releaseName,
+		&types.RollbackReleaseRequest{
+			Revision: rev.revision,
+		},
+	)
+}
+
 func getReleaseName(res *switchboardTypes.Resource) string {
 	// can ignore the error because this method is called once
 	// GetTarget has alrealy been called and validated previously
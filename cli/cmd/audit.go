@@ -0,0 +1,259 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	auditActor     string
+	auditCluster   uint
+	auditNamespace string
+	auditVerb      string
+	auditOutcome   string
+	auditResource  string
+	auditSince     time.Duration
+	auditPage      int64
+	auditReplayFor time.Duration
+)
+
+// auditCmd represents the "porter audit" base command when called
+// without any subcommands
+var auditCmd = &cobra.Command{
+	Use:   "audit",
+	Short: "Commands that read and export the audit log of the current project",
+}
+
+var auditListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the mutating API calls in the current project, newest first",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listAuditEvents)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var auditSinkListCmd = &cobra.Command{
+	Use:   "sinks",
+	Short: "Lists the sinks which receive the audit log of the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listAuditSinks)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var auditReplayCmd = &cobra.Command{
+	Use:   "replay [sink-id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Sends the audit events of the given duration to a sink again",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, replayAuditSink)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(auditCmd)
+
+	auditCmd.AddCommand(auditListCmd)
+	auditCmd.AddCommand(auditSinkListCmd)
+	auditCmd.AddCommand(auditReplayCmd)
+
+	auditReplayCmd.PersistentFlags().DurationVar(
+		&auditReplayFor,
+		"since",
+		24*time.Hour,
+		"resend the audit events created within this duration",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditActor,
+		"actor",
+		"",
+		"only list calls made by the user with this email, or the API token with this name or id",
+	)
+
+	auditListCmd.PersistentFlags().UintVar(
+		&auditCluster,
+		"cluster",
+		0,
+		"only list calls in the cluster with this id",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditNamespace,
+		"namespace",
+		"",
+		"only list calls in this namespace",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditVerb,
+		"verb",
+		"",
+		"only list calls with this verb (create, update or delete)",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditOutcome,
+		"outcome",
+		"",
+		"only list calls with this outcome (success, failure or forbidden)",
+	)
+
+	auditListCmd.PersistentFlags().StringVar(
+		&auditResource,
+		"resource",
+		"",
+		"only list calls targeting the resource with this name or id",
+	)
+
+	auditListCmd.PersistentFlags().DurationVar(
+		&auditSince,
+		"since",
+		0,
+		"only list calls made within this duration, such as 24h",
+	)
+
+	auditListCmd.PersistentFlags().Int64Var(
+		&auditPage,
+		"page",
+		0,
+		"the page of results to list",
+	)
+}
+
+func listAuditEvents(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := &types.ListAuditEventsRequest{
+		PaginationRequest: &types.PaginationRequest{
+			Page: auditPage,
+		},
+		Actor:     auditActor,
+		ClusterID: auditCluster,
+		Namespace: auditNamespace,
+		Verb:      types.APIVerb(auditVerb),
+		Outcome:   types.AuditOutcome(auditOutcome),
+		Resource:  auditResource,
+	}
+
+	if auditSince != 0 {
+		since := time.Now().Add(-auditSince)
+		req.Since = &since
+	}
+
+	resp, err := client.ListAuditEvents(context.Background(), cliConf.Project, req)
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "TIME", "ACTOR", "VERB", "RESOURCE", "PATH", "OUTCOME")
+
+	for _, event := range resp.Events {
+		actor := event.Actor.Name
+
+		if event.Actor.Kind == types.AuditActorAPIToken {
+			actor = fmt.Sprintf("%s (token)", actor)
+		}
+
+		resource := event.Resource
+
+		if event.ResourceScope != "" {
+			resource = fmt.Sprintf("%s/%s", event.ResourceScope, event.Resource)
+		}
+
+		line := fmt.Sprintf(
+			"%s\t%s\t%s\t%s\t%s\t%s\n",
+			event.CreatedAt.Format(time.RFC3339),
+			actor,
+			event.Verb,
+			resource,
+			event.Path,
+			event.Outcome,
+		)
+
+		if event.Outcome == types.AuditOutcomeSuccess {
+			fmt.Fprint(w, line)
+		} else {
+			color.New(color.FgRed).Fprint(w, line)
+		}
+	}
+
+	w.Flush()
+
+	if resp.Pagination != nil && resp.Pagination.NextPage != 0 {
+		fmt.Printf("Showing page %d of %d, use --page %d to list older calls\n",
+			resp.Pagination.CurrentPage+1, resp.Pagination.NumPages, resp.Pagination.NextPage)
+	}
+
+	return nil
+}
+
+func listAuditSinks(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.ListAuditSinks(context.Background(), cliConf.Project)
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", "ID", "NAME", "KIND", "LAST EXPORTED", "STATUS")
+
+	for _, sink := range *resp {
+		lastExported := "never"
+
+		if sink.LastExportedAt != nil {
+			lastExported = sink.LastExportedAt.Format(time.RFC3339)
+		}
+
+		status := "ok"
+
+		if sink.LastError != "" {
+			status = fmt.Sprintf("failing (%d attempts): %s", sink.FailureCount, sink.LastError)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\n", sink.ID, sink.Name, sink.Kind, lastExported, status)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func replayAuditSink(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	sinkID, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return err
+	}
+
+	since := time.Now().Add(-auditReplayFor)
+
+	_, err = client.ReplayAuditSink(context.Background(), cliConf.Project, uint(sinkID), &types.ReplayAuditSinkRequest{
+		Since: since,
+	})
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Audit events since %s will be sent to sink %d again\n", since.Format(time.RFC3339), sinkID)
+
+	return nil
+}
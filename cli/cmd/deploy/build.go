@@ -20,6 +20,9 @@ type BuildAgent struct {
 	ImageRepo   string
 	Env         map[string]string
 	ImageExists bool
+
+	// CacheImage overrides the default image used as the build cache when UseCache is set
+	CacheImage string
 }
 
 // BuildDocker uses the local Docker daemon to build the image
@@ -51,18 +54,51 @@ func (b *BuildAgent) BuildDocker(
 		UseCache:          b.UseCache,
 	}
 
+	if b.UseCache && b.CacheImage != "" {
+		// the cache image has to exist locally for its layers to be reused, so we attempt to pull
+		// it first. This is not fatal since the cache image does not exist on the first build.
+		err := dockerAgent.PullImage(b.CacheImage)
+
+		if err != nil {
+			fmt.Printf("could not pull cache image %s, building without cache: %s\n", b.CacheImage, err.Error())
+		} else {
+			opts.CacheImage = b.CacheImage
+		}
+	}
+
 	return dockerAgent.BuildLocal(
 		opts,
 	)
 }
 
+// PushDockerCache tags the image built with the given tag as the cache image and pushes it, so
+// that subsequent builds can reuse its layers
+func (b *BuildAgent) PushDockerCache(dockerAgent *docker.Agent, tag string) error {
+	if !b.UseCache || b.CacheImage == "" {
+		return nil
+	}
+
+	err := dockerAgent.TagImage(fmt.Sprintf("%s:%s", b.ImageRepo, tag), b.CacheImage)
+	if err != nil {
+		return err
+	}
+
+	return dockerAgent.PushImage(b.CacheImage)
+}
+
 // BuildPack uses the cloud-native buildpack client to build a container image
 func (b *BuildAgent) BuildPack(dockerAgent *docker.Agent, dst, tag, prevTag string, buildConfig *types.BuildConfig) error {
-	// retag the image with "pack-cache" tag so that it doesn't re-pull from the registry
+	cacheImage := fmt.Sprintf("%s:%s", b.ImageRepo, "pack-cache")
+
+	if b.CacheImage != "" {
+		cacheImage = b.CacheImage
+	}
+
+	// retag the image with the cache tag so that it doesn't re-pull from the registry
 	if b.ImageExists {
 		err := dockerAgent.TagImage(
 			fmt.Sprintf("%s:%s", b.ImageRepo, prevTag),
-			fmt.Sprintf("%s:%s", b.ImageRepo, "pack-cache"),
+			cacheImage,
 		)
 		if err != nil {
 			return err
@@ -81,7 +117,7 @@ func (b *BuildAgent) BuildPack(dockerAgent *docker.Agent, dst, tag, prevTag stri
 	}
 
 	// call builder
-	return packAgent.Build(opts, buildConfig, fmt.Sprintf("%s:%s", b.ImageRepo, "pack-cache"))
+	return packAgent.Build(opts, buildConfig, cacheImage)
 }
 
 // ResolveDockerPaths returns a path to the dockerfile that is either relative or absolute, and a path
@@ -29,6 +29,10 @@ type BuildOpts struct {
 	IsDockerfileInCtx bool
 	UseCache          bool
 
+	// CacheImage is the image used as the layer cache for the build. If it is empty, the image
+	// with the current tag is used instead.
+	CacheImage string
+
 	Env map[string]string
 }
 
@@ -84,6 +88,12 @@ func (a *Agent) BuildLocal(opts *BuildOpts) (err error) {
 	inlineCacheVal := "1"
 	buildArgs["BUILDKIT_INLINE_CACHE"] = &inlineCacheVal
 
+	cacheFrom := fmt.Sprintf("%s:%s", opts.ImageRepo, opts.CurrentTag)
+
+	if opts.CacheImage != "" {
+		cacheFrom = opts.CacheImage
+	}
+
 	out, err := a.ImageBuild(context.Background(), tar, types.ImageBuildOptions{
 		Dockerfile: dockerfilePath,
 		BuildArgs:  buildArgs,
@@ -91,7 +101,7 @@ func (a *Agent) BuildLocal(opts *BuildOpts) (err error) {
 			fmt.Sprintf("%s:%s", opts.ImageRepo, opts.Tag),
 		},
 		CacheFrom: []string{
-			fmt.Sprintf("%s:%s", opts.ImageRepo, opts.CurrentTag),
+			cacheFrom,
 		},
 		Remove:   true,
 		Platform: "linux/amd64",
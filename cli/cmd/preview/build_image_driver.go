@@ -76,6 +76,9 @@ func (d *BuildDriver) Apply(resource *models.Resource) (*models.Resource, error)
 		tag = commit.Sha[:7]
 	}
 
+	// use_pack_cache is kept for backwards compatibility, and is equivalent to use_cache
+	useCache := d.config.Build.UsePackCache || d.config.Build.UseCache
+
 	// if the method is registry and a tag is defined, we use the provided tag
 	if d.config.Build.Method == "registry" {
 		imageSpl := strings.Split(d.config.Build.Image, ":")
@@ -122,7 +125,7 @@ func (d *BuildDriver) Apply(resource *models.Resource) (*models.Resource, error)
 				LocalDockerfile: d.config.Build.Dockerfile,
 				Method:          deploy.DeployBuildType(d.config.Build.Method),
 				EnvGroups:       d.config.EnvGroups,
-				UseCache:        d.config.Build.UsePackCache,
+				UseCache:        useCache,
 			},
 			Kind:        d.source.Name,
 			ReleaseName: d.target.AppName,
@@ -136,13 +139,14 @@ func (d *BuildDriver) Apply(resource *models.Resource) (*models.Resource, error)
 		return nil, err
 	}
 
-	if d.config.Build.UsePackCache {
+	if useCache {
 		err := config.SetDockerConfig(client)
 		if err != nil {
 			return nil, err
 		}
 
-		if d.config.Build.Method == "pack" {
+		// the image repository has to exist before the cache image can be pushed to it
+		if d.config.Build.Method != "registry" {
 			repoResp, err := client.ListRegistryRepositories(context.Background(), d.target.Project, regID)
 			if err != nil {
 				return nil, err
@@ -252,6 +256,14 @@ func (d *BuildDriver) Apply(resource *models.Resource) (*models.Resource, error)
 		ImageExists: false,
 	}
 
+	if useCache {
+		buildAgent.CacheImage = d.config.Build.CacheImage
+
+		if buildAgent.CacheImage == "" {
+			buildAgent.CacheImage = getDefaultCacheImage(imageURL, d.config.Build.Method)
+		}
+	}
+
 	if d.config.Build.Method == string(deploy.DeployBuildTypeDocker) {
 		var basePath string
 
@@ -269,6 +281,10 @@ func (d *BuildDriver) Apply(resource *models.Resource) (*models.Resource, error)
 			tag,
 			"",
 		)
+
+		if err == nil {
+			err = buildAgent.PushDockerCache(agent, tag)
+		}
 	} else {
 		var buildConfig *types.BuildConfig
 
@@ -304,16 +320,22 @@ func (d *BuildDriver) Apply(resource *models.Resource) (*models.Resource, error)
 	return resource, nil
 }
 
+// getDefaultCacheImage returns the image used as the build cache when no cache image is configured,
+// which lives in the same image repository as the application image
+func getDefaultCacheImage(imageURL, method string) string {
+	if method == string(deploy.DeployBuildTypeDocker) {
+		return fmt.Sprintf("%s:%s", imageURL, "docker-cache")
+	}
+
+	return fmt.Sprintf("%s:%s", imageURL, "pack-cache")
+}
+
 func (d *BuildDriver) Output() (map[string]interface{}, error) {
 	return d.output, nil
 }
 
 func (d *BuildDriver) getConfig(resource *models.Resource) (*preview.BuildDriverConfig, error) {
-	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
-		RawConf:      resource.Config,
-		LookupTable:  *d.lookupTable,
-		Dependencies: resource.Dependencies,
-	})
+	populatedConf, err := ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
 	if err != nil {
 		return nil, err
 	}
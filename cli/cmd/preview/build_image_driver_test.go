@@ -0,0 +1,25 @@
+package preview
+
+import "testing"
+
+func TestGetDefaultCacheImage(t *testing.T) {
+	tests := []struct {
+		method   string
+		expImage string
+	}{
+		{
+			method:   "docker",
+			expImage: "registry.example.com/web:docker-cache",
+		},
+		{
+			method:   "pack",
+			expImage: "registry.example.com/web:pack-cache",
+		},
+	}
+
+	for _, tt := range tests {
+		if image := getDefaultCacheImage("registry.example.com/web", tt.method); image != tt.expImage {
+			t.Errorf("expected cache image %s for method %s, got %s", tt.expImage, tt.method, image)
+		}
+	}
+}
@@ -3,6 +3,8 @@ package preview
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 
 	"github.com/fatih/color"
 	"github.com/mitchellh/mapstructure"
@@ -18,12 +20,14 @@ type EnvGroupDriver struct {
 	lookupTable *map[string]drivers.Driver
 	target      *preview.Target
 	config      *preview.EnvGroupDriverConfig
+	out         io.Writer
 }
 
 func NewEnvGroupDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
 	driver := &EnvGroupDriver{
 		lookupTable: opts.DriverLookupTable,
 		output:      make(map[string]interface{}),
+		out:         os.Stdout,
 	}
 
 	target, err := GetTarget(resource.Name, resource.Target)
@@ -36,6 +40,10 @@ func NewEnvGroupDriver(resource *models.Resource, opts *drivers.SharedDriverOpts
 	return driver, nil
 }
 
+func (d *EnvGroupDriver) SetOutput(out io.Writer) {
+	d.out = out
+}
+
 func (d *EnvGroupDriver) ShouldApply(resource *models.Resource) bool {
 	return true
 }
@@ -56,7 +64,7 @@ func (d *EnvGroupDriver) Apply(resource *models.Resource) (*models.Resource, err
 		}
 
 		if group.Namespace == "" {
-			color.New(color.FgYellow).Printf("env group %s has empty namespace so defaulting to target namespace %s\n",
+			color.New(color.FgYellow).Fprintf(d.out, "env group %s has empty namespace so defaulting to target namespace %s\n",
 				group.Name, d.target.Namespace)
 
 			group.Namespace = d.target.Namespace
@@ -107,11 +115,7 @@ func (d *EnvGroupDriver) Output() (map[string]interface{}, error) {
 }
 
 func (d *EnvGroupDriver) getConfig(resource *models.Resource) (*preview.EnvGroupDriverConfig, error) {
-	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
-		RawConf:      resource.Config,
-		LookupTable:  *d.lookupTable,
-		Dependencies: resource.Dependencies,
-	})
+	populatedConf, err := ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,96 @@
+package preview
+
+import (
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/cli/cli/git"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+)
+
+var (
+	gitContextOnce sync.Once
+	gitContext     map[string]string
+)
+
+// getGitContext returns the git branch and commit of the repository being applied. Values set by
+// the preview environment workflow take precedence over the local git repository.
+func getGitContext() map[string]string {
+	gitContextOnce.Do(func() {
+		gitContext = make(map[string]string)
+
+		if branch := os.Getenv("PORTER_BRANCH_FROM"); branch != "" {
+			gitContext["branch"] = branch
+		} else if branch, err := git.CurrentBranch(); err == nil {
+			gitContext["branch"] = branch
+		}
+
+		if commit, err := git.LastCommit(); err == nil {
+			gitContext["sha"] = commit.Sha
+
+			if len(commit.Sha) >= 7 {
+				gitContext["short_sha"] = commit.Sha[:7]
+			}
+		}
+	})
+
+	return gitContext
+}
+
+func getApplyEnv() map[string]string {
+	res := make(map[string]string)
+
+	for _, key := range os.Environ() {
+		name, val, found := strings.Cut(key, "=")
+
+		if found && strings.HasPrefix(name, "PORTER_APPLY_") {
+			res[strings.TrimPrefix(name, "PORTER_APPLY_")] = val
+		}
+	}
+
+	return res
+}
+
+// ConstructConfig evaluates the porter.yaml expressions in the config of the resource, and then
+// populates the switchboard queries using the outputs of the resource's dependencies
+func ConstructConfig(
+	resource *models.Resource,
+	lookupTable map[string]drivers.Driver,
+	namespace string,
+) (map[string]interface{}, error) {
+	ctx := &preview.ExpressionContext{
+		Env:       getApplyEnv(),
+		Git:       getGitContext(),
+		Namespace: namespace,
+		Outputs:   make(map[string]map[string]interface{}),
+	}
+
+	for _, dep := range resource.Dependencies {
+		driver, ok := lookupTable[dep]
+
+		if !ok {
+			continue
+		}
+
+		output, err := driver.Output()
+		if err != nil {
+			return nil, err
+		}
+
+		ctx.Outputs[dep] = output
+	}
+
+	rawConf, err := preview.EvaluateExpressions(resource.Config, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+		RawConf:      rawConf,
+		LookupTable:  lookupTable,
+		Dependencies: resource.Dependencies,
+	})
+}
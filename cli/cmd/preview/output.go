@@ -0,0 +1,82 @@
+package preview
+
+import (
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/fatih/color"
+)
+
+// all prefixed writers share the same lock so that lines written by resources that are
+// applied concurrently are never interleaved
+var outputMu sync.Mutex
+
+// OutputSetter is implemented by drivers which write their output to the writer given by the
+// ParallelWorker, which prefixes every line written with the name of the resource
+type OutputSetter interface {
+	SetOutput(out io.Writer)
+}
+
+type prefixedWriter struct {
+	prefix string
+	out    io.Writer
+
+	mu  sync.Mutex
+	buf []byte
+}
+
+func newPrefixedWriter(out io.Writer, resourceName string) *prefixedWriter {
+	return &prefixedWriter{
+		prefix: color.New(color.FgCyan).Sprintf("[%s] ", resourceName),
+		out:    out,
+	}
+}
+
+// Write buffers the given bytes and writes every complete line to the underlying writer
+func (w *prefixedWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.buf = append(w.buf, p...)
+
+	for {
+		idx := bytes.IndexByte(w.buf, '\n')
+
+		if idx < 0 {
+			break
+		}
+
+		if err := w.writeLine(w.buf[:idx+1]); err != nil {
+			return 0, err
+		}
+
+		w.buf = w.buf[idx+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any remaining partial line to the underlying writer
+func (w *prefixedWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if len(w.buf) == 0 {
+		return nil
+	}
+
+	line := append(w.buf, '\n')
+	w.buf = nil
+
+	return w.writeLine(line)
+}
+
+func (w *prefixedWriter) writeLine(line []byte) error {
+	outputMu.Lock()
+	defer outputMu.Unlock()
+
+	_, err := w.out.Write(append([]byte(w.prefix), line...))
+
+	return err
+}
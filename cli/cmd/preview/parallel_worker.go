@@ -0,0 +1,374 @@
+package preview
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+	"github.com/porter-dev/switchboard/pkg/types"
+	"github.com/rs/zerolog"
+)
+
+// DriverFunc constructs the driver for a single resource
+type DriverFunc func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error)
+
+// WorkerHook is called by the ParallelWorker before and after the resources are applied
+type WorkerHook interface {
+	PreApply() error
+	DataQueries() map[string]interface{}
+	PostApply(populatedData map[string]interface{}) error
+	OnError(err error)
+	OnConsolidatedErrors(errors map[string]error)
+}
+
+// ParallelWorker applies a resource group by executing its dependency graph concurrently:
+// a resource is applied as soon as all of the resources it depends on have been applied,
+// with at most parallelism resources being applied at the same time.
+type ParallelWorker struct {
+	parallelism   int
+	defaultDriver string
+	drivers       map[string]DriverFunc
+	hookNames     []string
+	hooks         map[string]WorkerHook
+
+	// the writers for the output of every resource, which are only written while drivers are
+	// constructed and read while resources are applied
+	outputs map[string]io.Writer
+}
+
+func NewParallelWorker(parallelism int) *ParallelWorker {
+	if parallelism < 1 {
+		parallelism = 1
+	}
+
+	return &ParallelWorker{
+		parallelism: parallelism,
+		drivers:     make(map[string]DriverFunc),
+		hooks:       make(map[string]WorkerHook),
+		outputs:     make(map[string]io.Writer),
+	}
+}
+
+func (w *ParallelWorker) RegisterDriver(name string, driverFunc DriverFunc) {
+	w.drivers[name] = driverFunc
+}
+
+func (w *ParallelWorker) SetDefaultDriver(name string) {
+	w.defaultDriver = name
+}
+
+// RegisterHook registers a hook. Hooks are called in the order in which they are registered.
+func (w *ParallelWorker) RegisterHook(name string, hook WorkerHook) {
+	if _, exists := w.hooks[name]; !exists {
+		w.hookNames = append(w.hookNames, name)
+	}
+
+	w.hooks[name] = hook
+}
+
+type resourceResult struct {
+	name string
+	err  error
+}
+
+func (w *ParallelWorker) Apply(group *types.ResourceGroup, opts *types.ApplyOpts) error {
+	graph, err := preview.GetDependencyGraph(group.Resources)
+	if err != nil {
+		return w.onError(fmt.Errorf("error resolving dependencies: %w", err))
+	}
+
+	for _, name := range w.hookNames {
+		if err := w.hooks[name].PreApply(); err != nil {
+			return w.onError(fmt.Errorf("error running pre-apply hook '%s': %w", name, err))
+		}
+	}
+
+	modelResources := toModelResources(group.Resources, graph)
+
+	// all drivers are constructed before any resource is applied, so that the lookup table is
+	// only ever read while resources are being applied concurrently
+	lookupTable := make(map[string]drivers.Driver)
+	driverErrors := make(map[string]error)
+
+	for _, res := range group.Resources {
+		driverName := res.Driver
+
+		if driverName == "" {
+			driverName = w.defaultDriver
+		}
+
+		driverFunc, ok := w.drivers[driverName]
+
+		if !ok {
+			driverErrors[res.Name] = fmt.Errorf("no such driver '%s'", driverName)
+			continue
+		}
+
+		out := newPrefixedWriter(os.Stdout, res.Name)
+		w.outputs[res.Name] = out
+
+		logger := zerolog.New(zerolog.ConsoleWriter{Out: out, NoColor: true}).With().Timestamp().Logger()
+
+		driver, err := driverFunc(modelResources[res.Name], &drivers.SharedDriverOpts{
+			BaseDir:           opts.BasePath,
+			DriverLookupTable: &lookupTable,
+			Logger:            &logger,
+		})
+		if err != nil {
+			driverErrors[res.Name] = fmt.Errorf("error creating driver: %w", err)
+			continue
+		}
+
+		if setter, ok := driver.(OutputSetter); ok {
+			setter.SetOutput(out)
+		}
+
+		lookupTable[res.Name] = driver
+	}
+
+	if len(driverErrors) > 0 {
+		return w.onConsolidatedErrors(driverErrors)
+	}
+
+	if errors := w.execute(group.Resources, graph, modelResources, lookupTable); len(errors) > 0 {
+		return w.onConsolidatedErrors(errors)
+	}
+
+	for _, name := range w.hookNames {
+		hook := w.hooks[name]
+
+		populatedData := resolveDataQueries(hook.DataQueries(), lookupTable, modelResources)
+
+		if err := hook.PostApply(populatedData); err != nil {
+			// the resources have already been applied at this point, so hooks are given the chance
+			// to act on the failure in the same way as for resources which failed to apply
+			return w.onConsolidatedErrors(map[string]error{
+				name: fmt.Errorf("error running post-apply hook '%s': %w", name, err),
+			})
+		}
+	}
+
+	return nil
+}
+
+// output returns the writer for the output of the given resource
+func (w *ParallelWorker) output(resourceName string) io.Writer {
+	if out, ok := w.outputs[resourceName]; ok {
+		return out
+	}
+
+	return os.Stdout
+}
+
+// execute applies the resources in dependency order and returns the errors keyed by resource name.
+// Resources whose dependencies failed are not applied and are reported as errors as well.
+func (w *ParallelWorker) execute(
+	resources []*types.Resource,
+	graph map[string][]string,
+	modelResources map[string]*models.Resource,
+	lookupTable map[string]drivers.Driver,
+) map[string]error {
+	errors := make(map[string]error)
+	done := make(map[string]bool)
+	started := make(map[string]bool)
+
+	results := make(chan resourceResult)
+	sem := make(chan struct{}, w.parallelism)
+
+	var wg sync.WaitGroup
+
+	running := 0
+
+	// startReady starts every resource whose dependencies have been applied. Skipping a resource
+	// marks it as done, which can make its dependents ready, so we loop until nothing is skipped.
+	startReady := func() {
+		for skipped := true; skipped; {
+			skipped = false
+
+			for _, res := range resources {
+				if started[res.Name] {
+					continue
+				}
+
+				ready := true
+				var failedDep string
+
+				for _, dep := range graph[res.Name] {
+					if !done[dep] {
+						ready = false
+					} else if _, failed := errors[dep]; failed {
+						failedDep = dep
+					}
+				}
+
+				if !ready {
+					continue
+				}
+
+				started[res.Name] = true
+
+				if failedDep != "" {
+					errors[res.Name] = fmt.Errorf("skipped because dependency '%s' failed", failedDep)
+					done[res.Name] = true
+					skipped = true
+
+					color.New(color.FgYellow).Fprintf(w.output(res.Name), "skipped: dependency '%s' failed\n", failedDep)
+
+					continue
+				}
+
+				running++
+				wg.Add(1)
+
+				go func(name string) {
+					defer wg.Done()
+
+					sem <- struct{}{}
+					defer func() { <-sem }()
+
+					results <- resourceResult{
+						name: name,
+						err:  w.applyResource(modelResources[name], lookupTable[name]),
+					}
+				}(res.Name)
+			}
+		}
+	}
+
+	// every resource which is not done is either running or waits on a running resource, since
+	// the dependency graph has no cycles, so we block on the results until nothing is running
+	for startReady(); running > 0; startReady() {
+		result := <-results
+		running--
+
+		done[result.name] = true
+
+		if result.err != nil {
+			errors[result.name] = result.err
+		}
+	}
+
+	wg.Wait()
+
+	return errors
+}
+
+func (w *ParallelWorker) applyResource(resource *models.Resource, driver drivers.Driver) error {
+	out := w.output(resource.Name)
+
+	if pw, ok := out.(*prefixedWriter); ok {
+		defer pw.Flush()
+	}
+
+	if !driver.ShouldApply(resource) {
+		color.New(color.FgBlue).Fprintln(out, "skipping apply")
+		return nil
+	}
+
+	start := time.Now()
+
+	color.New(color.FgBlue).Fprintln(out, "applying")
+
+	_, err := driver.Apply(resource)
+	if err != nil {
+		color.New(color.FgRed).Fprintf(out, "error: %s\n", err.Error())
+		return err
+	}
+
+	color.New(color.FgGreen).Fprintf(out, "applied in %s\n", time.Since(start).Round(time.Second))
+
+	return nil
+}
+
+func (w *ParallelWorker) onError(err error) error {
+	for _, name := range w.hookNames {
+		w.hooks[name].OnError(err)
+	}
+
+	return err
+}
+
+// onConsolidatedErrors passes the errors keyed by resource or hook name to every hook, and
+// returns a single error listing the names
+func (w *ParallelWorker) onConsolidatedErrors(errors map[string]error) error {
+	for _, name := range w.hookNames {
+		w.hooks[name].OnConsolidatedErrors(errors)
+	}
+
+	var names []string
+
+	for name := range errors {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return fmt.Errorf("error applying resource(s): %s", strings.Join(names, ", "))
+}
+
+func toModelResources(resources []*types.Resource, graph map[string][]string) map[string]*models.Resource {
+	res := make(map[string]*models.Resource)
+
+	for _, resource := range resources {
+		res[resource.Name] = &models.Resource{
+			Name:   resource.Name,
+			Driver: resource.Driver,
+			Source: resource.Source,
+			Target: resource.Target,
+			Config: resource.Config,
+		}
+	}
+
+	for name, deps := range graph {
+		for _, dep := range deps {
+			res[name].Dependencies = append(res[name].Dependencies, dep)
+		}
+	}
+
+	return res
+}
+
+// resolveDataQueries populates the queries requested by a hook using the outputs of the applied
+// resources. Queries which cannot be resolved are omitted from the result.
+func resolveDataQueries(
+	queries map[string]interface{},
+	lookupTable map[string]drivers.Driver,
+	modelResources map[string]*models.Resource,
+) map[string]interface{} {
+	res := make(map[string]interface{})
+
+	if len(queries) == 0 {
+		return res
+	}
+
+	var allResources []string
+
+	for name := range modelResources {
+		allResources = append(allResources, name)
+	}
+
+	for key, query := range queries {
+		populated, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+			RawConf:      map[string]interface{}{key: query},
+			LookupTable:  lookupTable,
+			Dependencies: allResources,
+		})
+		if err != nil {
+			continue
+		}
+
+		if val, ok := populated[key]; ok {
+			res[key] = val
+		}
+	}
+
+	return res
+}
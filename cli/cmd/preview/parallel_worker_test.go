@@ -0,0 +1,327 @@
+package preview
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+	"github.com/porter-dev/switchboard/pkg/types"
+)
+
+// testApplyLog records the order in which resources are applied by the worker
+type testApplyLog struct {
+	mu      sync.Mutex
+	applied []string
+}
+
+func (l *testApplyLog) add(name string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.applied = append(l.applied, name)
+}
+
+func (l *testApplyLog) index(name string) int {
+	for i, applied := range l.applied {
+		if applied == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// testDriver resolves its config against the outputs of its dependencies and uses the
+// populated config as its output
+type testDriver struct {
+	lookupTable *map[string]drivers.Driver
+	log         *testApplyLog
+	fail        bool
+	output      map[string]interface{}
+}
+
+func newTestDriverFunc(log *testApplyLog, failing ...string) DriverFunc {
+	return func(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+		driver := &testDriver{
+			lookupTable: opts.DriverLookupTable,
+			log:         log,
+		}
+
+		for _, name := range failing {
+			if name == resource.Name {
+				driver.fail = true
+			}
+		}
+
+		return driver, nil
+	}
+}
+
+func (d *testDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *testDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	d.log.add(resource.Name)
+
+	if d.fail {
+		return nil, fmt.Errorf("failed to apply")
+	}
+
+	populated, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
+		RawConf:      resource.Config,
+		LookupTable:  *d.lookupTable,
+		Dependencies: resource.Dependencies,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	d.output = populated
+
+	return resource, nil
+}
+
+func (d *testDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+// testHook records the calls made by the worker
+type testHook struct {
+	queries       map[string]interface{}
+	postApplyErr  error
+	populatedData map[string]interface{}
+	errs          []error
+	consolidated  map[string]error
+}
+
+func (h *testHook) PreApply() error {
+	return nil
+}
+
+func (h *testHook) DataQueries() map[string]interface{} {
+	return h.queries
+}
+
+func (h *testHook) PostApply(populatedData map[string]interface{}) error {
+	h.populatedData = populatedData
+
+	return h.postApplyErr
+}
+
+func (h *testHook) OnError(err error) {
+	h.errs = append(h.errs, err)
+}
+
+func (h *testHook) OnConsolidatedErrors(errors map[string]error) {
+	h.consolidated = errors
+}
+
+func newTestWorker(parallelism int, log *testApplyLog, hook *testHook, failing ...string) *ParallelWorker {
+	worker := NewParallelWorker(parallelism)
+	worker.RegisterDriver("test", newTestDriverFunc(log, failing...))
+	worker.SetDefaultDriver("test")
+	worker.RegisterHook("test", hook)
+
+	return worker
+}
+
+func TestParallelWorkerDependencyOrder(t *testing.T) {
+	group := &types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{Name: "web", DependsOn: []string{"db", "cache"}},
+			{Name: "worker", DependsOn: []string{"db"}},
+			{Name: "db"},
+			{Name: "cache"},
+			{Name: "migrate", DependsOn: []string{"web", "worker"}},
+		},
+	}
+
+	for _, parallelism := range []int{1, 4} {
+		log := &testApplyLog{}
+		hook := &testHook{}
+
+		err := newTestWorker(parallelism, log, hook).Apply(group, &types.ApplyOpts{})
+		if err != nil {
+			t.Fatalf("parallelism %d: %v", parallelism, err)
+		}
+
+		if len(log.applied) != len(group.Resources) {
+			t.Fatalf("parallelism %d: expected %d resources to be applied, got %v", parallelism,
+				len(group.Resources), log.applied)
+		}
+
+		for _, res := range group.Resources {
+			for _, dep := range res.DependsOn {
+				if log.index(dep) > log.index(res.Name) {
+					t.Errorf("parallelism %d: '%s' was applied before its dependency '%s': %v", parallelism,
+						res.Name, dep, log.applied)
+				}
+			}
+		}
+
+		if hook.consolidated != nil {
+			t.Errorf("parallelism %d: unexpected errors: %v", parallelism, hook.consolidated)
+		}
+	}
+}
+
+func TestParallelWorkerCycle(t *testing.T) {
+	group := &types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{Name: "a", DependsOn: []string{"c"}},
+			{Name: "b", DependsOn: []string{"a"}},
+			{Name: "c", DependsOn: []string{"b"}},
+		},
+	}
+
+	log := &testApplyLog{}
+	hook := &testHook{}
+
+	err := newTestWorker(2, log, hook).Apply(group, &types.ApplyOpts{})
+
+	if err == nil || !strings.Contains(err.Error(), "circular") {
+		t.Fatalf("expected circular dependency error, got %v", err)
+	}
+
+	if len(log.applied) != 0 {
+		t.Errorf("expected no resources to be applied, got %v", log.applied)
+	}
+
+	if len(hook.errs) != 1 {
+		t.Errorf("expected the error to be passed to the hook, got %v", hook.errs)
+	}
+}
+
+func TestParallelWorkerOutputs(t *testing.T) {
+	group := &types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{
+				Name: "db",
+				Config: map[string]interface{}{
+					"host": "db.internal",
+				},
+			},
+			{
+				Name:      "web",
+				DependsOn: []string{"db"},
+				Config: map[string]interface{}{
+					"database_host": "{ .db.host }",
+				},
+			},
+		},
+	}
+
+	log := &testApplyLog{}
+	hook := &testHook{
+		queries: map[string]interface{}{
+			"web_database": "{ .web.database_host }",
+		},
+	}
+
+	worker := newTestWorker(2, log, hook)
+
+	if err := worker.Apply(group, &types.ApplyOpts{}); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if hook.populatedData["web_database"] != "db.internal" {
+		t.Errorf("expected the output of db to be propagated to the hook through web, got %v", hook.populatedData)
+	}
+
+	if _, ok := worker.output("web").(*prefixedWriter); !ok {
+		t.Errorf("expected a prefixed writer for the output of web")
+	}
+}
+
+func TestParallelWorkerFailedDependency(t *testing.T) {
+	group := &types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{Name: "db"},
+			{Name: "web", DependsOn: []string{"db"}},
+			{Name: "worker", DependsOn: []string{"web"}},
+			{Name: "docs"},
+		},
+	}
+
+	log := &testApplyLog{}
+	hook := &testHook{}
+
+	err := newTestWorker(2, log, hook, "db").Apply(group, &types.ApplyOpts{})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if log.index("web") != -1 {
+		t.Errorf("expected web to be skipped, got %v", log.applied)
+	}
+
+	if log.index("docs") == -1 {
+		t.Errorf("expected docs to be applied, got %v", log.applied)
+	}
+
+	if log.index("worker") != -1 {
+		t.Errorf("expected worker to be skipped since web was skipped, got %v", log.applied)
+	}
+
+	if len(hook.consolidated) != 3 || hook.consolidated["db"] == nil || hook.consolidated["web"] == nil ||
+		hook.consolidated["worker"] == nil {
+		t.Errorf("expected errors for db, web and worker, got %v", hook.consolidated)
+	}
+}
+
+func TestParallelWorkerDriverError(t *testing.T) {
+	group := &types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{Name: "web"},
+			{Name: "db", Driver: "unknown"},
+		},
+	}
+
+	log := &testApplyLog{}
+	hook := &testHook{}
+
+	err := newTestWorker(2, log, hook).Apply(group, &types.ApplyOpts{})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if len(log.applied) != 0 {
+		t.Errorf("expected no resources to be applied, got %v", log.applied)
+	}
+
+	if hook.consolidated["db"] == nil {
+		t.Errorf("expected the driver error to be passed to the hook, got %v", hook.consolidated)
+	}
+}
+
+func TestParallelWorkerPostApplyError(t *testing.T) {
+	group := &types.ResourceGroup{
+		Version: "v1",
+		Resources: []*types.Resource{
+			{Name: "web"},
+		},
+	}
+
+	log := &testApplyLog{}
+	hook := &testHook{
+		postApplyErr: fmt.Errorf("post-apply failed"),
+	}
+
+	err := newTestWorker(1, log, hook).Apply(group, &types.ApplyOpts{})
+	if err == nil {
+		t.Fatalf("expected error")
+	}
+
+	if hook.consolidated["test"] == nil {
+		t.Errorf("expected the post-apply error to be passed to the hook, got %v", hook.consolidated)
+	}
+}
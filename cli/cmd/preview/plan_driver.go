@@ -0,0 +1,535 @@
+package preview
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cli/cli/git"
+	"github.com/fatih/color"
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/cli/cmd/config"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/porter-dev/porter/internal/templater/utils"
+	"github.com/porter-dev/switchboard/pkg/drivers"
+	"github.com/porter-dev/switchboard/pkg/models"
+)
+
+// placeholder used for values that are only known once an image has been built and pushed
+const planImageRepoPlaceholder = "(known after build)"
+
+type PlanAction string
+
+const (
+	PlanActionCreate PlanAction = "create"
+	PlanActionUpdate PlanAction = "update"
+	PlanActionSkip   PlanAction = "skip"
+	PlanActionNoOp   PlanAction = "no-op"
+)
+
+// PlanChange is the change that "porter apply --dry-run" computed for a single resource
+type PlanChange struct {
+	Resource            string                `json:"resource"`
+	Driver              string                `json:"driver"`
+	Action              PlanAction            `json:"action"`
+	Namespace           string                `json:"namespace,omitempty"`
+	Chart               string                `json:"chart,omitempty"`
+	CurrentChartVersion string                `json:"current_chart_version,omitempty"`
+	TargetChartVersion  string                `json:"target_chart_version,omitempty"`
+	Note                string                `json:"note,omitempty"`
+	Diff                []*preview.ValuesDiff `json:"diff,omitempty"`
+}
+
+// Plan collects the changes computed by the plan drivers during a dry run
+type Plan struct {
+	mu      sync.Mutex
+	changes []*PlanChange
+}
+
+func NewPlan() *Plan {
+	return &Plan{}
+}
+
+func (p *Plan) addChange(change *PlanChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.changes = append(p.changes, change)
+}
+
+// Changes returns the computed changes sorted by resource name
+func (p *Plan) Changes() []*PlanChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	res := make([]*PlanChange, len(p.changes))
+	copy(res, p.changes)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Resource < res[j].Resource
+	})
+
+	return res
+}
+
+// Print writes a human-readable version of the plan to stdout
+func (p *Plan) Print() {
+	var numCreate, numUpdate, numUnchanged int
+
+	for _, change := range p.Changes() {
+		switch change.Action {
+		case PlanActionCreate:
+			numCreate++
+			color.New(color.FgGreen, color.Bold).Printf("+ %s (%s)", change.Resource, change.Driver)
+		case PlanActionUpdate:
+			if len(change.Diff) == 0 && change.CurrentChartVersion == change.TargetChartVersion {
+				numUnchanged++
+				color.New(color.Bold).Printf("  %s (%s)", change.Resource, change.Driver)
+			} else {
+				numUpdate++
+				color.New(color.FgYellow, color.Bold).Printf("~ %s (%s)", change.Resource, change.Driver)
+			}
+		default:
+			numUnchanged++
+			color.New(color.Bold).Printf("  %s (%s)", change.Resource, change.Driver)
+		}
+
+		if change.Namespace != "" {
+			fmt.Printf(" in namespace %s", change.Namespace)
+		}
+
+		fmt.Println()
+
+		if change.Chart != "" {
+			if change.CurrentChartVersion != "" && change.CurrentChartVersion != change.TargetChartVersion {
+				fmt.Printf("    chart: %s %s => %s\n", change.Chart, change.CurrentChartVersion, change.TargetChartVersion)
+			} else {
+				fmt.Printf("    chart: %s %s\n", change.Chart, change.TargetChartVersion)
+			}
+		}
+
+		if change.Note != "" {
+			fmt.Printf("    %s\n", change.Note)
+		}
+
+		for _, diff := range change.Diff {
+			switch diff.Op {
+			case preview.ValuesDiffOpAdd:
+				color.New(color.FgGreen).Printf("    %s\n", diff.String())
+			case preview.ValuesDiffOpRemove:
+				color.New(color.FgRed).Printf("    %s\n", diff.String())
+			default:
+				color.New(color.FgYellow).Printf("    %s\n", diff.String())
+			}
+		}
+	}
+
+	fmt.Printf("\nPlan: %d to create, %d to update, %d unchanged\n", numCreate, numUpdate, numUnchanged)
+}
+
+// PlanDeployDriver is the dry-run counterpart of the "deploy" and "update-config" drivers. Instead of
+// building and upgrading a release, it renders the values that would be sent and diffs them against
+// the currently deployed release.
+type PlanDeployDriver struct {
+	plan        *Plan
+	driverName  string
+	source      *preview.Source
+	target      *preview.Target
+	lookupTable *map[string]drivers.Driver
+	output      map[string]interface{}
+}
+
+// NewPlanDeployDriver returns a driver constructor that records its changes in the given plan
+func NewPlanDeployDriver(plan *Plan, driverName string) func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return func(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+		driver := &PlanDeployDriver{
+			plan:        plan,
+			driverName:  driverName,
+			lookupTable: opts.DriverLookupTable,
+			output:      make(map[string]interface{}),
+		}
+
+		target, err := GetTarget(resource.Name, resource.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		driver.target = target
+
+		source, err := GetSource(target.Project, resource.Name, resource.Source)
+		if err != nil {
+			return nil, err
+		}
+
+		driver.source = source
+
+		return driver, nil
+	}
+}
+
+func (d *PlanDeployDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *PlanDeployDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	populatedConf, err := ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	releaseName := resource.Name
+
+	if d.driverName == "update-config" && d.target.AppName != "" {
+		releaseName = d.target.AppName
+	}
+
+	change := &PlanChange{
+		Resource:           resource.Name,
+		Driver:             d.driverName,
+		Namespace:          d.target.Namespace,
+		Chart:              d.source.Name,
+		TargetChartVersion: d.source.Version,
+	}
+
+	var values map[string]interface{}
+	var imageSection map[string]interface{}
+
+	if d.source.IsApplication || d.driverName == "update-config" {
+		appConf := &preview.ApplicationConfig{}
+
+		if err := mapstructure.Decode(populatedConf, appConf); err != nil {
+			return nil, err
+		}
+
+		values = appConf.Values
+
+		imageSection, err = d.getImageSection(populatedConf, appConf)
+		if err != nil {
+			return nil, err
+		}
+
+		if appConf.Build.Method != "" && appConf.Build.Method != "registry" {
+			change.Note = fmt.Sprintf("image would be built using method '%s' (skipped in dry run)", appConf.Build.Method)
+		}
+	} else {
+		values = populatedConf
+	}
+
+	if values == nil {
+		values = make(map[string]interface{})
+	}
+
+	client := config.GetAPIClient()
+
+	release, err := client.GetRelease(
+		context.Background(),
+		d.target.Project,
+		d.target.Cluster,
+		d.target.Namespace,
+		releaseName,
+	)
+
+	if err != nil && !IsReleaseNotFound(err) {
+		return nil, fmt.Errorf("error reading release %s/%s: %w", d.target.Namespace, releaseName, err)
+	}
+
+	var desired map[string]interface{}
+
+	if err != nil {
+		change.Action = PlanActionCreate
+		desired = utils.CoalesceValues(copyValues(d.source.SourceValues), copyValues(values))
+
+		if imageSection != nil {
+			desired["image"] = imageSection
+		}
+
+		// only show the values set in porter.yaml, rather than every default value of the chart
+		if imageSection != nil {
+			values["image"] = imageSection
+		}
+
+		change.Diff = preview.DiffValues(map[string]interface{}{}, values)
+	} else {
+		change.Action = PlanActionUpdate
+
+		if release.Release != nil && release.Chart != nil && release.Chart.Metadata != nil {
+			change.CurrentChartVersion = release.Chart.Metadata.Version
+		}
+
+		if d.source.IsApplication || d.driverName == "update-config" {
+			desired = utils.CoalesceValues(copyValues(release.Config), values)
+
+			if imageSection != nil {
+				currImage, _ := desired["image"].(map[string]interface{})
+				desired["image"] = utils.CoalesceValues(copyValues(currImage), imageSection)
+			}
+		} else {
+			// addons are upgraded with the values from porter.yaml as-is
+			desired = values
+		}
+
+		change.Diff = preview.DiffValues(release.Config, desired)
+	}
+
+	d.plan.addChange(change)
+
+	d.output = utils.CoalesceValues(copyValues(d.source.SourceValues), copyValues(desired))
+
+	return resource, nil
+}
+
+func (d *PlanDeployDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+// getImageSection computes the image section that an apply would write to the release, using a
+// placeholder for the repository of images that have not been built yet
+func (d *PlanDeployDriver) getImageSection(
+	populatedConf map[string]interface{},
+	appConf *preview.ApplicationConfig,
+) (map[string]interface{}, error) {
+	if d.driverName == "update-config" {
+		updateConf := &preview.UpdateConfigDriverConfig{}
+
+		if err := mapstructure.Decode(populatedConf, updateConf); err != nil {
+			return nil, err
+		}
+
+		tag := os.Getenv("PORTER_TAG")
+
+		if tag == "" {
+			tag = updateConf.UpdateConfig.Tag
+		}
+
+		if tag == "" {
+			var err error
+
+			tag, err = getPlanGitTag()
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		return map[string]interface{}{
+			"repository": strings.Split(updateConf.UpdateConfig.Image, ":")[0],
+			"tag":        tag,
+		}, nil
+	}
+
+	if appConf.Build.Method == "registry" {
+		imageSpl := strings.Split(appConf.Build.Image, ":")
+		tag := "latest"
+
+		if len(imageSpl) == 2 && imageSpl[1] != "" {
+			tag = imageSpl[1]
+		}
+
+		return map[string]interface{}{
+			"repository": imageSpl[0],
+			"tag":        tag,
+		}, nil
+	}
+
+	tag := os.Getenv("PORTER_TAG")
+
+	if tag == "" {
+		var err error
+
+		tag, err = getPlanGitTag()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return map[string]interface{}{
+		"tag": tag,
+	}, nil
+}
+
+// PlanImageDriver is the dry-run counterpart of the "build-image" and "push-image" drivers. It does
+// not build or push anything, and outputs placeholder image values for dependent resources.
+type PlanImageDriver struct {
+	plan       *Plan
+	driverName string
+	output     map[string]interface{}
+}
+
+// NewPlanImageDriver returns a driver constructor that records its changes in the given plan
+func NewPlanImageDriver(plan *Plan, driverName string) func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return func(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+		return &PlanImageDriver{
+			plan:       plan,
+			driverName: driverName,
+			output:     make(map[string]interface{}),
+		}, nil
+	}
+}
+
+func (d *PlanImageDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *PlanImageDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	tag := os.Getenv("PORTER_TAG")
+
+	if tag == "" {
+		var err error
+
+		tag, err = getPlanGitTag()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	d.output["registry_url"] = planImageRepoPlaceholder
+	d.output["image_repo"] = planImageRepoPlaceholder
+	d.output["image_tag"] = tag
+	d.output["image"] = fmt.Sprintf("%s:%s", planImageRepoPlaceholder, tag)
+
+	d.plan.addChange(&PlanChange{
+		Resource: resource.Name,
+		Driver:   d.driverName,
+		Action:   PlanActionSkip,
+		Note:     fmt.Sprintf("image with tag '%s' would be built and pushed (skipped in dry run)", tag),
+	})
+
+	return resource, nil
+}
+
+func (d *PlanImageDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+// PlanEnvGroupDriver is the dry-run counterpart of the "env-group" driver. It reports the env groups
+// that would be created, and outputs the variables of existing env groups.
+type PlanEnvGroupDriver struct {
+	plan        *Plan
+	target      *preview.Target
+	lookupTable *map[string]drivers.Driver
+	output      map[string]interface{}
+}
+
+// NewPlanEnvGroupDriver returns a driver constructor that records its changes in the given plan
+func NewPlanEnvGroupDriver(plan *Plan) func(*models.Resource, *drivers.SharedDriverOpts) (drivers.Driver, error) {
+	return func(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
+		driver := &PlanEnvGroupDriver{
+			plan:        plan,
+			lookupTable: opts.DriverLookupTable,
+			output:      make(map[string]interface{}),
+		}
+
+		target, err := GetTarget(resource.Name, resource.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		driver.target = target
+
+		return driver, nil
+	}
+}
+
+func (d *PlanEnvGroupDriver) ShouldApply(resource *models.Resource) bool {
+	return true
+}
+
+func (d *PlanEnvGroupDriver) Apply(resource *models.Resource) (*models.Resource, error) {
+	populatedConf, err := ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	driverConfig := &preview.EnvGroupDriverConfig{}
+
+	err = mapstructure.Decode(populatedConf, driverConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client := config.GetAPIClient()
+
+	for _, group := range driverConfig.EnvGroups {
+		if group.Name == "" {
+			return nil, fmt.Errorf("env group name cannot be empty")
+		}
+
+		if group.Namespace == "" {
+			group.Namespace = d.target.Namespace
+		}
+
+		change := &PlanChange{
+			Resource:  fmt.Sprintf("%s/%s", resource.Name, group.Name),
+			Driver:    "env-group",
+			Namespace: group.Namespace,
+		}
+
+		envGroupResp, err := client.GetEnvGroup(
+			context.Background(),
+			d.target.Project,
+			d.target.Cluster,
+			group.Namespace,
+			&types.GetEnvGroupRequest{
+				Name: group.Name,
+			},
+		)
+
+		if err != nil && err.Error() == "env group not found" {
+			change.Action = PlanActionCreate
+			change.Note = fmt.Sprintf("env group would be created with %d variable(s)", len(group.Variables))
+
+			d.output[group.Name] = map[string]interface{}{
+				"variables": group.Variables,
+			}
+		} else if err != nil {
+			return nil, err
+		} else {
+			// existing env groups are never updated by the env-group driver
+			change.Action = PlanActionNoOp
+
+			d.output[envGroupResp.Name] = map[string]interface{}{
+				"variables": envGroupResp.Variables,
+			}
+		}
+
+		d.plan.addChange(change)
+	}
+
+	return resource, nil
+}
+
+func (d *PlanEnvGroupDriver) Output() (map[string]interface{}, error) {
+	return d.output, nil
+}
+
+func getPlanGitTag() (string, error) {
+	commit, err := git.LastCommit()
+	if err != nil {
+		return "", fmt.Errorf("error getting last git commit: %w", err)
+	}
+
+	return commit.Sha[:7], nil
+}
+
+// copyValues returns a deep copy of the given values, since CoalesceValues modifies its arguments
+func copyValues(values map[string]interface{}) map[string]interface{} {
+	if values == nil {
+		return nil
+	}
+
+	res := make(map[string]interface{}, len(values))
+
+	for key, val := range values {
+		if mapVal, ok := val.(map[string]interface{}); ok {
+			res[key] = copyValues(mapVal)
+		} else {
+			res[key] = val
+		}
+	}
+
+	return res
+}
@@ -146,11 +146,7 @@ func (d *PushDriver) Output() (map[string]interface{}, error) {
 }
 
 func (d *PushDriver) getConfig(resource *models.Resource) (*preview.PushDriverConfig, error) {
-	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
-		RawConf:      resource.Config,
-		LookupTable:  *d.lookupTable,
-		Dependencies: resource.Dependencies,
-	})
+	populatedConf, err := ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
 	if err != nil {
 		return nil, err
 	}
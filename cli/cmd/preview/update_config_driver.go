@@ -3,6 +3,7 @@ package preview
 import (
 	"context"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 
@@ -26,12 +27,14 @@ type UpdateConfigDriver struct {
 	config      *preview.UpdateConfigDriverConfig
 	lookupTable *map[string]drivers.Driver
 	output      map[string]interface{}
+	out         io.Writer
 }
 
 func NewUpdateConfigDriver(resource *models.Resource, opts *drivers.SharedDriverOpts) (drivers.Driver, error) {
 	driver := &UpdateConfigDriver{
 		lookupTable: opts.DriverLookupTable,
 		output:      make(map[string]interface{}),
+		out:         os.Stdout,
 	}
 
 	target, err := GetTarget(resource.Name, resource.Target)
@@ -51,6 +54,10 @@ func NewUpdateConfigDriver(resource *models.Resource, opts *drivers.SharedDriver
 	return driver, nil
 }
 
+func (d *UpdateConfigDriver) SetOutput(out io.Writer) {
+	d.out = out
+}
+
 func (d *UpdateConfigDriver) ShouldApply(resource *models.Resource) bool {
 	return true
 }
@@ -75,18 +82,18 @@ func (d *UpdateConfigDriver) Apply(resource *models.Resource) (*models.Resource,
 
 	shouldCreate := err != nil
 
-	color.New(color.FgBlue).Println("checking for the existence of PORTER_TAG environment variable for the image tag")
+	color.New(color.FgBlue).Fprintln(d.out, "checking for the existence of PORTER_TAG environment variable for the image tag")
 
 	tag := os.Getenv("PORTER_TAG")
 
 	if tag == "" {
-		color.New(color.FgBlue).Println("PORTER_TAG environment variable not found, checking for update_config.tag in porter.yaml for the image tag")
+		color.New(color.FgBlue).Fprintln(d.out, "PORTER_TAG environment variable not found, checking for update_config.tag in porter.yaml for the image tag")
 
 		tag = d.config.UpdateConfig.Tag
 	}
 
 	if tag == "" {
-		color.New(color.FgBlue).Println("update_config.tag not found in porter.yaml, falling back to the latest git commit SHA as the image tag")
+		color.New(color.FgBlue).Fprintln(d.out, "update_config.tag not found in porter.yaml, falling back to the latest git commit SHA as the image tag")
 
 		commit, err := git.LastCommit()
 		if err != nil {
@@ -127,7 +134,7 @@ func (d *UpdateConfigDriver) Apply(resource *models.Resource) (*models.Resource,
 	}
 
 	if shouldCreate {
-		color.New(color.FgYellow).Printf("Could not read release %s/%s: attempting creation\n", d.target.Namespace, d.target.AppName)
+		color.New(color.FgYellow).Fprintf(d.out, "Could not read release %s/%s: attempting creation\n", d.target.Namespace, d.target.AppName)
 
 		createAgent := &deploy.CreateAgent{
 			Client: client,
@@ -182,7 +189,7 @@ func (d *UpdateConfigDriver) Apply(resource *models.Resource) (*models.Resource,
 	}
 
 	if d.source.Name == "job" && updateConfigDriverConfig.WaitForJob && (shouldCreate || !updateConfigDriverConfig.OnlyCreate) {
-		color.New(color.FgYellow).Printf("Waiting for job '%s' to finish\n", resource.Name)
+		color.New(color.FgYellow).Fprintf(d.out, "Waiting for job '%s' to finish\n", resource.Name)
 
 		err = wait.WaitForJob(client, &wait.WaitOpts{
 			ProjectID: d.target.Project,
@@ -210,11 +217,7 @@ func (d *UpdateConfigDriver) Output() (map[string]interface{}, error) {
 }
 
 func (d *UpdateConfigDriver) getConfig(resource *models.Resource) (*preview.UpdateConfigDriverConfig, error) {
-	populatedConf, err := drivers.ConstructConfig(&drivers.ConstructConfigOpts{
-		RawConf:      resource.Config,
-		LookupTable:  *d.lookupTable,
-		Dependencies: resource.Dependencies,
-	})
+	populatedConf, err := ConstructConfig(resource, *d.lookupTable, d.target.Namespace)
 	if err != nil {
 		return nil, err
 	}
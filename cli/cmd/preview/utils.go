@@ -5,12 +5,19 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
 
 	"github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/porter-dev/porter/internal/integrations/preview"
 )
 
+// IsReleaseNotFound returns true if the error returned by the API when reading a release means
+// that the release does not exist, as opposed to the request failing for another reason
+func IsReleaseNotFound(err error) bool {
+	return err != nil && strings.Contains(err.Error(), "release not found")
+}
+
 func GetSource(projectID uint, resourceName string, input map[string]interface{}) (*preview.Source, error) {
 	output := &preview.Source{}
 
@@ -4,35 +4,40 @@ import (
 	"context"
 	"fmt"
 	"regexp"
+	"strings"
 
 	api "github.com/porter-dev/porter/api/client"
+	apiTypes "github.com/porter-dev/porter/api/types"
 	"github.com/porter-dev/porter/cli/cmd/config"
 	"github.com/porter-dev/switchboard/pkg/types"
 	"gopkg.in/yaml.v3"
 )
 
-// const (
-// 	constantsEnvGroup = "preview-env-constants"
+const (
+	constantsEnvGroup = "preview-env-constants"
 
-// 	defaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789~`!@#$%^&*()_+-={}[]"
-// )
+	// random values are restricted to alphanumeric characters so that they can never be
+	// mistaken for switchboard queries or YAML syntax once they are substituted
+	defaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// matches all instances of ${{ porter.variables.FOO }}
+var variableRefRegex = regexp.MustCompile(`\$\{\{\s*porter\.variables\.([A-Za-z0-9_\-]+)\s*\}\}`)
 
 type PreviewApplier struct {
 	apiClient *api.Client
 	rawBytes  []byte
 	namespace string
 	parsed    *PorterYAML
+	dryRun    bool
 
-	// variablesMap map[string]string
-	// osEnv        map[string]string
-	// envGroups    map[string]*apiTypes.EnvGroup
+	variablesMap map[string]string
+	envGroups    map[string]*apiTypes.EnvGroup
 }
 
-func NewApplier(client *api.Client, raw []byte, namespace string) (*PreviewApplier, error) {
-	// replace all instances of ${{ porter.env.FOO }} with { .get-env.FOO }
-	re := regexp.MustCompile(`\$\{\{\s*porter\.env\.(.*)\s*\}\}`)
-	raw = re.ReplaceAll(raw, []byte("{.get-env.$1}"))
-
+func NewApplier(client *api.Client, raw []byte, namespace string, dryRun bool) (*PreviewApplier, error) {
+	// instances of ${{ porter.env.FOO }} are left as-is, since they are evaluated by the
+	// porter.yaml expression engine when the resources are applied
 	parsed := &PorterYAML{}
 
 	err := yaml.Unmarshal(raw, parsed)
@@ -47,6 +52,18 @@ func NewApplier(client *api.Client, raw []byte, namespace string) (*PreviewAppli
 	// 	return nil, err
 	// }
 
+	err = validateVariablesAndEnvGroups(parsed)
+
+	if err != nil {
+		errMsg := composePreviewMessage("error validating porter.yaml", Error)
+		return nil, fmt.Errorf("%s: %w", errMsg, err)
+	}
+
+	if namespace == "" {
+		// same default as the v1 resource targets
+		namespace = "default"
+	}
+
 	err = validateCLIEnvironment(namespace)
 
 	if err != nil {
@@ -55,13 +72,60 @@ func NewApplier(client *api.Client, raw []byte, namespace string) (*PreviewAppli
 	}
 
 	return &PreviewApplier{
-		apiClient: client,
-		rawBytes:  raw,
-		namespace: namespace,
-		parsed:    parsed,
+		apiClient:    client,
+		rawBytes:     raw,
+		namespace:    namespace,
+		parsed:       parsed,
+		dryRun:       dryRun,
+		variablesMap: make(map[string]string),
+		envGroups:    make(map[string]*apiTypes.EnvGroup),
 	}, nil
 }
 
+func validateVariablesAndEnvGroups(parsed *PorterYAML) error {
+	names := make(map[string]bool)
+
+	for _, v := range parsed.Variables {
+		if v == nil {
+			continue
+		}
+
+		if err := v.validate(); err != nil {
+			return err
+		}
+
+		if names[v.GetName()] {
+			return fmt.Errorf("duplicate variable '%s'", v.GetName())
+		}
+
+		names[v.GetName()] = true
+	}
+
+	egNames := make(map[string]bool)
+
+	for _, eg := range parsed.EnvGroups {
+		if eg == nil {
+			continue
+		}
+
+		if err := eg.validate(); err != nil {
+			return err
+		}
+
+		if egNames[eg.GetName()] {
+			return fmt.Errorf("duplicate env group '%s'", eg.GetName())
+		}
+
+		if eg.GetName() == constantsEnvGroup {
+			return fmt.Errorf("env group name '%s' is reserved", constantsEnvGroup)
+		}
+
+		egNames[eg.GetName()] = true
+	}
+
+	return nil
+}
+
 func validateCLIEnvironment(namespace string) error {
 	if config.GetCLIConfig().Token == "" {
 		return fmt.Errorf("no auth token present, please run 'porter auth login' to authenticate")
@@ -108,10 +172,12 @@ func (a *PreviewApplier) Apply() error {
 		}
 	}
 
-	if !nsFound {
-		// 	errMsg := composePreviewMessage(fmt.Sprintf("namespace '%s' does not exist in project '%d', cluster '%d'",
-		// 		a.namespace, config.GetCLIConfig().Project, config.GetCLIConfig().Cluster), Error)
-		// 	return fmt.Errorf("%s: %w", errMsg, err)
+	if !nsFound && !a.dryRun && a.storesInNamespace() {
+		// variables with once set to true and env groups are stored in the target namespace, which
+		// has to be created beforehand since the CLI never creates namespaces on its own
+		errMsg := composePreviewMessage(fmt.Sprintf("namespace '%s' does not exist in project '%d', cluster '%d'",
+			a.namespace, config.GetCLIConfig().Project, config.GetCLIConfig().Cluster), Error)
+		return fmt.Errorf("%s: variables with once set to true and env groups require an existing namespace", errMsg)
 	}
 
 	printInfoMessage(fmt.Sprintf("Applying porter.yaml with the following attributes:\n"+
@@ -122,26 +188,30 @@ func (a *PreviewApplier) Apply() error {
 		a.namespace),
 	)
 
-	// err = a.readOSEnv()
+	err = a.processVariables()
 
-	// if err != nil {
-	// 	errMsg := composePreviewMessage("error reading OS environment variables", Error)
-	// 	return fmt.Errorf("%s: %w", errMsg, err)
-	// }
+	if err != nil {
+		return err
+	}
 
-	// err = a.processVariables()
+	err = a.processEnvGroups()
 
-	// if err != nil {
-	// 	return err
-	// }
+	if err != nil {
+		return err
+	}
 
-	// err = a.processEnvGroups()
+	return a.substituteVariables()
+}
 
-	// if err != nil {
-	// 	return err
-	// }
+// storesInNamespace returns true if applying the porter.yaml writes env groups to the target namespace
+func (a *PreviewApplier) storesInNamespace() bool {
+	for _, v := range a.parsed.Variables {
+		if v.IsOnce() {
+			return true
+		}
+	}
 
-	return nil
+	return len(a.parsed.EnvGroups) > 0
 }
 
 func (a *PreviewApplier) DowngradeToV1() (*types.ResourceGroup, error) {
@@ -167,6 +237,8 @@ func (a *PreviewApplier) DowngradeToV1() (*types.ResourceGroup, error) {
 			continue
 		}
 
+		a.resolveEnvGroupRefs(b)
+
 		buildRefs[b.GetName()] = b
 
 		bi, err := b.getV1BuildImage()
@@ -217,202 +289,322 @@ func (a *PreviewApplier) DowngradeToV1() (*types.ResourceGroup, error) {
 	return v1File, nil
 }
 
-// func (a *PreviewApplier) readOSEnv() error {
-// 	printInfoMessage("Reading OS environment variables")
-
-// 	env := os.Environ()
-// 	osEnv := make(map[string]string)
-
-// 	for _, e := range env {
-// 		k, v, _ := strings.Cut(e, "=")
-// 		kCopy := k
-
-// 		if k != "" && v != "" && strings.HasPrefix(k, "PORTER_APPLY_") {
-// 			// we only read in env variables that start with PORTER_APPLY_
-// 			for strings.HasPrefix(k, "PORTER_APPLY_") {
-// 				k = strings.TrimPrefix(k, "PORTER_APPLY_")
-// 			}
-
-// 			if k == "" {
-// 				printWarningMessage(fmt.Sprintf("Ignoring invalid OS environment variable '%s'", kCopy))
-// 			}
-
-// 			osEnv[k] = v
-// 		}
-// 	}
-
-// 	a.osEnv = osEnv
-
-// 	return nil
-// }
-
-// func (a *PreviewApplier) processVariables() error {
-// 	printInfoMessage("Processing variables")
-
-// 	constantsMap := make(map[string]string)
-// 	variablesMap := make(map[string]string)
-
-// 	for _, v := range a.parsed.Variables {
-// 		if v == nil {
-// 			continue
-// 		}
-
-// 		if v.Once != nil && *v.Once {
-// 			// a constant which should be stored in the env group on first run
-// 			if exists, err := a.constantExistsInEnvGroup(*v.Name); err == nil {
-// 				if exists == nil {
-// 					// this should not happen
-// 					return fmt.Errorf("internal error: please let the Porter team know about this and quote the following " +
-// 						"error:\n-----\nERROR: checking for constant existence in env group returned nil with no error")
-// 				}
-
-// 				val := *exists
-
-// 				if !val {
-// 					// create the constant in the env group
-// 					if *v.Value != "" {
-// 						constantsMap[*v.Name] = *v.Value
-// 					} else if v.Random != nil && *v.Random {
-// 						constantsMap[*v.Name] = randomString(*v.Length, defaultCharset)
-// 					} else {
-// 						// this should not happen
-// 						return fmt.Errorf("internal error: please let the Porter team know about this and quote the following "+
-// 							"error:\n-----\nERROR: for variable '%s', random is false and value is empty", *v.Name)
-// 					}
-// 				}
-// 			} else {
-// 				return fmt.Errorf("error checking for existence of constant %s: %w", *v.Name, err)
-// 			}
-// 		} else {
-// 			if v.Value != nil && *v.Value != "" {
-// 				variablesMap[*v.Name] = *v.Value
-// 			} else if v.Random != nil && *v.Random {
-// 				variablesMap[*v.Name] = randomString(*v.Length, defaultCharset)
-// 			} else {
-// 				// this should not happen
-// 				return fmt.Errorf("internal error: please let the Porter team know about this and quote the following "+
-// 					"error:\n-----\nERROR: for variable '%s', random is false and value is empty", *v.Name)
-// 			}
-// 		}
-// 	}
-
-// 	if len(constantsMap) > 0 {
-// 		// we need to create these constants in the env group
-// 		_, err := a.apiClient.CreateEnvGroup(
-// 			context.Background(),
-// 			config.GetCLIConfig().Project,
-// 			config.GetCLIConfig().Cluster,
-// 			a.namespace,
-// 			&apiTypes.CreateEnvGroupRequest{
-// 				Name:      constantsEnvGroup,
-// 				Variables: constantsMap,
-// 			},
-// 		)
-
-// 		if err != nil {
-// 			return fmt.Errorf("error creating constants (variables with once set to true) in env group: %w", err)
-// 		}
-
-// 		for k, v := range constantsMap {
-// 			variablesMap[k] = v
-// 		}
-// 	}
-
-// 	a.variablesMap = variablesMap
-
-// 	return nil
-// }
-
-// func (a *PreviewApplier) constantExistsInEnvGroup(name string) (*bool, error) {
-// 	apiResponse, err := a.apiClient.GetEnvGroup(
-// 		context.Background(),
-// 		config.GetCLIConfig().Project,
-// 		config.GetCLIConfig().Cluster,
-// 		a.namespace,
-// 		&apiTypes.GetEnvGroupRequest{
-// 			Name: constantsEnvGroup,
-// 			// we do not care about the version because it always needs to be the latest
-// 		},
-// 	)
-
-// 	if err != nil {
-// 		if strings.Contains(err.Error(), "env group not found") {
-// 			return booleanptr(false), nil
-// 		}
-
-// 		return nil, err
-// 	}
-
-// 	if _, ok := apiResponse.Variables[name]; ok {
-// 		return booleanptr(true), nil
-// 	}
-
-// 	return booleanptr(false), nil
-// }
-
-// func (a *PreviewApplier) processEnvGroups() error {
-// 	printInfoMessage("Processing env groups")
-
-// 	for _, eg := range a.parsed.EnvGroups {
-// 		if eg == nil {
-// 			continue
-// 		}
-
-// 		if eg.Name == nil || *eg.Name == "" {
-
-// 		}
-
-// 		envGroup, err := a.apiClient.GetEnvGroup(
-// 			context.Background(),
-// 			config.GetCLIConfig().Project,
-// 			config.GetCLIConfig().Cluster,
-// 			a.namespace,
-// 			&apiTypes.GetEnvGroupRequest{
-// 				Name: *eg.Name,
-// 			},
-// 		)
-
-// 		if err != nil && strings.Contains(err.Error(), "env group not found") {
-// 			if eg.CloneFrom == nil {
-// 				return fmt.Errorf(composePreviewMessage(fmt.Sprintf("empty clone_from for env group '%s'", *eg.Name), Error))
-// 			}
-
-// 			egNS, egName, found := strings.Cut(*eg.CloneFrom, "/")
-
-// 			if !found {
-// 				return fmt.Errorf("error parsing clone_from for env group '%s': invalid format", *eg.Name)
-// 			}
-
-// 			// clone the env group
-// 			envGroup, err := a.apiClient.CloneEnvGroup(
-// 				context.Background(),
-// 				config.GetCLIConfig().Project,
-// 				config.GetCLIConfig().Cluster,
-// 				egNS,
-// 				&apiTypes.CloneEnvGroupRequest{
-// 					SourceName:      egName,
-// 					TargetNamespace: a.namespace,
-// 					TargetName:      *eg.Name,
-// 				},
-// 			)
-
-// 			if err != nil {
-// 				return fmt.Errorf("error cloning env group '%s' from '%s': %w", egName, egNS, err)
-// 			}
-
-// 			a.envGroups[*eg.Name] = &apiTypes.EnvGroup{
-// 				Name:      envGroup.Name,
-// 				Variables: envGroup.Variables,
-// 			}
-// 		} else if err != nil {
-// 			return fmt.Errorf("error checking for env group '%s': %w", *eg.Name, err)
-// 		} else {
-// 			a.envGroups[*eg.Name] = &apiTypes.EnvGroup{
-// 				Name:      envGroup.Name,
-// 				Variables: envGroup.Variables,
-// 			}
-// 		}
-// 	}
-
-// 	return nil
-// }
+func (a *PreviewApplier) processVariables() error {
+	if len(a.parsed.Variables) == 0 {
+		return nil
+	}
+
+	printInfoMessage("Processing variables")
+
+	var existingConstants map[string]string
+
+	constantsMap := make(map[string]string)
+
+	for _, v := range a.parsed.Variables {
+		if v == nil {
+			continue
+		}
+
+		if !v.IsOnce() {
+			a.variablesMap[v.GetName()] = v.getNewValue()
+			continue
+		}
+
+		// a constant which should only be generated on the first apply and is stored in the
+		// constants env group for subsequent applies
+		if existingConstants == nil {
+			var err error
+
+			existingConstants, err = a.getConstants()
+			if err != nil {
+				errMsg := composePreviewMessage("error reading variables with once set to true", Error)
+				return fmt.Errorf("%s: %w", errMsg, err)
+			}
+		}
+
+		if val, ok := existingConstants[v.GetName()]; ok {
+			a.variablesMap[v.GetName()] = val
+			continue
+		}
+
+		constantsMap[v.GetName()] = v.getNewValue()
+		a.variablesMap[v.GetName()] = constantsMap[v.GetName()]
+	}
+
+	if len(constantsMap) == 0 {
+		return nil
+	}
+
+	if a.dryRun {
+		printInfoMessage(fmt.Sprintf("Dry run: %d variable(s) with once set to true would be stored in env group '%s'",
+			len(constantsMap), constantsEnvGroup))
+
+		return nil
+	}
+
+	// creating an env group with an existing name replaces its variables, so we need to
+	// include the constants from previous applies
+	for k, v := range existingConstants {
+		if _, ok := constantsMap[k]; !ok {
+			constantsMap[k] = v
+		}
+	}
+
+	_, err := a.apiClient.CreateEnvGroup(
+		context.Background(),
+		config.GetCLIConfig().Project,
+		config.GetCLIConfig().Cluster,
+		a.namespace,
+		&apiTypes.CreateEnvGroupRequest{
+			Name:      constantsEnvGroup,
+			Variables: constantsMap,
+		},
+	)
+
+	if err != nil {
+		errMsg := composePreviewMessage("error storing variables with once set to true in env group", Error)
+		return fmt.Errorf("%s: %w", errMsg, err)
+	}
+
+	return nil
+}
+
+// getConstants returns the variables stored in the constants env group of the namespace, or an
+// empty map if the env group does not exist yet
+func (a *PreviewApplier) getConstants() (map[string]string, error) {
+	apiResponse, err := a.apiClient.GetEnvGroup(
+		context.Background(),
+		config.GetCLIConfig().Project,
+		config.GetCLIConfig().Cluster,
+		a.namespace,
+		&apiTypes.GetEnvGroupRequest{
+			Name: constantsEnvGroup,
+			// we do not care about the version because it always needs to be the latest
+		},
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "env group not found") {
+			return make(map[string]string), nil
+		}
+
+		return nil, err
+	}
+
+	if apiResponse.EnvGroup == nil || apiResponse.Variables == nil {
+		return make(map[string]string), nil
+	}
+
+	return apiResponse.Variables, nil
+}
+
+func (a *PreviewApplier) processEnvGroups() error {
+	if len(a.parsed.EnvGroups) == 0 {
+		return nil
+	}
+
+	printInfoMessage("Processing env groups")
+
+	for _, eg := range a.parsed.EnvGroups {
+		if eg == nil {
+			continue
+		}
+
+		envGroup, err := a.apiClient.GetEnvGroup(
+			context.Background(),
+			config.GetCLIConfig().Project,
+			config.GetCLIConfig().Cluster,
+			a.namespace,
+			&apiTypes.GetEnvGroupRequest{
+				Name: eg.GetName(),
+			},
+		)
+
+		if err == nil {
+			a.envGroups[eg.GetName()] = envGroup.EnvGroup
+			continue
+		} else if !strings.Contains(err.Error(), "env group not found") {
+			errMsg := composePreviewMessage(fmt.Sprintf("error checking for env group '%s'", eg.GetName()), Error)
+			return fmt.Errorf("%s: %w", errMsg, err)
+		}
+
+		egNS, egName, err := eg.getCloneSource()
+		if err != nil {
+			return fmt.Errorf("%s: %w", composePreviewMessage("error parsing env group", Error), err)
+		}
+
+		if a.dryRun {
+			printInfoMessage(fmt.Sprintf("Dry run: env group '%s' would be cloned from '%s'", eg.GetName(),
+				eg.GetCloneFrom()))
+
+			a.envGroups[eg.GetName()] = &apiTypes.EnvGroup{
+				Name:      eg.GetName(),
+				Namespace: a.namespace,
+			}
+
+			continue
+		}
+
+		printInfoMessage(fmt.Sprintf("Cloning env group '%s' from namespace '%s' to env group '%s' in namespace '%s'",
+			egName, egNS, eg.GetName(), a.namespace))
+
+		clonedEnvGroup, err := a.apiClient.CloneEnvGroup(
+			context.Background(),
+			config.GetCLIConfig().Project,
+			config.GetCLIConfig().Cluster,
+			egNS,
+			&apiTypes.CloneEnvGroupRequest{
+				SourceName:      egName,
+				TargetNamespace: a.namespace,
+				TargetName:      eg.GetName(),
+			},
+		)
+		if err != nil {
+			errMsg := composePreviewMessage(fmt.Sprintf("error cloning env group '%s' from '%s'", egName, egNS), Error)
+			return fmt.Errorf("%s: %w", errMsg, err)
+		}
+
+		a.envGroups[eg.GetName()] = clonedEnvGroup
+	}
+
+	return nil
+}
+
+// resolveEnvGroupRefs rewrites references to env groups declared in the env_groups block, which
+// can be imported by their name alone, to the "namespace/name" form used by builds
+func (a *PreviewApplier) resolveEnvGroupRefs(b *Build) {
+	if b.Env == nil {
+		return
+	}
+
+	for i, ref := range b.Env.ImportFrom {
+		if ref == nil || strings.Contains(*ref, "/") {
+			continue
+		}
+
+		if _, ok := a.envGroups[*ref]; ok {
+			b.Env.ImportFrom[i] = stringptr(fmt.Sprintf("%s/%s", a.namespace, *ref))
+		}
+	}
+}
+
+// substituteVariables replaces all instances of ${{ porter.variables.FOO }} in build environments
+// and Helm values with the value of the variable FOO
+func (a *PreviewApplier) substituteVariables() error {
+	for _, b := range a.parsed.Builds {
+		if b == nil {
+			continue
+		}
+
+		if b.Image != nil {
+			img, err := a.substituteString(*b.Image)
+			if err != nil {
+				return err
+			}
+
+			b.Image = stringptr(img)
+		}
+
+		if b.Env != nil {
+			for k, v := range b.Env.Raw {
+				if v == nil {
+					continue
+				}
+
+				val, err := a.substituteString(*v)
+				if err != nil {
+					return err
+				}
+
+				b.Env.Raw[k] = stringptr(val)
+			}
+		}
+	}
+
+	for _, app := range a.parsed.Apps {
+		if app == nil {
+			continue
+		}
+
+		values, err := a.substituteValue(app.HelmValues)
+		if err != nil {
+			return err
+		}
+
+		app.HelmValues, _ = values.(map[string]any)
+	}
+
+	for _, addon := range a.parsed.Addons {
+		if addon == nil {
+			continue
+		}
+
+		values, err := a.substituteValue(addon.HelmValues)
+		if err != nil {
+			return err
+		}
+
+		addon.HelmValues, _ = values.(map[string]any)
+	}
+
+	return nil
+}
+
+func (a *PreviewApplier) substituteValue(val any) (any, error) {
+	switch v := val.(type) {
+	case string:
+		return a.substituteString(v)
+	case map[string]any:
+		if v == nil {
+			return v, nil
+		}
+
+		for key, inner := range v {
+			res, err := a.substituteValue(inner)
+			if err != nil {
+				return nil, err
+			}
+
+			v[key] = res
+		}
+
+		return v, nil
+	case []any:
+		for i, inner := range v {
+			res, err := a.substituteValue(inner)
+			if err != nil {
+				return nil, err
+			}
+
+			v[i] = res
+		}
+
+		return v, nil
+	}
+
+	return val, nil
+}
+
+func (a *PreviewApplier) substituteString(str string) (string, error) {
+	var missing []string
+
+	res := variableRefRegex.ReplaceAllStringFunc(str, func(match string) string {
+		name := variableRefRegex.FindStringSubmatch(match)[1]
+
+		val, ok := a.variablesMap[name]
+
+		if !ok {
+			missing = append(missing, name)
+			return match
+		}
+
+		return val
+	})
+
+	if len(missing) > 0 {
+		errMsg := composePreviewMessage(fmt.Sprintf("undefined variable(s) referenced: %s",
+			strings.Join(missing, ", ")), Error)
+		return "", fmt.Errorf(errMsg)
+	}
+
+	return res, nil
+}
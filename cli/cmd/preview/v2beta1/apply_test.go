@@ -0,0 +1,417 @@
+package v2beta1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	api "github.com/porter-dev/porter/api/client"
+	apiTypes "github.com/porter-dev/porter/api/types"
+	"gopkg.in/yaml.v3"
+)
+
+const testNamespace = "pr-1"
+
+// mockEnvGroupAPI serves the env group endpoints used by the applier, keeping the env groups in
+// memory keyed by "namespace/name"
+type mockEnvGroupAPI struct {
+	mu        sync.Mutex
+	envGroups map[string]*apiTypes.EnvGroup
+	creates   int
+	clones    int
+}
+
+func newMockEnvGroupAPI(t *testing.T, envGroups ...*apiTypes.EnvGroup) (*mockEnvGroupAPI, *api.Client) {
+	m := &mockEnvGroupAPI{
+		envGroups: make(map[string]*apiTypes.EnvGroup),
+	}
+
+	for _, eg := range envGroups {
+		m.envGroups[eg.Namespace+"/"+eg.Name] = eg
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+
+	t.Cleanup(server.Close)
+
+	return m, api.NewClientWithToken(server.URL, "token")
+}
+
+func (m *mockEnvGroupAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// paths are of the form /projects/{id}/clusters/{id}/namespaces/{namespace}/envgroup[/action]
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+
+	if len(parts) < 6 || parts[4] != "namespaces" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	ns := parts[5]
+	action := strings.Join(parts[6:], "/")
+
+	switch action {
+	case "envgroup":
+		eg, ok := m.envGroups[ns+"/"+r.URL.Query().Get("name")]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(&apiTypes.ExternalError{Error: "env group not found"})
+			return
+		}
+
+		json.NewEncoder(w).Encode(&apiTypes.GetEnvGroupResponse{EnvGroup: eg})
+	case "envgroup/create":
+		req := &apiTypes.CreateEnvGroupRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+
+		m.creates++
+
+		eg := &apiTypes.EnvGroup{Name: req.Name, Namespace: ns, Variables: req.Variables}
+		m.envGroups[ns+"/"+req.Name] = eg
+
+		json.NewEncoder(w).Encode(eg)
+	case "envgroup/clone":
+		req := &apiTypes.CloneEnvGroupRequest{}
+		json.NewDecoder(r.Body).Decode(req)
+
+		source, ok := m.envGroups[ns+"/"+req.SourceName]
+
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			json.NewEncoder(w).Encode(&apiTypes.ExternalError{Error: "env group not found"})
+			return
+		}
+
+		m.clones++
+
+		eg := &apiTypes.EnvGroup{Name: req.TargetName, Namespace: req.TargetNamespace, Variables: source.Variables}
+		m.envGroups[req.TargetNamespace+"/"+req.TargetName] = eg
+
+		json.NewEncoder(w).Encode(eg)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func newTestApplier(t *testing.T, client *api.Client, raw string, dryRun bool) *PreviewApplier {
+	parsed := &PorterYAML{}
+
+	if err := yaml.Unmarshal([]byte(raw), parsed); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return &PreviewApplier{
+		apiClient:    client,
+		namespace:    testNamespace,
+		parsed:       parsed,
+		dryRun:       dryRun,
+		variablesMap: make(map[string]string),
+		envGroups:    make(map[string]*apiTypes.EnvGroup),
+	}
+}
+
+const testVariablesYAML = `
+variables:
+- name: DB_NAME
+  value: preview
+- name: DB_PASSWORD
+  random: true
+  length: 16
+  once: true
+- name: API_KEY
+  value: static-key
+  once: true
+`
+
+func TestProcessVariables(t *testing.T) {
+	tests := []struct {
+		description     string
+		existing        map[string]string
+		dryRun          bool
+		expCreates      int
+		expDBPassword   string
+		expAPIKey       string
+		expStoredValues bool
+	}{
+		{
+			description:     "first apply stores the variables with once set to true",
+			expCreates:      1,
+			expAPIKey:       "static-key",
+			expStoredValues: true,
+		},
+		{
+			description: "later applies reuse the stored variables",
+			existing: map[string]string{
+				"DB_PASSWORD": "stored-password",
+				"API_KEY":     "stored-key",
+			},
+			expCreates:      0,
+			expDBPassword:   "stored-password",
+			expAPIKey:       "stored-key",
+			expStoredValues: true,
+		},
+		{
+			description: "dry run does not store the variables",
+			dryRun:      true,
+			expCreates:  0,
+			expAPIKey:   "static-key",
+		},
+	}
+
+	for _, test := range tests {
+		var envGroups []*apiTypes.EnvGroup
+
+		if test.existing != nil {
+			envGroups = append(envGroups, &apiTypes.EnvGroup{
+				Name:      constantsEnvGroup,
+				Namespace: testNamespace,
+				Variables: test.existing,
+			})
+		}
+
+		mock, client := newMockEnvGroupAPI(t, envGroups...)
+		applier := newTestApplier(t, client, testVariablesYAML, test.dryRun)
+
+		if err := applier.processVariables(); err != nil {
+			t.Fatalf("%s: %v", test.description, err)
+		}
+
+		if mock.creates != test.expCreates {
+			t.Errorf("%s: expected %d env group creates, got %d", test.description, test.expCreates, mock.creates)
+		}
+
+		if applier.variablesMap["DB_NAME"] != "preview" {
+			t.Errorf("%s: expected DB_NAME to be preview, got %s", test.description, applier.variablesMap["DB_NAME"])
+		}
+
+		dbPassword := applier.variablesMap["DB_PASSWORD"]
+
+		if test.expDBPassword != "" {
+			if dbPassword != test.expDBPassword {
+				t.Errorf("%s: expected DB_PASSWORD to be %s, got %s", test.description, test.expDBPassword, dbPassword)
+			}
+		} else if len(dbPassword) != 16 {
+			t.Errorf("%s: expected random DB_PASSWORD of length 16, got %s", test.description, dbPassword)
+		}
+
+		if applier.variablesMap["API_KEY"] != test.expAPIKey {
+			t.Errorf("%s: expected API_KEY to be %s, got %s", test.description, test.expAPIKey,
+				applier.variablesMap["API_KEY"])
+		}
+
+		stored, ok := mock.envGroups[testNamespace+"/"+constantsEnvGroup]
+
+		if ok != test.expStoredValues {
+			t.Fatalf("%s: expected constants env group to exist: %t", test.description, test.expStoredValues)
+		}
+
+		if !ok {
+			continue
+		}
+
+		if stored.Variables["DB_PASSWORD"] != dbPassword {
+			t.Errorf("%s: stored DB_PASSWORD %s does not match %s", test.description,
+				stored.Variables["DB_PASSWORD"], dbPassword)
+		}
+
+		if _, ok := stored.Variables["DB_NAME"]; ok {
+			t.Errorf("%s: variable without once set to true must not be stored", test.description)
+		}
+	}
+}
+
+func TestProcessVariablesKeepsPreviousConstants(t *testing.T) {
+	mock, client := newMockEnvGroupAPI(t, &apiTypes.EnvGroup{
+		Name:      constantsEnvGroup,
+		Namespace: testNamespace,
+		Variables: map[string]string{
+			"REMOVED_FROM_YAML": "value",
+			"API_KEY":           "stored-key",
+		},
+	})
+
+	applier := newTestApplier(t, client, testVariablesYAML, false)
+
+	if err := applier.processVariables(); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	stored := mock.envGroups[testNamespace+"/"+constantsEnvGroup].Variables
+
+	// creating the env group replaces its variables, so the existing constants have to be kept
+	for _, name := range []string{"REMOVED_FROM_YAML", "API_KEY", "DB_PASSWORD"} {
+		if _, ok := stored[name]; !ok {
+			t.Errorf("expected %s to be stored in the constants env group", name)
+		}
+	}
+
+	if stored["API_KEY"] != "stored-key" {
+		t.Errorf("expected API_KEY to keep its stored value, got %s", stored["API_KEY"])
+	}
+}
+
+const testEnvGroupsYAML = `
+env_groups:
+- name: shared
+  clone_from: default/shared-config
+`
+
+func TestProcessEnvGroups(t *testing.T) {
+	source := &apiTypes.EnvGroup{
+		Name:      "shared-config",
+		Namespace: "default",
+		Variables: map[string]string{"LOG_LEVEL": "debug"},
+	}
+
+	tests := []struct {
+		description string
+		existing    []*apiTypes.EnvGroup
+		dryRun      bool
+		expClones   int
+		expVars     map[string]string
+	}{
+		{
+			description: "missing env group is cloned",
+			existing:    []*apiTypes.EnvGroup{source},
+			expClones:   1,
+			expVars:     map[string]string{"LOG_LEVEL": "debug"},
+		},
+		{
+			description: "existing env group is not cloned again",
+			existing: []*apiTypes.EnvGroup{
+				source,
+				{
+					Name:      "shared",
+					Namespace: testNamespace,
+					Variables: map[string]string{"LOG_LEVEL": "info"},
+				},
+			},
+			expClones: 0,
+			expVars:   map[string]string{"LOG_LEVEL": "info"},
+		},
+		{
+			description: "dry run does not clone",
+			existing:    []*apiTypes.EnvGroup{source},
+			dryRun:      true,
+			expClones:   0,
+		},
+	}
+
+	for _, test := range tests {
+		mock, client := newMockEnvGroupAPI(t, test.existing...)
+		applier := newTestApplier(t, client, testEnvGroupsYAML, test.dryRun)
+
+		if err := applier.processEnvGroups(); err != nil {
+			t.Fatalf("%s: %v", test.description, err)
+		}
+
+		if mock.clones != test.expClones {
+			t.Errorf("%s: expected %d clones, got %d", test.description, test.expClones, mock.clones)
+		}
+
+		eg, ok := applier.envGroups["shared"]
+
+		if !ok {
+			t.Fatalf("%s: expected env group shared to be registered", test.description)
+		}
+
+		if eg.Namespace != testNamespace {
+			t.Errorf("%s: expected env group in namespace %s, got %s", test.description, testNamespace, eg.Namespace)
+		}
+
+		for k, v := range test.expVars {
+			if eg.Variables[k] != v {
+				t.Errorf("%s: expected %s to be %s, got %s", test.description, k, v, eg.Variables[k])
+			}
+		}
+	}
+}
+
+func TestProcessEnvGroupsMissingSource(t *testing.T) {
+	_, client := newMockEnvGroupAPI(t)
+	applier := newTestApplier(t, client, testEnvGroupsYAML, false)
+
+	if err := applier.processEnvGroups(); err == nil {
+		t.Errorf("expected error when the clone_from env group does not exist")
+	}
+}
+
+const testSubstituteYAML = `
+builds:
+- name: web
+  method: registry
+  image: ${{ porter.variables.REGISTRY }}/web
+  env:
+    raw:
+      DB_NAME: ${{porter.variables.DB_NAME}}
+apps:
+- name: web
+  build_ref: web
+  helm_values:
+    replicaCount: 1
+    container:
+      env:
+        normal:
+          DATABASE_URL: postgres://${{ porter.variables.DB_NAME }}:5432
+    hosts:
+    - ${{ porter.variables.DB_NAME }}.example.com
+`
+
+func TestSubstituteVariables(t *testing.T) {
+	_, client := newMockEnvGroupAPI(t)
+	applier := newTestApplier(t, client, testSubstituteYAML, false)
+
+	applier.variablesMap["REGISTRY"] = "registry.example.com"
+	applier.variablesMap["DB_NAME"] = "preview"
+
+	if err := applier.substituteVariables(); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	build := applier.parsed.Builds[0]
+
+	if *build.Image != "registry.example.com/web" {
+		t.Errorf("expected image to be substituted, got %s", *build.Image)
+	}
+
+	for _, v := range build.Env.Raw {
+		if *v != "preview" {
+			t.Errorf("expected build env to be substituted, got %s", *v)
+		}
+	}
+
+	values := applier.parsed.Apps[0].HelmValues
+
+	env := values["container"].(map[string]any)["env"].(map[string]any)["normal"].(map[string]any)
+
+	if env["DATABASE_URL"] != "postgres://preview:5432" {
+		t.Errorf("expected nested value to be substituted, got %v", env["DATABASE_URL"])
+	}
+
+	if hosts := values["hosts"].([]any); hosts[0] != "preview.example.com" {
+		t.Errorf("expected array value to be substituted, got %v", hosts[0])
+	}
+
+	if values["replicaCount"] != 1 {
+		t.Errorf("expected non-string value to be kept, got %v", values["replicaCount"])
+	}
+}
+
+func TestSubstituteVariablesUndefined(t *testing.T) {
+	_, client := newMockEnvGroupAPI(t)
+	applier := newTestApplier(t, client, testSubstituteYAML, false)
+
+	applier.variablesMap["REGISTRY"] = "registry.example.com"
+
+	err := applier.substituteVariables()
+
+	if err == nil || !strings.Contains(err.Error(), "DB_NAME") {
+		t.Errorf("expected undefined variable error for DB_NAME, got %v", err)
+	}
+}
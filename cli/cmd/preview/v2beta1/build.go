@@ -76,6 +76,22 @@ func (b *Build) GetImage() string {
 	return *b.Image
 }
 
+func (b *Build) GetUseCache() bool {
+	if b == nil || b.UseCache == nil {
+		return false
+	}
+
+	return *b.UseCache
+}
+
+func (b *Build) GetCacheImage() string {
+	if b == nil || b.CacheImage == nil {
+		return ""
+	}
+
+	return *b.CacheImage
+}
+
 func (b *Build) GetRawEnv() map[string]string {
 	env := make(map[string]string)
 
@@ -139,6 +155,12 @@ func (b *Build) getV1BuildImage() (*types.Resource, error) {
 	config.Build.Context = b.GetContext()
 	config.Build.Env = b.GetRawEnv()
 
+	// caching is not applicable to images that are pulled from a registry
+	if b.GetMethod() != "registry" {
+		config.Build.UseCache = b.GetUseCache()
+		config.Build.CacheImage = b.GetCacheImage()
+	}
+
 	for _, eg := range b.GetEnvGroups() {
 		ns, name, _ := strings.Cut(eg, "/")
 
@@ -176,6 +198,9 @@ func (b *Build) getV1PushImage() (*types.Resource, error) {
 
 	config.Push.Image = fmt.Sprintf("{ .%s-build-image.image }", b.GetName())
 
+	// pack publishes the image to the registry directly when caching is enabled
+	config.Push.UsePackCache = b.GetMethod() == "pack" && b.GetUseCache()
+
 	rawConfig := make(map[string]any)
 
 	err := mapstructure.Decode(config, &rawConfig)
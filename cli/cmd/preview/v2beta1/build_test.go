@@ -0,0 +1,105 @@
+package v2beta1
+
+import (
+	"testing"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildCacheConfig(t *testing.T) {
+	tests := []struct {
+		name             string
+		raw              string
+		expUseCache      bool
+		expCacheImage    string
+		expPushPackCache bool
+	}{
+		{
+			name: "caching is disabled by default",
+			raw: `
+name: web
+method: docker
+dockerfile: ./Dockerfile
+`,
+		},
+		{
+			name: "docker build with cache",
+			raw: `
+name: web
+method: docker
+dockerfile: ./Dockerfile
+use_cache: true
+`,
+			expUseCache: true,
+		},
+		{
+			name: "pack build with cache image",
+			raw: `
+name: web
+method: pack
+builder: heroku/buildpacks:20
+use_cache: true
+cache_image: registry.example.com/web:cache
+`,
+			expUseCache:      true,
+			expCacheImage:    "registry.example.com/web:cache",
+			expPushPackCache: true,
+		},
+		{
+			name: "registry images are not cached",
+			raw: `
+name: web
+method: registry
+image: nginx:latest
+use_cache: true
+cache_image: registry.example.com/web:cache
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			build := &Build{}
+
+			if err := yaml.Unmarshal([]byte(tt.raw), build); err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			buildResource, err := build.getV1BuildImage()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			buildConfig := &preview.BuildDriverConfig{}
+
+			if err := mapstructure.Decode(buildResource.Config, buildConfig); err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			if buildConfig.Build.UseCache != tt.expUseCache {
+				t.Errorf("expected use_cache to be %t, got %t", tt.expUseCache, buildConfig.Build.UseCache)
+			}
+
+			if buildConfig.Build.CacheImage != tt.expCacheImage {
+				t.Errorf("expected cache_image %q, got %q", tt.expCacheImage, buildConfig.Build.CacheImage)
+			}
+
+			pushResource, err := build.getV1PushImage()
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			pushConfig := &preview.PushDriverConfig{}
+
+			if err := mapstructure.Decode(pushResource.Config, pushConfig); err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			if pushConfig.Push.UsePackCache != tt.expPushPackCache {
+				t.Errorf("expected use_pack_cache to be %t, got %t", tt.expPushPackCache, pushConfig.Push.UsePackCache)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package v2beta1
+
+import (
+	"fmt"
+	"strings"
+)
+
+func (e *EnvGroup) GetName() string {
+	if e == nil || e.Name == nil {
+		return ""
+	}
+
+	return *e.Name
+}
+
+func (e *EnvGroup) GetCloneFrom() string {
+	if e == nil || e.CloneFrom == nil {
+		return ""
+	}
+
+	return *e.CloneFrom
+}
+
+// getCloneSource returns the namespace and name of the env group to clone from, which
+// is specified as "namespace/name"
+func (e *EnvGroup) getCloneSource() (string, string, error) {
+	ns, name, found := strings.Cut(e.GetCloneFrom(), "/")
+
+	if !found || ns == "" || name == "" {
+		return "", "", fmt.Errorf("invalid clone_from '%s' for env group '%s': must be of the form "+
+			"'namespace/name'", e.GetCloneFrom(), e.GetName())
+	}
+
+	return ns, name, nil
+}
+
+func (e *EnvGroup) validate() error {
+	if e.GetName() == "" {
+		return fmt.Errorf("env group name cannot be empty")
+	}
+
+	if e.GetCloneFrom() == "" {
+		return fmt.Errorf("empty clone_from for env group '%s'", e.GetName())
+	}
+
+	_, _, err := e.getCloneSource()
+
+	return err
+}
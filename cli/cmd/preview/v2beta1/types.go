@@ -1,17 +1,17 @@
 package v2beta1
 
-// type Variable struct {
-// 	Name   *string `yaml:"name" validate:"required,unique"`
-// 	Value  *string `yaml:"value" validate:"required_if=Random false"`
-// 	Once   *bool   `yaml:"once"`
-// 	Random *bool   `yaml:"random"`
-// 	Length *uint   `yaml:"length"`
-// }
-
-// type EnvGroup struct {
-// 	Name      *string `yaml:"name" validate:"required"`
-// 	CloneFrom *string `yaml:"clone_from" validate:"required"`
-// }
+type Variable struct {
+	Name   *string `yaml:"name" validate:"required,unique"`
+	Value  *string `yaml:"value" validate:"required_if=Random false"`
+	Once   *bool   `yaml:"once"`
+	Random *bool   `yaml:"random"`
+	Length *uint   `yaml:"length"`
+}
+
+type EnvGroup struct {
+	Name      *string `yaml:"name" validate:"required"`
+	CloneFrom *string `yaml:"clone_from" validate:"required"`
+}
 
 type BuildEnv struct {
 	Raw        map[*string]*string `yaml:"raw"`
@@ -27,7 +27,8 @@ type Build struct {
 	Dockerfile *string   `yaml:"dockerfile" validate:"required_if=Method docker"`
 	Image      *string   `yaml:"image" validate:"required_if=Method registry"`
 	Env        *BuildEnv `yaml:"env"`
-	// UseCache   *bool     `yaml:"use_cache"`
+	UseCache   *bool     `yaml:"use_cache"`
+	CacheImage *string   `yaml:"cache_image"`
 }
 
 type HelmChart struct {
@@ -54,10 +55,10 @@ type AddonResource struct {
 }
 
 type PorterYAML struct {
-	Version *string `yaml:"version"`
-	// Variables []*Variable `yaml:"variables"`
-	// EnvGroups []*EnvGroup `yaml:"env_groups"`
-	Builds []*Build         `yaml:"builds"`
-	Apps   []*AppResource   `yaml:"apps"`
-	Addons []*AddonResource `yaml:"addons"`
+	Version   *string          `yaml:"version"`
+	Variables []*Variable      `yaml:"variables"`
+	EnvGroups []*EnvGroup      `yaml:"env_groups"`
+	Builds    []*Build         `yaml:"builds"`
+	Apps      []*AppResource   `yaml:"apps"`
+	Addons    []*AddonResource `yaml:"addons"`
 }
@@ -0,0 +1,71 @@
+package v2beta1
+
+import "fmt"
+
+// default length of random variables, same as the v1 random-string driver
+const defaultRandomVariableLength uint = 8
+
+func (v *Variable) GetName() string {
+	if v == nil || v.Name == nil {
+		return ""
+	}
+
+	return *v.Name
+}
+
+func (v *Variable) GetValue() string {
+	if v == nil || v.Value == nil {
+		return ""
+	}
+
+	return *v.Value
+}
+
+func (v *Variable) IsOnce() bool {
+	if v == nil || v.Once == nil {
+		return false
+	}
+
+	return *v.Once
+}
+
+func (v *Variable) IsRandom() bool {
+	if v == nil || v.Random == nil {
+		return false
+	}
+
+	return *v.Random
+}
+
+func (v *Variable) GetLength() uint {
+	if v == nil || v.Length == nil || *v.Length == 0 {
+		return defaultRandomVariableLength
+	}
+
+	return *v.Length
+}
+
+func (v *Variable) validate() error {
+	if v.GetName() == "" {
+		return fmt.Errorf("variable name cannot be empty")
+	}
+
+	if !v.IsRandom() && v.GetValue() == "" {
+		return fmt.Errorf("variable '%s' must either have a value or have random set to true", v.GetName())
+	}
+
+	if v.IsRandom() && v.GetValue() != "" {
+		return fmt.Errorf("variable '%s' cannot have both a value and random set to true", v.GetName())
+	}
+
+	return nil
+}
+
+// getNewValue returns the value of this variable, generating a new one for random variables
+func (v *Variable) getNewValue() string {
+	if v.IsRandom() {
+		return randomString(v.GetLength(), defaultCharset)
+	}
+
+	return v.GetValue()
+}
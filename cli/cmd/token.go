@@ -0,0 +1,438 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	api "github.com/porter-dev/porter/api/client"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/spf13/cobra"
+)
+
+var (
+	tokenCluster     uint
+	tokenNamespace   string
+	tokenRelease     string
+	tokenRegistries  []uint
+	tokenPermissions []string
+	tokenExpiresIn   time.Duration
+	tokenStale       time.Duration
+	tokenOverlap     time.Duration
+	tokenRole        string
+)
+
+// tokenCmd represents the "porter token" base command when called
+// without any subcommands
+var tokenCmd = &cobra.Command{
+	Use:     "token",
+	Aliases: []string{"tokens"},
+	Short:   "Commands that manage API tokens for the current project",
+}
+
+var tokenCreateCmd = &cobra.Command{
+	Use:   "create [name]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Creates an API token which is limited to the given permissions",
+	Long: fmt.Sprintf(`
+%s
+
+Creates an API token in the current project. By default, the token can only read and write
+releases, and it can be further limited to a single cluster, namespace or release:
+
+  %s
+
+Permissions are passed as "scope:access", where access is one of "read", "write" or a
+comma-separated list of verbs (get, list, create, update, delete). The parent scopes of
+a granted scope are given read access so that the token can reach the granted resources.
+The token is only printed once, so make sure to store it in a safe place.
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter token create\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter token create ci --cluster 4 --namespace staging --permission release:write"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, createToken)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var tokenListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Lists the API tokens in the current project",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, listTokens)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var tokenRevokeCmd = &cobra.Command{
+	Use:   "revoke [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Revokes the API token with the given id",
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, revokeToken)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+var tokenRotateCmd = &cobra.Command{
+	Use:   "rotate [id]",
+	Args:  cobra.ExactArgs(1),
+	Short: "Issues a new secret for the API token with the given id",
+	Long: fmt.Sprintf(`
+%s
+
+Issues a new secret for an API token. The previous secret keeps working for the duration
+set by --overlap, so that clients using the token can be updated without downtime.
+
+  %s
+`,
+		color.New(color.FgBlue, color.Bold).Sprintf("Help for \"porter token rotate\":"),
+		color.New(color.FgGreen, color.Bold).Sprintf("porter token rotate [id] --overlap 1h"),
+	),
+	Run: func(cmd *cobra.Command, args []string) {
+		err := checkLoginAndRun(args, rotateToken)
+		if err != nil {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tokenCmd)
+
+	tokenCmd.AddCommand(tokenCreateCmd)
+	tokenCmd.AddCommand(tokenListCmd)
+	tokenCmd.AddCommand(tokenRevokeCmd)
+	tokenCmd.AddCommand(tokenRotateCmd)
+
+	tokenListCmd.PersistentFlags().DurationVar(
+		&tokenStale,
+		"stale",
+		0,
+		"only list tokens which have not been used for the given duration, such as 720h",
+	)
+
+	tokenRotateCmd.PersistentFlags().DurationVar(
+		&tokenOverlap,
+		"overlap",
+		24*time.Hour,
+		"the duration for which the previous secret keeps working",
+	)
+
+	tokenCreateCmd.PersistentFlags().UintVar(
+		&tokenCluster,
+		"cluster",
+		0,
+		"the id of the cluster the token is limited to",
+	)
+
+	tokenCreateCmd.PersistentFlags().StringVar(
+		&tokenNamespace,
+		"namespace",
+		"",
+		"the namespace the token is limited to",
+	)
+
+	tokenCreateCmd.PersistentFlags().StringVar(
+		&tokenRelease,
+		"release",
+		"",
+		"the name of the release the token is limited to",
+	)
+
+	tokenCreateCmd.PersistentFlags().UintSliceVar(
+		&tokenRegistries,
+		"registry",
+		[]uint{},
+		"the ids of the registries the token is limited to",
+	)
+
+	tokenCreateCmd.PersistentFlags().StringArrayVar(
+		&tokenPermissions,
+		"permission",
+		[]string{"release:write"},
+		"a permission granted to the token, in the form scope:access",
+	)
+
+	tokenCreateCmd.PersistentFlags().DurationVar(
+		&tokenExpiresIn,
+		"expires-in",
+		0,
+		"the duration after which the token expires (defaults to 1 year)",
+	)
+
+	tokenCreateCmd.PersistentFlags().StringVar(
+		&tokenRole,
+		"role",
+		"",
+		"the id of a preset or custom role to assign to the token, instead of the --permission flags",
+	)
+}
+
+func createToken(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	req := &types.CreateAPIToken{
+		Name: args[0],
+	}
+
+	if tokenRole != "" {
+		req.PolicyUID = tokenRole
+	} else {
+		policy, err := buildTokenPolicy()
+		if err != nil {
+			return err
+		}
+
+		req.Policy = policy
+	}
+
+	if tokenExpiresIn != 0 {
+		req.ExpiresAt = time.Now().Add(tokenExpiresIn)
+	}
+
+	resp, err := client.CreateAPIToken(context.Background(), cliConf.Project, req)
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Created token %s with id %s, expiring at %s\n", resp.Name, resp.ID, resp.ExpiresAt.Format(time.RFC3339))
+	color.New(color.FgYellow).Println("This token will not be shown again:")
+	fmt.Println(resp.Token)
+
+	return nil
+}
+
+func listTokens(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	var resp *types.ListAPITokensResponse
+	var err error
+
+	if tokenStale != 0 {
+		resp, err = client.ListStaleAPITokens(context.Background(), cliConf.Project, &types.ListStaleAPITokensRequest{
+			UnusedFor: tokenStale.String(),
+		})
+	} else {
+		resp, err = client.ListAPITokens(context.Background(), cliConf.Project)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	w := new(tabwriter.Writer)
+	w.Init(os.Stdout, 3, 8, 0, '\t', tabwriter.AlignRight)
+
+	fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", "ID", "NAME", "POLICY", "EXPIRES", "LAST USED", "REVOKED")
+
+	for _, token := range *resp {
+		lastUsed := "never"
+
+		if token.LastUsedAt != nil {
+			lastUsed = fmt.Sprintf("%s (%s)", token.LastUsedAt.Format(time.RFC3339), token.LastUsedIP)
+		}
+
+		fmt.Fprintf(
+			w,
+			"%s\t%s\t%s\t%s\t%s\t%t\n",
+			token.ID,
+			token.Name,
+			token.PolicyName,
+			token.ExpiresAt.Format(time.RFC3339),
+			lastUsed,
+			token.Revoked,
+		)
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func revokeToken(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	_, err := client.RevokeAPIToken(context.Background(), cliConf.Project, args[0])
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Revoked token with id %s\n", args[0])
+
+	return nil
+}
+
+func rotateToken(user *types.GetAuthenticatedUserResponse, client *api.Client, args []string) error {
+	resp, err := client.RotateAPIToken(context.Background(), cliConf.Project, args[0], &types.RotateAPITokenRequest{
+		Overlap: tokenOverlap.String(),
+	})
+	if err != nil {
+		return err
+	}
+
+	color.New(color.FgGreen).Printf("Rotated token %s with id %s\n", resp.Name, resp.ID)
+
+	if resp.PreviousSecretExpiresAt != nil {
+		fmt.Printf("The previous secret stops working at %s\n", resp.PreviousSecretExpiresAt.Format(time.RFC3339))
+	}
+
+	color.New(color.FgYellow).Println("This token will not be shown again:")
+	fmt.Println(resp.Token)
+
+	return nil
+}
+
+// buildTokenPolicy builds a policy document tree from the token flags. Each granted scope
+// gets the requested verbs, which are passed down to its children, while the parents of a
+// granted scope only get read access. All other scopes are explicitly given no verbs.
+func buildTokenPolicy() ([]*types.PolicyDocument, error) {
+	grants := make(map[types.PermissionScope][]types.APIVerb)
+
+	for _, permission := range tokenPermissions {
+		scope, verbs, err := parseTokenPermission(permission)
+		if err != nil {
+			return nil, err
+		}
+
+		grants[scope] = mergeVerbs(grants[scope], verbs)
+	}
+
+	resources := make(map[types.PermissionScope][]types.NameOrUInt)
+
+	if tokenCluster != 0 {
+		resources[types.ClusterScope] = []types.NameOrUInt{{UInt: tokenCluster}}
+	}
+
+	if tokenNamespace != "" {
+		resources[types.NamespaceScope] = []types.NameOrUInt{{Name: tokenNamespace}}
+	}
+
+	if tokenRelease != "" {
+		resources[types.ReleaseScope] = []types.NameOrUInt{{Name: tokenRelease}}
+	}
+
+	for _, registryID := range tokenRegistries {
+		resources[types.RegistryScope] = append(resources[types.RegistryScope], types.NameOrUInt{UInt: registryID})
+	}
+
+	var docs []*types.PolicyDocument
+
+	for scope, subtree := range types.ScopeHeirarchy {
+		docs = append(docs, buildTokenPolicyDocument(scope, subtree, nil, grants, resources))
+	}
+
+	return docs, nil
+}
+
+func buildTokenPolicyDocument(
+	scope types.PermissionScope,
+	subtree types.ScopeTree,
+	inherited []types.APIVerb,
+	grants map[types.PermissionScope][]types.APIVerb,
+	resources map[types.PermissionScope][]types.NameOrUInt,
+) *types.PolicyDocument {
+	verbs := mergeVerbs(inherited, grants[scope])
+
+	// the project scope does not pass its verbs down to its children
+	var childVerbs []types.APIVerb
+
+	if scope != types.ProjectScope {
+		childVerbs = verbs
+	}
+
+	doc := &types.PolicyDocument{
+		Scope:     scope,
+		Resources: resources[scope],
+		Children:  make(map[types.PermissionScope]*types.PolicyDocument),
+	}
+
+	for childScope, childTree := range subtree {
+		child := buildTokenPolicyDocument(childScope, childTree, childVerbs, grants, resources)
+
+		if len(child.Verbs) > 0 {
+			verbs = mergeVerbs(verbs, types.ReadVerbGroup())
+		}
+
+		doc.Children[childScope] = child
+	}
+
+	doc.Verbs = verbs
+
+	return doc
+}
+
+func parseTokenPermission(permission string) (types.PermissionScope, []types.APIVerb, error) {
+	spl := strings.SplitN(permission, ":", 2)
+
+	if len(spl) != 2 {
+		return "", nil, fmt.Errorf("permission %s must be in the form scope:access", permission)
+	}
+
+	scope := types.PermissionScope(spl[0])
+
+	if !isTokenScope(types.ScopeHeirarchy, scope) {
+		return "", nil, fmt.Errorf("%s is not a valid scope", spl[0])
+	}
+
+	switch spl[1] {
+	case "read":
+		return scope, types.ReadVerbGroup(), nil
+	case "write":
+		return scope, []types.APIVerb{
+			types.APIVerbGet,
+			types.APIVerbList,
+			types.APIVerbCreate,
+			types.APIVerbUpdate,
+		}, nil
+	}
+
+	var verbs []types.APIVerb
+
+	for _, verb := range strings.Split(spl[1], ",") {
+		switch apiVerb := types.APIVerb(verb); apiVerb {
+		case types.APIVerbGet, types.APIVerbList, types.APIVerbCreate, types.APIVerbUpdate, types.APIVerbDelete:
+			verbs = append(verbs, apiVerb)
+		default:
+			return "", nil, fmt.Errorf("%s is not a valid access level or verb", verb)
+		}
+	}
+
+	return scope, verbs, nil
+}
+
+func isTokenScope(tree types.ScopeTree, scope types.PermissionScope) bool {
+	for treeScope, subtree := range tree {
+		if treeScope == scope || isTokenScope(subtree, scope) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func mergeVerbs(verbs []types.APIVerb, add []types.APIVerb) []types.APIVerb {
+	res := append([]types.APIVerb{}, verbs...)
+
+	for _, verb := range add {
+		found := false
+
+		for _, existing := range res {
+			if existing == verb {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			res = append(res, verb)
+		}
+	}
+
+	return res
+}
@@ -41,6 +41,8 @@ const Login: React.FC<Props> = ({
   const [hasBasic, setHasBasic] = useState(true);
   const [hasGithub, setHasGithub] = useState(true);
   const [hasGoogle, setHasGoogle] = useState(false);
+  const [hasOIDC, setHasOIDC] = useState(false);
+  const [oidcProviderName, setOIDCProviderName] = useState("SSO");
   const [hasResetPassword, setHasResetPassword] = useState(true);
   const [windowDimensions, setWindowDimensions] = useState(getWindowDimensions());
 
@@ -94,6 +96,8 @@ const Login: React.FC<Props> = ({
         setHasBasic(res.data?.basic_login);
         setHasGithub(res.data?.github_login);
         setHasGoogle(res.data?.google_login);
+        setHasOIDC(res.data?.oidc_login);
+        res.data?.oidc_provider_name && setOIDCProviderName(res.data.oidc_provider_name);
         setHasResetPassword(res.data?.email);
       })
       .catch((err) => console.log(err));
@@ -118,6 +122,11 @@ const Login: React.FC<Props> = ({
     window.location.href = redirectUrl;
   };
 
+  const oidcRedirect = () => {
+    let redirectUrl = `/api/oauth/login/oidc`;
+    window.location.href = redirectUrl;
+  };
+
   return (
     <StyledLogin>
       {windowDimensions.width > windowDimensions.height && (
@@ -156,7 +165,7 @@ const Login: React.FC<Props> = ({
           Log in to your Porter account
         </Heading>
         <Spacer y={1} />
-        {(hasGithub || hasGoogle) && (
+        {(hasGithub || hasGoogle || hasOIDC) && (
           <>
             <Container row>
               {hasGithub && (
@@ -174,6 +183,14 @@ const Login: React.FC<Props> = ({
                   Log in with Google
                 </OAuthButton>
               )}
+              {(hasGithub || hasGoogle) && hasOIDC && (
+                <Spacer inline x={2} />
+              )}
+              {hasOIDC && (
+                <OAuthButton onClick={oidcRedirect}>
+                  Log in with {oidcProviderName}
+                </OAuthButton>
+              )}
             </Container>
             {hasBasic && (
               <OrWrapper>
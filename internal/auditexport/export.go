@@ -0,0 +1,149 @@
+package auditexport
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	// BatchSize is the maximum number of audit events which are sent to a sink at once
+	BatchSize = 500
+
+	// MaxBatchesPerRun limits the number of batches which are sent to a sink in a single run, so
+	// that a sink which is catching up after an outage does not delay the other sinks
+	MaxBatchesPerRun = 20
+
+	// SettleDelay is the age an audit event must reach before it is exported. Event IDs are
+	// assigned when a transaction begins, so an event with a lower ID can become visible after
+	// an event with a higher ID. Waiting for events to settle prevents the cursor of a sink from
+	// moving past an event which is not visible yet.
+	SettleDelay = 10 * time.Second
+
+	initialBackoff = 30 * time.Second
+	maxBackoff     = time.Hour
+)
+
+// ExportAll sends the new audit events of every project to the sinks of the project. Events are
+// only marked as delivered after the sink accepted them, so each event is delivered at least once.
+func ExportAll(repo repository.AuditEventRepository, now time.Time) error {
+	sinks, err := repo.ListAuditSinks()
+	if err != nil {
+		return err
+	}
+
+	for _, sink := range sinks {
+		if sink.NextAttemptAt != nil && sink.NextAttemptAt.After(now) {
+			continue
+		}
+
+		if err := exportSink(repo, sink, now); err != nil {
+			log.Printf("error exporting audit events to sink %d of project %d: %v", sink.ID, sink.ProjectID, err)
+		}
+	}
+
+	return nil
+}
+
+func exportSink(repo repository.AuditEventRepository, sink *models.AuditSink, now time.Time) error {
+	prevLastEventID := sink.LastEventID
+
+	exporter, err := getSinkExporter(sink)
+
+	if err == nil {
+		err = exportBatches(repo, exporter, sink, now)
+	}
+
+	if err != nil {
+		sink.FailureCount++
+		sink.LastError = sinkErrorMessage(err)
+
+		backoff := initialBackoff << (sink.FailureCount - 1)
+
+		if backoff > maxBackoff || backoff <= 0 {
+			backoff = maxBackoff
+		}
+
+		nextAttemptAt := now.Add(backoff)
+		sink.NextAttemptAt = &nextAttemptAt
+	} else {
+		sink.FailureCount = 0
+		sink.NextAttemptAt = nil
+		sink.LastError = ""
+	}
+
+	if updateErr := repo.UpdateAuditSinkDelivery(sink, prevLastEventID); updateErr != nil {
+		return updateErr
+	}
+
+	return err
+}
+
+// exportBatches sends batches to the sink until it has received every settled event, or until
+// MaxBatchesPerRun is reached. The cursor of the sink is moved after each delivered batch.
+func exportBatches(
+	repo repository.AuditEventRepository,
+	exporter Exporter,
+	sink *models.AuditSink,
+	now time.Time,
+) error {
+	for i := 0; i < MaxBatchesPerRun; i++ {
+		events, err := repo.ListAuditEventsAfterID(sink.ProjectID, sink.LastEventID, now.Add(-SettleDelay), BatchSize)
+		if err != nil {
+			return err
+		}
+
+		if len(events) == 0 {
+			return nil
+		}
+
+		batch := make([]*types.AuditEvent, 0, len(events))
+
+		for _, event := range events {
+			batch = append(batch, event.ToAuditEventType())
+		}
+
+		if err := exporter.Export(context.Background(), batch); err != nil {
+			return err
+		}
+
+		exportedAt := time.Now()
+
+		sink.LastEventID = events[len(events)-1].ID
+		sink.LastExportedAt = &exportedAt
+
+		if len(events) < BatchSize {
+			return nil
+		}
+	}
+
+	return nil
+}
+
+// sinkErrorMessage returns the error which is shown to the project. The address of a sink which was
+// rejected is left out, since it would reveal what internal hostnames resolve to.
+func sinkErrorMessage(err error) string {
+	if errors.Is(err, egress.ErrPrivateAddress) {
+		return fmt.Sprintf("could not connect to sink: %v", egress.ErrPrivateAddress)
+	}
+
+	return err.Error()
+}
+
+func getSinkExporter(sink *models.AuditSink) (Exporter, error) {
+	conf := &types.AuditSinkConfig{}
+
+	if err := json.Unmarshal(sink.Config, conf); err != nil {
+		return nil, fmt.Errorf("could not read sink configuration: %w", err)
+	}
+
+	return NewExporter(types.AuditSinkKind(sink.Kind), conf)
+}
@@ -0,0 +1,98 @@
+package auditexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+)
+
+// Exporter delivers batches of audit events to an external sink. A batch is either delivered
+// completely or an error is returned, in which case the whole batch is retried.
+type Exporter interface {
+	Export(ctx context.Context, events []*types.AuditEvent) error
+}
+
+// NewExporter returns the exporter for the given kind of sink
+func NewExporter(kind types.AuditSinkKind, conf *types.AuditSinkConfig) (Exporter, error) {
+	if err := ValidateConfig(kind, conf); err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case types.AuditSinkSyslog:
+		return newSyslogExporter(conf.Syslog)
+	case types.AuditSinkS3:
+		return newS3Exporter(conf.S3)
+	case types.AuditSinkHTTPS:
+		return newHTTPSExporter(conf.HTTPS), nil
+	}
+
+	return nil, fmt.Errorf("unknown audit sink kind %s", kind)
+}
+
+// ValidateConfig checks that the configuration of the given kind of sink is set, and that the sink
+// is not a private address. Hostnames which resolve to private addresses are rejected when the
+// exporter connects to them.
+func ValidateConfig(kind types.AuditSinkKind, conf *types.AuditSinkConfig) error {
+	if conf == nil {
+		return fmt.Errorf("configuration for %s sink must be set", kind)
+	}
+
+	switch kind {
+	case types.AuditSinkSyslog:
+		if conf.Syslog == nil {
+			return fmt.Errorf("syslog configuration must be set")
+		}
+
+		host, _, err := net.SplitHostPort(conf.Syslog.Address)
+		if err != nil {
+			return fmt.Errorf("syslog address must be in the form host:port")
+		}
+
+		if err := egress.ValidateHost(host); err != nil {
+			return fmt.Errorf("invalid syslog address: %w", err)
+		}
+	case types.AuditSinkS3:
+		if conf.S3 == nil {
+			return fmt.Errorf("s3 configuration must be set")
+		}
+
+		if conf.S3.Endpoint != "" {
+			if err := egress.ValidateHTTPSURL(conf.S3.Endpoint); err != nil {
+				return fmt.Errorf("invalid s3 endpoint: %w", err)
+			}
+		}
+	case types.AuditSinkHTTPS:
+		if conf.HTTPS == nil {
+			return fmt.Errorf("https configuration must be set")
+		}
+
+		if err := egress.ValidateHTTPSURL(conf.HTTPS.URL); err != nil {
+			return fmt.Errorf("invalid https sink URL: %w", err)
+		}
+	default:
+		return fmt.Errorf("unknown audit sink kind %s", kind)
+	}
+
+	return nil
+}
+
+// toNDJSON encodes the events as newline-delimited JSON
+func toNDJSON(events []*types.AuditEvent) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := json.NewEncoder(&buf)
+
+	for _, event := range events {
+		if err := enc.Encode(event); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,162 @@
+package auditexport
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+)
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		kind    types.AuditSinkKind
+		conf    *types.AuditSinkConfig
+		wantErr bool
+	}{
+		{
+			name: "https sink",
+			kind: types.AuditSinkHTTPS,
+			conf: &types.AuditSinkConfig{HTTPS: &types.HTTPSAuditSinkConfig{URL: "https://siem.example.com/audit"}},
+		},
+		{
+			name:    "http sink",
+			kind:    types.AuditSinkHTTPS,
+			conf:    &types.AuditSinkConfig{HTTPS: &types.HTTPSAuditSinkConfig{URL: "http://siem.example.com/audit"}},
+			wantErr: true,
+		},
+		{
+			name:    "https sink on a link-local address",
+			kind:    types.AuditSinkHTTPS,
+			conf:    &types.AuditSinkConfig{HTTPS: &types.HTTPSAuditSinkConfig{URL: "https://169.254.169.254/latest/meta-data/"}},
+			wantErr: true,
+		},
+		{
+			name: "s3 sink on aws",
+			kind: types.AuditSinkS3,
+			conf: &types.AuditSinkConfig{S3: &types.S3AuditSinkConfig{Bucket: "audit", Region: "us-east-1"}},
+		},
+		{
+			name: "s3 sink with a custom endpoint",
+			kind: types.AuditSinkS3,
+			conf: &types.AuditSinkConfig{S3: &types.S3AuditSinkConfig{
+				Bucket:   "audit",
+				Region:   "us-east-1",
+				Endpoint: "https://minio.example.com",
+			}},
+		},
+		{
+			name: "s3 sink on a private endpoint",
+			kind: types.AuditSinkS3,
+			conf: &types.AuditSinkConfig{S3: &types.S3AuditSinkConfig{
+				Bucket:   "audit",
+				Region:   "us-east-1",
+				Endpoint: "https://10.0.0.12:9000",
+			}},
+			wantErr: true,
+		},
+		{
+			name: "syslog sink",
+			kind: types.AuditSinkSyslog,
+			conf: &types.AuditSinkConfig{Syslog: &types.SyslogAuditSinkConfig{Address: "syslog.example.com:6514"}},
+		},
+		{
+			name:    "syslog sink on localhost",
+			kind:    types.AuditSinkSyslog,
+			conf:    &types.AuditSinkConfig{Syslog: &types.SyslogAuditSinkConfig{Address: "localhost:514"}},
+			wantErr: true,
+		},
+		{
+			name:    "syslog sink on a private address",
+			kind:    types.AuditSinkSyslog,
+			conf:    &types.AuditSinkConfig{Syslog: &types.SyslogAuditSinkConfig{Address: "192.168.1.10:514"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateConfig(tt.kind, tt.conf)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error to be %t, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+// the exporters are created directly, since the addresses of local test servers are rejected by
+// ValidateConfig before they could be dialed
+func TestExportersRejectPrivateAddresses(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("audit events should not be sent to %s", r.Host)
+	}))
+	defer server.Close()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	syslog, err := newSyslogExporter(&types.SyslogAuditSinkConfig{Address: listener.Addr().String()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s3, err := newS3Exporter(&types.S3AuditSinkConfig{
+		Bucket:          "audit",
+		Region:          "us-east-1",
+		Endpoint:        server.URL,
+		ForcePathStyle:  true,
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exporters := map[string]Exporter{
+		"https":  newHTTPSExporter(&types.HTTPSAuditSinkConfig{URL: server.URL}),
+		"s3":     s3,
+		"syslog": syslog,
+	}
+
+	events := []*types.AuditEvent{{ID: 1, CreatedAt: time.Now(), ProjectID: 1}}
+
+	for name, exporter := range exporters {
+		t.Run(name, func(t *testing.T) {
+			err := exporter.Export(context.Background(), events)
+
+			if !errors.Is(err, egress.ErrPrivateAddress) {
+				t.Fatalf("expected a private address error, got %v", err)
+			}
+
+			if msg := sinkErrorMessage(err); strings.Contains(msg, "127.0.0.1") {
+				t.Errorf("expected error shown to the project not to contain the address, got %q", msg)
+			}
+		})
+	}
+}
+
+func TestSinkErrorMessage(t *testing.T) {
+	err := fmt.Errorf("could not send audit events: %w", fmt.Errorf("%w: 10.0.3.4", egress.ErrPrivateAddress))
+
+	if msg := sinkErrorMessage(err); strings.Contains(msg, "10.0.3.4") {
+		t.Errorf("expected rejected address to be left out, got %q", msg)
+	}
+
+	err = fmt.Errorf("audit sink responded with status 503")
+
+	if msg := sinkErrorMessage(err); msg != err.Error() {
+		t.Errorf("expected other errors to be shown, got %q", msg)
+	}
+}
@@ -0,0 +1,68 @@
+package auditexport
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+	"github.com/porter-dev/porter/internal/notifier/webhook"
+)
+
+type httpsExporter struct {
+	client *http.Client
+	conf   *types.HTTPSAuditSinkConfig
+}
+
+func newHTTPSExporter(conf *types.HTTPSAuditSinkConfig) *httpsExporter {
+	return &httpsExporter{
+		client: egress.NewHTTPClient(30 * time.Second),
+		conf:   conf,
+	}
+}
+
+// Export posts the batch as NDJSON. Every status outside of 2xx fails the export, so that
+// endpoints which throttle with 429 or fail with 5xx receive the batch again later.
+func (h *httpsExporter) Export(ctx context.Context, events []*types.AuditEvent) error {
+	body, err := toNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.conf.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	// the delivery ID only depends on the events in the batch, so receivers can deduplicate retries
+	req.Header.Set(webhook.DeliveryHeader, fmt.Sprintf("audit-%d-%d", events[0].ID, events[len(events)-1].ID))
+
+	if h.conf.Authorization != "" {
+		req.Header.Set("Authorization", h.conf.Authorization)
+	}
+
+	if h.conf.Secret != "" {
+		req.Header.Set(webhook.SignatureHeader, webhook.Sign([]byte(h.conf.Secret), body))
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("could not send audit events: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("audit sink responded with status %d", resp.StatusCode)
+	}
+
+	return nil
+}
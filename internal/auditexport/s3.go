@@ -0,0 +1,90 @@
+package auditexport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+)
+
+type s3Exporter struct {
+	client *s3.S3
+	conf   *types.S3AuditSinkConfig
+}
+
+func newS3Exporter(conf *types.S3AuditSinkConfig) (*s3Exporter, error) {
+	awsConf := &aws.Config{
+		Credentials: credentials.NewStaticCredentials(
+			conf.AccessKeyID,
+			conf.SecretAccessKey,
+			"",
+		),
+		Region:           aws.String(conf.Region),
+		S3ForcePathStyle: aws.Bool(conf.ForcePathStyle),
+	}
+
+	// the default endpoints of AWS are not configured by users, so only custom endpoints are
+	// dialed with the client which rejects private addresses
+	if conf.Endpoint != "" {
+		awsConf.Endpoint = aws.String(conf.Endpoint)
+		awsConf.HTTPClient = egress.NewHTTPClient(30 * time.Second)
+	}
+
+	sess, err := session.NewSession(awsConf)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create AWS session: %v", err)
+	}
+
+	return &s3Exporter{
+		client: s3.New(sess),
+		conf:   conf,
+	}, nil
+}
+
+// Export writes the batch as a single NDJSON object. The key of the object is derived from the
+// IDs of the events, so a batch which is retried overwrites the object of the failed attempt.
+func (s *s3Exporter) Export(ctx context.Context, events []*types.AuditEvent) error {
+	body, err := toNDJSON(events)
+	if err != nil {
+		return err
+	}
+
+	first := events[0]
+	last := events[len(events)-1]
+
+	key := path.Join(
+		s.conf.Prefix,
+		fmt.Sprintf("project-%d", first.ProjectID),
+		first.CreatedAt.UTC().Format("2006/01/02"),
+		fmt.Sprintf("%012d-%012d.ndjson", first.ID, last.ID),
+	)
+
+	_, err = s.client.PutObjectWithContext(ctx, &s3.PutObjectInput{
+		Body:        aws.ReadSeekCloser(bytes.NewReader(body)),
+		Bucket:      aws.String(s.conf.Bucket),
+		Key:         aws.String(key),
+		ContentType: aws.String("application/x-ndjson"),
+	})
+	if err != nil {
+		// errors of the AWS SDK cannot be unwrapped, so rejected addresses are returned directly
+		var awsErr awserr.Error
+
+		if errors.As(err, &awsErr) && errors.Is(awsErr.OrigErr(), egress.ErrPrivateAddress) {
+			err = awsErr.OrigErr()
+		}
+
+		return fmt.Errorf("could not write audit events to bucket %s: %w", s.conf.Bucket, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,129 @@
+package auditexport
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/egress"
+)
+
+const (
+	// syslogFacility is the "log audit" facility of RFC 5424
+	syslogFacility = 13
+
+	syslogSeverityNotice  = 5
+	syslogSeverityWarning = 4
+
+	syslogTimeout = 30 * time.Second
+)
+
+type syslogExporter struct {
+	conf      *types.SyslogAuditSinkConfig
+	tlsConfig *tls.Config
+}
+
+func newSyslogExporter(conf *types.SyslogAuditSinkConfig) (*syslogExporter, error) {
+	exporter := &syslogExporter{conf: conf}
+
+	if conf.TLS {
+		host, _, err := net.SplitHostPort(conf.Address)
+		if err != nil {
+			return nil, err
+		}
+
+		exporter.tlsConfig = &tls.Config{
+			ServerName: host,
+			MinVersion: tls.VersionTLS12,
+		}
+
+		if conf.CACertificate != "" {
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+
+			if !pool.AppendCertsFromPEM([]byte(conf.CACertificate)) {
+				return nil, fmt.Errorf("could not parse syslog CA certificate")
+			}
+
+			exporter.tlsConfig.RootCAs = pool
+		}
+	}
+
+	return exporter, nil
+}
+
+// Export opens a new connection for every batch, so that a broken connection to the syslog server
+// does not outlive a failed export
+func (s *syslogExporter) Export(ctx context.Context, events []*types.AuditEvent) error {
+	ctx, cancel := context.WithTimeout(ctx, syslogTimeout)
+	defer cancel()
+
+	dialer := egress.NewDialer(syslogTimeout)
+
+	var conn net.Conn
+	var err error
+
+	if s.tlsConfig != nil {
+		conn, err = (&tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}).DialContext(ctx, "tcp", s.conf.Address)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", s.conf.Address)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not connect to syslog server: %w", err)
+	}
+
+	defer conn.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	for _, event := range events {
+		msg, err := formatSyslogMessage(s.conf.AppName, event)
+		if err != nil {
+			return err
+		}
+
+		// messages are framed with octet counting as described in RFC 6587
+		if _, err := fmt.Fprintf(conn, "%d %s", len(msg), msg); err != nil {
+			return fmt.Errorf("could not write to syslog server: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// formatSyslogMessage formats the event as an RFC 5424 message with the JSON-encoded event as
+// the message body
+func formatSyslogMessage(appName string, event *types.AuditEvent) (string, error) {
+	if appName == "" {
+		appName = "porter"
+	}
+
+	severity := syslogSeverityNotice
+
+	if event.Outcome != types.AuditOutcomeSuccess {
+		severity = syslogSeverityWarning
+	}
+
+	body, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf(
+		"<%d>1 %s porter %s - audit - %s",
+		syslogFacility*8+severity,
+		event.CreatedAt.UTC().Format(time.RFC3339Nano),
+		appName,
+		body,
+	), nil
+}
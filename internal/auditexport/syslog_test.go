@@ -0,0 +1,45 @@
+package auditexport
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+func TestFormatSyslogMessage(t *testing.T) {
+	event := &types.AuditEvent{
+		ID:        42,
+		CreatedAt: time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC),
+		ProjectID: 1,
+		Actor: &types.AuditActor{
+			Kind: types.AuditActorUser,
+			Name: "test@example.com",
+		},
+		Verb:    types.APIVerbUpdate,
+		Outcome: types.AuditOutcomeSuccess,
+	}
+
+	msg, err := formatSyslogMessage("", event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expPrefix := `<109>1 2023-01-02T03:04:05Z porter porter - audit - {"id":42,`
+
+	if !strings.HasPrefix(msg, expPrefix) {
+		t.Errorf("expected message to start with %q, got %q", expPrefix, msg)
+	}
+
+	event.Outcome = types.AuditOutcomeForbidden
+
+	msg, err = formatSyslogMessage("siem", event)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(msg, "<108>1 2023-01-02T03:04:05Z porter siem - audit") {
+		t.Errorf("expected forbidden event to be logged as a warning by app siem, got %q", msg)
+	}
+}
@@ -0,0 +1,127 @@
+// Package egress makes the connections to addresses which are configured by users, such as notification
+// webhooks and audit log sinks. Connections to addresses which are not publicly routable are rejected
+// when they are dialed, after the host has been resolved, so that a hostname which resolves to a private
+// address cannot be used to reach the network of the server, even if it resolved to a public address
+// when it was validated.
+package egress
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when dialing an address which is not publicly routable
+var ErrPrivateAddress = errors.New("connections to private addresses are not allowed")
+
+// reservedNets are the ranges which are not publicly routable, other than the private, loopback,
+// link-local and multicast ranges checked by net.IP
+var reservedNets = []*net.IPNet{
+	mustParseCIDR("0.0.0.0/8"),
+	mustParseCIDR("100.64.0.0/10"), // shared address space of carrier-grade NAT
+	mustParseCIDR("192.0.0.0/24"),
+	mustParseCIDR("198.18.0.0/15"),
+	mustParseCIDR("64:ff9b::/96"), // NAT64, which can map to any IPv4 address
+}
+
+func mustParseCIDR(cidr string) *net.IPNet {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		panic(err)
+	}
+
+	return ipNet
+}
+
+// IsPublicIP returns whether the address is publicly routable
+func IsPublicIP(ip net.IP) bool {
+	if ip.IsUnspecified() || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+
+	for _, ipNet := range reservedNets {
+		if ipNet.Contains(ip) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// control is called with the resolved address of every connection before it is made
+func control(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	if ip := net.ParseIP(host); ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+
+	return nil
+}
+
+// NewDialer returns a dialer which rejects connections to addresses which are not publicly routable
+func NewDialer(timeout time.Duration) *net.Dialer {
+	return &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+		Control:   control,
+	}
+}
+
+// NewHTTPClient returns an HTTP client which makes every connection, including those of redirects, with
+// a dialer from NewDialer. Proxies from the environment are not used, since the proxy would connect to
+// the address instead.
+func NewHTTPClient(timeout time.Duration) *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = NewDialer(timeout).DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+	}
+}
+
+// ValidateHTTPSURL checks that the URL uses https, and that its host is not an address which would
+// be rejected when it is dialed
+func ValidateHTTPSURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid URL: %w", err)
+	}
+
+	if u.Scheme != "https" {
+		return fmt.Errorf("URL must use https")
+	}
+
+	return ValidateHost(u.Hostname())
+}
+
+// ValidateHost rejects hosts which are addresses that are not publicly routable, or localhost. Other
+// hostnames are only checked when they are dialed, since they may resolve to different addresses later.
+func ValidateHost(host string) error {
+	if host == "" {
+		return fmt.Errorf("host is required")
+	}
+
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !IsPublicIP(ip) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, host)
+	}
+
+	return nil
+}
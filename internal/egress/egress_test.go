@@ -0,0 +1,96 @@
+package egress_test
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/egress"
+)
+
+func TestIsPublicIP(t *testing.T) {
+	tests := []struct {
+		ip   string
+		want bool
+	}{
+		{"8.8.8.8", true},
+		{"2606:4700:4700::1111", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.0.0.1", false},
+		{"172.16.5.4", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fd00::1", false},
+		{"0.0.0.0", false},
+		{"100.64.0.1", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"224.0.0.1", false},
+	}
+
+	for _, tt := range tests {
+		if got := egress.IsPublicIP(net.ParseIP(tt.ip)); got != tt.want {
+			t.Errorf("IsPublicIP(%s): expected %t, got %t", tt.ip, tt.want, got)
+		}
+	}
+}
+
+func TestValidateHTTPSURL(t *testing.T) {
+	tests := []struct {
+		url     string
+		wantErr bool
+	}{
+		{"https://hooks.example.com/porter", false},
+		{"https://8.8.8.8/porter", false},
+		{"http://hooks.example.com/porter", true},
+		{"ftp://hooks.example.com/porter", true},
+		{"https:///porter", true},
+		{"https://localhost/porter", true},
+		{"https://api.localhost/porter", true},
+		{"https://127.0.0.1:8080/porter", true},
+		{"https://169.254.169.254/latest/meta-data/", true},
+		{"https://[::1]/porter", true},
+	}
+
+	for _, tt := range tests {
+		if err := egress.ValidateHTTPSURL(tt.url); (err != nil) != tt.wantErr {
+			t.Errorf("ValidateHTTPSURL(%s): expected error to be %t, got %v", tt.url, tt.wantErr, err)
+		}
+	}
+}
+
+func TestHTTPClientRejectsPrivateAddresses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("request should not reach the server")
+	}))
+	defer server.Close()
+
+	_, err := egress.NewHTTPClient(time.Second).Get(server.URL)
+
+	if !errors.Is(err, egress.ErrPrivateAddress) {
+		t.Fatalf("expected a private address error, got %v", err)
+	}
+}
+
+func TestDialerRejectsPrivateAddresses(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	// the host is resolved before the address is checked
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+
+	_, err = egress.NewDialer(time.Second).Dial("tcp", net.JoinHostPort("localhost", port))
+
+	if !errors.Is(err, egress.ErrPrivateAddress) {
+		t.Fatalf("expected a private address error, got %v", err)
+	}
+}
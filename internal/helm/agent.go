@@ -171,6 +171,15 @@ type UpgradeReleaseConfig struct {
 	// Optional, if chart is part of a Porter Stack
 	StackName     string
 	StackRevision uint
+
+	// Optional, validates a dry run of the upgrade before it is applied
+	Validator ReleaseValidator
+}
+
+// ReleaseValidator validates a release rendered by a dry run before it is installed or upgraded. If it
+// returns an error, the release is not deployed.
+type ReleaseValidator interface {
+	ValidateRelease(rel *release.Release) error
 }
 
 // UpgradeRelease upgrades a specific release with new values.yaml
@@ -233,7 +242,25 @@ func (a *Agent) UpgradeReleaseByValues(
 		}
 	}
 
-	res, err := cmd.Run(conf.Name, ch, conf.Values)
+	runUpgrade := func() (*release.Release, error) {
+		if conf.Validator != nil {
+			cmd.DryRun = true
+			dryRunRel, err := cmd.Run(conf.Name, ch, conf.Values)
+			cmd.DryRun = false
+
+			if err != nil {
+				return nil, err
+			}
+
+			if err := conf.Validator.ValidateRelease(dryRunRel); err != nil {
+				return nil, err
+			}
+		}
+
+		return cmd.Run(conf.Name, ch, conf.Values)
+	}
+
+	res, err := runUpgrade()
 	if err != nil {
 		// refer: https://github.com/helm/helm/blob/release-3.8/pkg/action/action.go#L62
 		// issue tracker: https://github.com/helm/helm/issues/4558
@@ -272,7 +299,7 @@ func (a *Agent) UpgradeReleaseByValues(
 					}
 
 					// retry upgrade
-					res, err = cmd.Run(conf.Name, ch, conf.Values)
+					res, err = runUpgrade()
 
 					if err != nil {
 						return nil, fmt.Errorf("Upgrade failed: %w", err)
@@ -345,7 +372,7 @@ func (a *Agent) UpgradeReleaseByValues(
 					return nil, fmt.Errorf("Upgrade failed: %w", err)
 				}
 
-				res, err := cmd.Run(conf.Name, ch, conf.Values)
+				res, err := runUpgrade()
 				if err != nil {
 					return nil, fmt.Errorf("Upgrade failed: %w", err)
 				}
@@ -369,6 +396,9 @@ type InstallChartConfig struct {
 	Cluster    *models.Cluster
 	Repo       repository.Repository
 	Registries []*models.Registry
+
+	// Optional, validates a dry run of the install before it is applied
+	Validator ReleaseValidator
 }
 
 // InstallChartFromValuesBytes reads the raw values and calls Agent.InstallChart
@@ -437,6 +467,20 @@ func (a *Agent) InstallChart(
 		}
 	}
 
+	if conf.Validator != nil {
+		cmd.DryRun = true
+		dryRunRel, err := cmd.Run(conf.Chart, conf.Values)
+		cmd.DryRun = false
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := conf.Validator.ValidateRelease(dryRunRel); err != nil {
+			return nil, err
+		}
+	}
+
 	return cmd.Run(conf.Chart, conf.Values)
 }
 
@@ -2,7 +2,6 @@ package gitlab
 
 import (
 	"fmt"
-	"net/http"
 	"net/url"
 	"strings"
 
@@ -46,16 +45,9 @@ func (g *GitlabCI) Setup() error {
 
 	g.pID = fmt.Sprintf("%s/%s", g.GitRepoOwner, g.GitRepoName)
 
-	branches, _, err := client.Branches.ListBranches(g.pID, &gitlab.ListBranchesOptions{})
+	g.defaultGitBranch, err = getDefaultBranch(client, g.pID)
 	if err != nil {
-		return fmt.Errorf("error fetching list of branches: %w", err)
-	}
-
-	for _, branch := range branches {
-		if branch.Default {
-			g.defaultGitBranch = branch.Name
-			break
-		}
+		return err
 	}
 
 	err = g.createGitlabSecret(client)
@@ -66,126 +58,7 @@ func (g *GitlabCI) Setup() error {
 
 	jobName := getGitlabStageJobName(g.ReleaseName)
 
-	ciFile, resp, err := client.RepositoryFiles.GetRawFile(g.pID, ".gitlab-ci.yml", &gitlab.GetRawFileOptions{
-		Ref: gitlab.String(g.defaultGitBranch),
-	})
-
-	if resp.StatusCode == http.StatusNotFound {
-		// create .gitlab-ci.yml
-		contentsMap := make(map[string]interface{})
-		contentsMap["stages"] = []string{
-			jobName,
-		}
-		contentsMap[jobName] = g.getCIJob(jobName)
-
-		contentsYAML, _ := yaml.Marshal(contentsMap)
-
-		_, _, err = client.RepositoryFiles.CreateFile(g.pID, ".gitlab-ci.yml", &gitlab.CreateFileOptions{
-			Branch:        gitlab.String(g.defaultGitBranch),
-			AuthorName:    gitlab.String("Porter Bot"),
-			AuthorEmail:   gitlab.String("contact@getporter.dev"),
-			Content:       gitlab.String(string(contentsYAML)),
-			CommitMessage: gitlab.String("Create .gitlab-ci.yml file"),
-		})
-
-		if err != nil {
-			return fmt.Errorf("error creating .gitlab-ci.yml file: %w", err)
-		}
-	} else if err != nil {
-		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
-	} else {
-		// update .gitlab-ci.yml if needed
-
-		// to preserve the order of the YAML, we use a MapSlice
-		ciFileContentsMap := yaml.MapSlice{}
-		err = yaml.Unmarshal(ciFile, &ciFileContentsMap)
-
-		if err != nil {
-			return fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
-		}
-
-		var stagesInt []interface{}
-		stagesIdx := -1
-
-		for idx, elem := range ciFileContentsMap {
-			if key, ok := elem.Key.(string); ok {
-				if key == "stages" {
-					stages, ok := elem.Value.([]interface{})
-
-					if !ok {
-						return fmt.Errorf("error converting stages to interface slice")
-					}
-
-					stagesInt = stages
-					stagesIdx = idx
-
-					break
-				}
-			} else {
-				return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
-			}
-		}
-
-		// two cases can happen here:
-		// 1: "stages" exists
-		// 2: "stages" does not exist
-
-		if stagesIdx >= 0 { // 1: "stages" exists
-			stageExists := false
-
-			for _, stage := range stagesInt {
-				stageStr, ok := stage.(string)
-				if !ok {
-					return fmt.Errorf("error converting from interface to string")
-				}
-
-				if stageStr == jobName {
-					stageExists = true
-					break
-				}
-			}
-
-			if !stageExists {
-				stagesInt = append(stagesInt, jobName)
-
-				ciFileContentsMap[stagesIdx] = yaml.MapItem{
-					Key:   "stages",
-					Value: stagesInt,
-				}
-			}
-		} else { // 2: "stages" does not exist
-			stagesInt = append(stagesInt, jobName)
-
-			ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
-				Key:   "stages",
-				Value: stagesInt,
-			})
-		}
-
-		ciFileContentsMap = append(ciFileContentsMap, yaml.MapItem{
-			Key:   jobName,
-			Value: g.getCIJob(jobName),
-		})
-
-		contentsYAML, err := yaml.Marshal(ciFileContentsMap)
-		if err != nil {
-			return fmt.Errorf("error marshalling contents of .gitlab-ci.yml while updating to add porter job")
-		}
-
-		_, _, err = client.RepositoryFiles.UpdateFile(g.pID, ".gitlab-ci.yml", &gitlab.UpdateFileOptions{
-			Branch:        gitlab.String(g.defaultGitBranch),
-			AuthorName:    gitlab.String("Porter Bot"),
-			AuthorEmail:   gitlab.String("contact@getporter.dev"),
-			Content:       gitlab.String(string(contentsYAML)),
-			CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
-		})
-
-		if err != nil {
-			return fmt.Errorf("error updating .gitlab-ci.yml file to add porter job: %w", err)
-		}
-	}
-
-	return nil
+	return addCIJob(client, g.pID, g.defaultGitBranch, jobName, g.getCIJob(jobName))
 }
 
 func (g *GitlabCI) Cleanup() error {
@@ -196,16 +69,9 @@ func (g *GitlabCI) Cleanup() error {
 
 	g.pID = fmt.Sprintf("%s/%s", g.GitRepoOwner, g.GitRepoName)
 
-	branches, _, err := client.Branches.ListBranches(g.pID, &gitlab.ListBranchesOptions{})
+	g.defaultGitBranch, err = getDefaultBranch(client, g.pID)
 	if err != nil {
-		return fmt.Errorf("error fetching list of branches: %w", err)
-	}
-
-	for _, branch := range branches {
-		if branch.Default {
-			g.defaultGitBranch = branch.Name
-			break
-		}
+		return err
 	}
 
 	err = g.deleteGitlabSecret(client)
@@ -214,97 +80,7 @@ func (g *GitlabCI) Cleanup() error {
 		return err
 	}
 
-	jobName := getGitlabStageJobName(g.ReleaseName)
-
-	ciFile, resp, err := client.RepositoryFiles.GetRawFile(g.pID, ".gitlab-ci.yml", &gitlab.GetRawFileOptions{
-		Ref: gitlab.String(g.defaultGitBranch),
-	})
-
-	if resp.StatusCode == http.StatusNotFound {
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error getting .gitlab-ci.yml file: %w", err)
-	}
-
-	ciFileContentsMap := yaml.MapSlice{}
-	err = yaml.Unmarshal(ciFile, &ciFileContentsMap)
-
-	if err != nil {
-		return fmt.Errorf("error unmarshalling existing .gitlab-ci.yml: %w", err)
-	}
-
-	var stagesInt []interface{}
-	stagesIdx := -1
-
-	for idx, elem := range ciFileContentsMap {
-		if key, ok := elem.Key.(string); ok {
-			if key == "stages" {
-				stages, ok := elem.Value.([]interface{})
-
-				if !ok {
-					return fmt.Errorf("error converting stages to interface slice")
-				}
-
-				stagesInt = stages
-				stagesIdx = idx
-
-				break
-			}
-		} else {
-			return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
-		}
-	}
-
-	if stagesIdx >= 0 { // "stages" exists
-		var newStages []string
-
-		for _, stage := range stagesInt {
-			stageStr, ok := stage.(string)
-			if !ok {
-				return fmt.Errorf("error converting from interface to string")
-			}
-
-			if stageStr != jobName {
-				newStages = append(newStages, stageStr)
-			}
-		}
-
-		ciFileContentsMap[stagesIdx] = yaml.MapItem{
-			Key:   "stages",
-			Value: newStages,
-		}
-	}
-
-	newCIFileContentsMap := yaml.MapSlice{}
-
-	for _, elem := range ciFileContentsMap {
-		if key, ok := elem.Key.(string); ok {
-			if key != jobName {
-				newCIFileContentsMap = append(newCIFileContentsMap, elem)
-			}
-		} else {
-			return fmt.Errorf("invalid key '%v' in .gitlab-ci.yml", elem.Key)
-		}
-	}
-
-	contentsYAML, err := yaml.Marshal(newCIFileContentsMap)
-	if err != nil {
-		return fmt.Errorf("error unmarshalling contents of .gitlab-ci.yml while updating to remove porter job")
-	}
-
-	_, _, err = client.RepositoryFiles.UpdateFile(g.pID, ".gitlab-ci.yml", &gitlab.UpdateFileOptions{
-		Branch:        gitlab.String(g.defaultGitBranch),
-		AuthorName:    gitlab.String("Porter Bot"),
-		AuthorEmail:   gitlab.String("contact@getporter.dev"),
-		Content:       gitlab.String(string(contentsYAML)),
-		CommitMessage: gitlab.String("Update .gitlab-ci.yml file"),
-	})
-
-	if err != nil {
-		return fmt.Errorf("error updating .gitlab-ci.yml file to remove porter job: %w", err)
-	}
-
-	return nil
+	return removeCIJob(client, g.pID, g.defaultGitBranch, getGitlabStageJobName(g.ReleaseName))
 }
 
 func (g *GitlabCI) getClient() (*gitlab.Client, error) {
@@ -445,47 +221,11 @@ func (g *GitlabCI) getCIJob(jobName string) yaml.MapSlice {
 }
 
 func (g *GitlabCI) createGitlabSecret(client *gitlab.Client) error {
-	_, resp, err := client.ProjectVariables.GetVariable(g.pID, g.getPorterTokenSecretName(),
-		&gitlab.GetProjectVariableOptions{})
-
-	if resp.StatusCode == http.StatusNotFound {
-		_, _, err = client.ProjectVariables.CreateVariable(g.pID, &gitlab.CreateProjectVariableOptions{
-			Key:    gitlab.String(g.getPorterTokenSecretName()),
-			Value:  gitlab.String(g.PorterToken),
-			Masked: gitlab.Bool(true),
-		})
-
-		if err != nil {
-			return fmt.Errorf("error creating porter token variable: %w", err)
-		}
-
-		return nil
-	} else if err != nil {
-		return fmt.Errorf("error getting porter token variable: %w", err)
-	}
-
-	_, _, err = client.ProjectVariables.UpdateVariable(g.pID, g.getPorterTokenSecretName(),
-		&gitlab.UpdateProjectVariableOptions{
-			Value:  gitlab.String(g.PorterToken),
-			Masked: gitlab.Bool(true),
-		},
-	)
-
-	if err != nil {
-		return fmt.Errorf("error updating porter token variable: %w", err)
-	}
-
-	return nil
+	return createOrUpdateVariable(client, g.pID, g.getPorterTokenSecretName(), g.PorterToken)
 }
 
 func (g *GitlabCI) deleteGitlabSecret(client *gitlab.Client) error {
-	_, err := client.ProjectVariables.RemoveVariable(g.pID, g.getPorterTokenSecretName(),
-		&gitlab.RemoveProjectVariableOptions{})
-	if err != nil {
-		return fmt.Errorf("error removing porter token variable: %w", err)
-	}
-
-	return nil
+	return removeVariable(client, g.pID, g.getPorterTokenSecretName())
 }
 
 func (g *GitlabCI) getPorterTokenSecretName() string {
@@ -0,0 +1,206 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/xanzy/go-gitlab"
+	"gopkg.in/yaml.v2"
+)
+
+type PreviewEnvOpts struct {
+	Client                                    *gitlab.Client
+	InstanceURL                               string
+	ServerURL                                 string
+	PorterToken                               string
+	GitRepoOwner, GitRepoName                 string
+	EnvironmentName                           string
+	InstanceName                              string
+	ProjectID, ClusterID, GitlabIntegrationID uint
+}
+
+// previewEnvVars are the variables read by `porter apply` to create or update a preview deployment
+var previewEnvVars = []string{
+	"PORTER_HOST", "PORTER_PROJECT", "PORTER_CLUSTER", "PORTER_TOKEN", "PORTER_GITLAB_INTEGRATION_ID",
+	"PORTER_PULL_REQUEST_ID", "PORTER_BRANCH_FROM", "PORTER_BRANCH_INTO", "PORTER_ACTION_ID",
+	"PORTER_REPO_OWNER", "PORTER_REPO_NAME", "PORTER_PR_NAME", "PORTER_TAG", "PORTER_NAMESPACE",
+}
+
+// SetupPreviewEnv stores the Porter token as a masked CI/CD variable of the GitLab project and adds
+// a job to the .gitlab-ci.yml file of the default branch, which runs `porter apply` in merge request pipelines
+func SetupPreviewEnv(opts *PreviewEnvOpts) error {
+	pID := fmt.Sprintf("%s/%s", opts.GitRepoOwner, opts.GitRepoName)
+
+	defaultBranch, err := getDefaultBranch(opts.Client, pID)
+	if err != nil {
+		return err
+	}
+
+	err = createOrUpdateVariable(
+		opts.Client, pID, getPreviewEnvSecretName(opts.ProjectID, opts.ClusterID, opts.InstanceName), opts.PorterToken,
+	)
+
+	if err != nil {
+		return err
+	}
+
+	jobName := getPreviewEnvJobName(opts.EnvironmentName)
+
+	return addCIJob(opts.Client, pID, defaultBranch, jobName, getPreviewCIJob(opts, jobName))
+}
+
+// DeletePreviewEnv removes the job and the CI/CD variable added by SetupPreviewEnv
+func DeletePreviewEnv(opts *PreviewEnvOpts) error {
+	pID := fmt.Sprintf("%s/%s", opts.GitRepoOwner, opts.GitRepoName)
+
+	defaultBranch, err := getDefaultBranch(opts.Client, pID)
+	if err != nil {
+		return err
+	}
+
+	err = removeVariable(opts.Client, pID, getPreviewEnvSecretName(opts.ProjectID, opts.ClusterID, opts.InstanceName))
+
+	if err != nil {
+		return err
+	}
+
+	return removeCIJob(opts.Client, pID, defaultBranch, getPreviewEnvJobName(opts.EnvironmentName))
+}
+
+func getPreviewEnvSecretName(projectID, clusterID uint, instanceName string) string {
+	if instanceName != "" {
+		return fmt.Sprintf("PORTER_PREVIEW_%s_%d_%d", strings.ToUpper(instanceName), projectID, clusterID)
+	}
+
+	return fmt.Sprintf("PORTER_PREVIEW_%d_%d", projectID, clusterID)
+}
+
+func getPreviewEnvJobName(envName string) string {
+	return fmt.Sprintf("porter-preview-%s", strings.ToLower(strings.ReplaceAll(envName, "_", "-")))
+}
+
+func getPreviewCIJob(opts *PreviewEnvOpts, jobName string) yaml.MapSlice {
+	res := yaml.MapSlice{}
+	instanceURL, _ := url.Parse(opts.InstanceURL)
+
+	variables := yaml.MapSlice{
+		{Key: "GIT_STRATEGY", Value: "clone"},
+		{Key: "PORTER_HOST", Value: opts.ServerURL},
+		{Key: "PORTER_PROJECT", Value: fmt.Sprintf("%d", opts.ProjectID)},
+		{Key: "PORTER_CLUSTER", Value: fmt.Sprintf("%d", opts.ClusterID)},
+		{Key: "PORTER_TOKEN", Value: fmt.Sprintf("$%s", getPreviewEnvSecretName(opts.ProjectID, opts.ClusterID, opts.InstanceName))},
+		{Key: "PORTER_GITLAB_INTEGRATION_ID", Value: fmt.Sprintf("%d", opts.GitlabIntegrationID)},
+		{Key: "PORTER_PULL_REQUEST_ID", Value: "$CI_MERGE_REQUEST_IID"},
+		{Key: "PORTER_BRANCH_FROM", Value: "$CI_MERGE_REQUEST_SOURCE_BRANCH_NAME"},
+		{Key: "PORTER_BRANCH_INTO", Value: "$CI_MERGE_REQUEST_TARGET_BRANCH_NAME"},
+		{Key: "PORTER_ACTION_ID", Value: "$CI_PIPELINE_ID"},
+		{Key: "PORTER_REPO_OWNER", Value: "$CI_PROJECT_NAMESPACE"},
+		{Key: "PORTER_REPO_NAME", Value: "$CI_PROJECT_NAME"},
+		{Key: "PORTER_PR_NAME", Value: "$CI_MERGE_REQUEST_TITLE"},
+		{Key: "PORTER_TAG", Value: "$CI_COMMIT_SHORT_SHA"},
+		{
+			Key: "PORTER_NAMESPACE",
+			Value: fmt.Sprintf(
+				"pr-$CI_MERGE_REQUEST_IID-%s", strings.ToLower(strings.ReplaceAll(opts.GitRepoName, "_", "-")),
+			),
+		},
+	}
+
+	res = append(res,
+		yaml.MapItem{
+			Key: "rules",
+			Value: []map[string]string{
+				{
+					"if": "$CI_PIPELINE_SOURCE == \"merge_request_event\"",
+				},
+			},
+		},
+	)
+
+	if instanceURL.Hostname() == "gitlab.com" || instanceURL.Hostname() == "www.gitlab.com" {
+		var envFlags []string
+
+		for _, envVar := range previewEnvVars {
+			envFlags = append(envFlags, fmt.Sprintf("-e %s", envVar))
+		}
+
+		res = append(res,
+			yaml.MapItem{
+				Key:   "image",
+				Value: "docker:latest",
+			},
+			yaml.MapItem{
+				Key: "services",
+				Value: []string{
+					"docker:dind",
+				},
+			},
+			yaml.MapItem{
+				Key: "script",
+				Value: []string{
+					fmt.Sprintf(
+						"docker run --rm --workdir=\"/app\" "+
+							"-v /var/run/docker.sock:/var/run/docker.sock "+
+							"-v $(pwd):/app %s "+
+							"public.ecr.aws/o1j4x7p4/porter-cli:latest "+
+							"apply -f porter.yaml",
+						strings.Join(envFlags, " "),
+					),
+				},
+			},
+			yaml.MapItem{
+				Key: "tags",
+				Value: []string{
+					"docker",
+				},
+			},
+		)
+	} else {
+		res = append(res,
+			yaml.MapItem{
+				Key: "image",
+				Value: map[string]interface{}{
+					"name": "public.ecr.aws/o1j4x7p4/porter-cli:latest",
+					"entrypoint": []string{
+						"",
+					},
+				},
+			},
+			yaml.MapItem{
+				Key: "script",
+				Value: []string{
+					"porter apply -f porter.yaml",
+				},
+			},
+			yaml.MapItem{
+				Key: "tags",
+				Value: []string{
+					"porter-runner",
+				},
+			},
+		)
+	}
+
+	res = append(res,
+		yaml.MapItem{
+			Key:   "stage",
+			Value: jobName,
+		},
+		yaml.MapItem{
+			Key:   "timeout",
+			Value: "30 minutes",
+		},
+		yaml.MapItem{
+			// only run a single preview job per merge request at a time
+			Key:   "resource_group",
+			Value: fmt.Sprintf("%s-$CI_MERGE_REQUEST_IID", jobName),
+		},
+		yaml.MapItem{
+			Key:   "variables",
+			Value: variables,
+		},
+	)
+
+	return res
+}
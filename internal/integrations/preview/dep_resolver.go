@@ -69,3 +69,17 @@ func (r *dependencyResolver) depResolve(name string) error {
 
 	return nil
 }
+
+// GetDependencyGraph validates the dependencies between the given resources and returns the
+// dependency graph, which maps the name of each resource to the names of the resources it
+// depends on
+func GetDependencyGraph(resources []*types.Resource) (map[string][]string, error) {
+	resolver := newDependencyResolver(resources)
+
+	err := resolver.Resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	return resolver.graph, nil
+}
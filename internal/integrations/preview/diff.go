@@ -0,0 +1,126 @@
+package preview
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+type ValuesDiffOp string
+
+const (
+	ValuesDiffOpAdd    ValuesDiffOp = "add"
+	ValuesDiffOpRemove ValuesDiffOp = "remove"
+	ValuesDiffOpChange ValuesDiffOp = "change"
+)
+
+// ValuesDiff is a single difference between two sets of Helm values, keyed by the
+// dot-separated path of the value
+type ValuesDiff struct {
+	Path string       `json:"path"`
+	Op   ValuesDiffOp `json:"op"`
+	From interface{}  `json:"from,omitempty"`
+	To   interface{}  `json:"to,omitempty"`
+}
+
+func (d *ValuesDiff) String() string {
+	switch d.Op {
+	case ValuesDiffOpAdd:
+		return fmt.Sprintf("+ %s: %s", d.Path, formatDiffValue(d.To))
+	case ValuesDiffOpRemove:
+		return fmt.Sprintf("- %s: %s", d.Path, formatDiffValue(d.From))
+	}
+
+	return fmt.Sprintf("~ %s: %s => %s", d.Path, formatDiffValue(d.From), formatDiffValue(d.To))
+}
+
+// DiffValues compares the currently deployed values against the desired values and returns
+// the list of differences, sorted by path. Maps are compared key-by-key while arrays and
+// scalars are compared as a whole, which mirrors the way CoalesceValues merges values.
+func DiffValues(current, desired map[string]interface{}) []*ValuesDiff {
+	var res []*ValuesDiff
+
+	diffMaps("", current, desired, &res)
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return res[i].Path < res[j].Path
+	})
+
+	return res
+}
+
+func diffMaps(prefix string, current, desired map[string]interface{}, res *[]*ValuesDiff) {
+	for key, currVal := range current {
+		path := joinDiffPath(prefix, key)
+
+		desiredVal, ok := desired[key]
+
+		if !ok {
+			*res = append(*res, &ValuesDiff{Path: path, Op: ValuesDiffOpRemove, From: currVal})
+			continue
+		}
+
+		currMap, currIsMap := toStringMap(currVal)
+		desiredMap, desiredIsMap := toStringMap(desiredVal)
+
+		if currIsMap && desiredIsMap {
+			diffMaps(path, currMap, desiredMap, res)
+		} else if !valuesEqual(currVal, desiredVal) {
+			*res = append(*res, &ValuesDiff{Path: path, Op: ValuesDiffOpChange, From: currVal, To: desiredVal})
+		}
+	}
+
+	for key, desiredVal := range desired {
+		if _, ok := current[key]; !ok {
+			*res = append(*res, &ValuesDiff{Path: joinDiffPath(prefix, key), Op: ValuesDiffOpAdd, To: desiredVal})
+		}
+	}
+}
+
+func joinDiffPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+
+	return prefix + "." + key
+}
+
+func toStringMap(val interface{}) (map[string]interface{}, bool) {
+	switch v := val.(type) {
+	case map[string]interface{}:
+		return v, true
+	case map[interface{}]interface{}:
+		res := make(map[string]interface{})
+
+		for key, inner := range v {
+			res[fmt.Sprintf("%v", key)] = inner
+		}
+
+		return res, true
+	}
+
+	return nil, false
+}
+
+// valuesEqual compares two values by their JSON representation, since values read from
+// the API and values read from porter.yaml do not share the same numeric types
+func valuesEqual(a, b interface{}) bool {
+	aBytes, aErr := json.Marshal(a)
+	bBytes, bErr := json.Marshal(b)
+
+	if aErr != nil || bErr != nil {
+		return reflect.DeepEqual(a, b)
+	}
+
+	return string(aBytes) == string(bBytes)
+}
+
+func formatDiffValue(val interface{}) string {
+	bytes, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprintf("%v", val)
+	}
+
+	return string(bytes)
+}
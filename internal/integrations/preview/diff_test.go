@@ -0,0 +1,171 @@
+package preview_test
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+)
+
+type diffValuesTest struct {
+	description string
+	current     map[string]interface{}
+	desired     map[string]interface{}
+	expected    []*preview.ValuesDiff
+}
+
+var diffValuesTests = []diffValuesTest{
+	{
+		description: "identical values",
+		current: map[string]interface{}{
+			"replicaCount": 1,
+			"image": map[string]interface{}{
+				"repository": "nginx",
+			},
+		},
+		desired: map[string]interface{}{
+			"replicaCount": 1,
+			"image": map[string]interface{}{
+				"repository": "nginx",
+			},
+		},
+		expected: nil,
+	},
+	{
+		description: "create from empty values",
+		current:     map[string]interface{}{},
+		desired: map[string]interface{}{
+			"replicaCount": 2,
+			"image": map[string]interface{}{
+				"tag": "latest",
+			},
+		},
+		expected: []*preview.ValuesDiff{
+			{Path: "image", Op: preview.ValuesDiffOpAdd, To: map[string]interface{}{"tag": "latest"}},
+			{Path: "replicaCount", Op: preview.ValuesDiffOpAdd, To: 2},
+		},
+	},
+	{
+		description: "nested add, remove and change",
+		current: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "nginx",
+				"tag":        "1.0",
+			},
+			"ingress": map[string]interface{}{
+				"enabled": true,
+			},
+		},
+		desired: map[string]interface{}{
+			"image": map[string]interface{}{
+				"repository": "nginx",
+				"tag":        "2.0",
+				"pullPolicy": "Always",
+			},
+		},
+		expected: []*preview.ValuesDiff{
+			{Path: "image.pullPolicy", Op: preview.ValuesDiffOpAdd, To: "Always"},
+			{Path: "image.tag", Op: preview.ValuesDiffOpChange, From: "1.0", To: "2.0"},
+			{Path: "ingress", Op: preview.ValuesDiffOpRemove, From: map[string]interface{}{"enabled": true}},
+		},
+	},
+	{
+		description: "numbers of different types are equal",
+		current: map[string]interface{}{
+			"replicaCount": float64(3),
+		},
+		desired: map[string]interface{}{
+			"replicaCount": 3,
+		},
+		expected: nil,
+	},
+	{
+		description: "arrays are compared as a whole",
+		current: map[string]interface{}{
+			"hosts": []interface{}{"a.example.com", "b.example.com"},
+		},
+		desired: map[string]interface{}{
+			"hosts": []interface{}{"a.example.com"},
+		},
+		expected: []*preview.ValuesDiff{
+			{
+				Path: "hosts",
+				Op:   preview.ValuesDiffOpChange,
+				From: []interface{}{"a.example.com", "b.example.com"},
+				To:   []interface{}{"a.example.com"},
+			},
+		},
+	},
+	{
+		description: "maps decoded from YAML are compared key-by-key",
+		current: map[string]interface{}{
+			"env": map[interface{}]interface{}{
+				"PORT": "80",
+			},
+		},
+		desired: map[string]interface{}{
+			"env": map[string]interface{}{
+				"PORT": "8080",
+			},
+		},
+		expected: []*preview.ValuesDiff{
+			{Path: "env.PORT", Op: preview.ValuesDiffOpChange, From: "80", To: "8080"},
+		},
+	},
+	{
+		description: "map replaced by a scalar",
+		current: map[string]interface{}{
+			"resources": map[string]interface{}{
+				"cpu": "100m",
+			},
+		},
+		desired: map[string]interface{}{
+			"resources": "none",
+		},
+		expected: []*preview.ValuesDiff{
+			{
+				Path: "resources",
+				Op:   preview.ValuesDiffOpChange,
+				From: map[string]interface{}{"cpu": "100m"},
+				To:   "none",
+			},
+		},
+	},
+}
+
+func TestDiffValues(t *testing.T) {
+	for _, test := range diffValuesTests {
+		res := preview.DiffValues(test.current, test.desired)
+
+		if diff := deep.Equal(test.expected, res); diff != nil {
+			t.Errorf("%s: incorrect diff", test.description)
+			t.Error(diff)
+		}
+	}
+}
+
+func TestValuesDiffString(t *testing.T) {
+	tests := []struct {
+		diff     *preview.ValuesDiff
+		expected string
+	}{
+		{
+			diff:     &preview.ValuesDiff{Path: "image.tag", Op: preview.ValuesDiffOpAdd, To: "latest"},
+			expected: `+ image.tag: "latest"`,
+		},
+		{
+			diff:     &preview.ValuesDiff{Path: "replicaCount", Op: preview.ValuesDiffOpRemove, From: 1},
+			expected: `- replicaCount: 1`,
+		},
+		{
+			diff:     &preview.ValuesDiff{Path: "ingress.enabled", Op: preview.ValuesDiffOpChange, From: false, To: true},
+			expected: `~ ingress.enabled: false => true`,
+		},
+	}
+
+	for _, test := range tests {
+		if res := test.diff.String(); res != test.expected {
+			t.Errorf("expected %s, got %s", test.expected, res)
+		}
+	}
+}
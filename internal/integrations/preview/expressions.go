@@ -0,0 +1,401 @@
+package preview
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+const (
+	expressionStartDelim = "${{"
+	expressionEndDelim   = "}}"
+
+	toIntFunc  = "toInt"
+	toBoolFunc = "toBool"
+)
+
+// ExpressionContext is the data that porter.yaml expressions are evaluated against
+type ExpressionContext struct {
+	// Env contains the PORTER_APPLY_ prefixed environment variables, without the prefix
+	Env map[string]string
+
+	// Git contains the "branch", "sha" and "short_sha" of the repository being applied
+	Git map[string]string
+
+	// Namespace is the namespace that the resource is applied to
+	Namespace string
+
+	// Outputs contains the outputs of the resources that the evaluated resource depends on
+	Outputs map[string]map[string]interface{}
+}
+
+func (c *ExpressionContext) data() map[string]interface{} {
+	return map[string]interface{}{
+		"env":       c.Env,
+		"git":       c.Git,
+		"namespace": c.Namespace,
+		"outputs":   c.Outputs,
+	}
+}
+
+// expressionFuncs returns the functions available in porter.yaml expressions: the hermetic
+// sprig functions, "output" to read a value from the output of another resource, "toInt" and
+// "toBool" to convert the result of an expression, and "porter" which supports the legacy
+// ${{ porter.env.FOO }} syntax
+func expressionFuncs(ctx *ExpressionContext) template.FuncMap {
+	funcs := sprig.HermeticTxtFuncMap()
+
+	funcs[toIntFunc] = toInt
+	funcs[toBoolFunc] = toBool
+
+	funcs["porter"] = func() map[string]interface{} {
+		env := make(map[string]string)
+
+		if ctx != nil && ctx.Env != nil {
+			env = ctx.Env
+		}
+
+		return map[string]interface{}{
+			"env": env,
+		}
+	}
+
+	funcs["output"] = func(resource, path string) (interface{}, error) {
+		if ctx == nil {
+			return nil, nil
+		}
+
+		output, ok := ctx.Outputs[resource]
+
+		if !ok {
+			return nil, fmt.Errorf("no output found for resource '%s': it must be listed in depends_on", resource)
+		}
+
+		var curr interface{} = output
+
+		for _, key := range strings.Split(path, ".") {
+			currMap, ok := curr.(map[string]interface{})
+
+			if !ok {
+				return nil, fmt.Errorf("path '%s' not found in the output of resource '%s'", path, resource)
+			}
+
+			if curr, ok = currMap[key]; !ok {
+				return nil, fmt.Errorf("path '%s' not found in the output of resource '%s'", path, resource)
+			}
+		}
+
+		return curr, nil
+	}
+
+	return funcs
+}
+
+func parseExpression(str string, ctx *ExpressionContext) (*template.Template, error) {
+	return template.New("expression").
+		Delims(expressionStartDelim, expressionEndDelim).
+		Option("missingkey=zero").
+		Funcs(expressionFuncs(ctx)).
+		Parse(str)
+}
+
+// HasExpression returns true if the given string contains a porter.yaml expression
+func HasExpression(str string) bool {
+	return strings.Contains(str, expressionStartDelim)
+}
+
+// EvaluateExpressions evaluates every porter.yaml expression of the form ${{ ... }} found in the
+// string values of the given config, and returns a copy of the config with the evaluated values.
+// Expressions use the Go template syntax with the sprig functions, for example:
+//
+//	${{ .git.branch | lower | trunc 20 }}
+//	${{ default "1" .env.REPLICAS }}
+//	${{ if eq .git.branch "main" }}prod${{ else }}preview${{ end }}
+//	${{ output "postgres" "service.hostname" }}
+//
+// The result of an expression is always a string, unless the string consists of a single
+// expression which ends with the "toInt" or "toBool" function:
+//
+//	${{ .env.REPLICAS | toInt }}
+//	${{ toBool .env.AUTOSCALING }}
+func EvaluateExpressions(config map[string]interface{}, ctx *ExpressionContext) (map[string]interface{}, error) {
+	if ctx == nil {
+		ctx = &ExpressionContext{}
+	}
+
+	res, err := evaluateValue(config, ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	resMap, _ := res.(map[string]interface{})
+
+	return resMap, nil
+}
+
+func evaluateValue(val interface{}, ctx *ExpressionContext, path string) (interface{}, error) {
+	switch v := val.(type) {
+	case string:
+		if !HasExpression(v) {
+			return v, nil
+		}
+
+		res, err := evaluateString(v, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error evaluating expression at '%s': %w", path, err)
+		}
+
+		return res, nil
+	case map[string]interface{}:
+		if v == nil {
+			return v, nil
+		}
+
+		res := make(map[string]interface{}, len(v))
+
+		for key, inner := range v {
+			evaluated, err := evaluateValue(inner, ctx, joinDiffPath(path, key))
+			if err != nil {
+				return nil, err
+			}
+
+			res[key] = evaluated
+		}
+
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, len(v))
+
+		for i, inner := range v {
+			evaluated, err := evaluateValue(inner, ctx, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+
+			res[i] = evaluated
+		}
+
+		return res, nil
+	}
+
+	return val, nil
+}
+
+func evaluateString(str string, ctx *ExpressionContext) (interface{}, error) {
+	tmpl, err := parseExpression(str, ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+
+	if err := tmpl.Execute(&buf, ctx.data()); err != nil {
+		return nil, err
+	}
+
+	res := buf.String()
+
+	switch getConversion(tmpl.Tree.Root) {
+	case toIntFunc:
+		return toInt(res)
+	case toBoolFunc:
+		return toBool(res)
+	}
+
+	return res, nil
+}
+
+// getConversion returns the name of the conversion function which ends the pipeline of the
+// template, if the template consists of a single expression surrounded by whitespace
+func getConversion(root *parse.ListNode) string {
+	var action *parse.ActionNode
+
+	for _, node := range root.Nodes {
+		switch n := node.(type) {
+		case *parse.TextNode:
+			if strings.TrimSpace(string(n.Text)) != "" {
+				return ""
+			}
+		case *parse.ActionNode:
+			if action != nil {
+				return ""
+			}
+
+			action = n
+		default:
+			return ""
+		}
+	}
+
+	if action == nil || action.Pipe == nil || len(action.Pipe.Cmds) == 0 {
+		return ""
+	}
+
+	cmd := action.Pipe.Cmds[len(action.Pipe.Cmds)-1]
+
+	if ident, ok := cmd.Args[0].(*parse.IdentifierNode); ok {
+		if ident.Ident == toIntFunc || ident.Ident == toBoolFunc {
+			return ident.Ident
+		}
+	}
+
+	return ""
+}
+
+func toInt(val interface{}) (int, error) {
+	switch v := val.(type) {
+	case int:
+		return v, nil
+	case int64:
+		return int(v), nil
+	case float64:
+		if v == float64(int(v)) {
+			return int(v), nil
+		}
+	case string:
+		res, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("cannot convert '%s' to an integer", v)
+		}
+
+		return res, nil
+	}
+
+	return 0, fmt.Errorf("cannot convert '%v' to an integer", val)
+}
+
+func toBool(val interface{}) (bool, error) {
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case string:
+		res, err := strconv.ParseBool(strings.TrimSpace(v))
+		if err != nil {
+			return false, fmt.Errorf("cannot convert '%s' to a boolean", v)
+		}
+
+		return res, nil
+	}
+
+	return false, fmt.Errorf("cannot convert '%v' to a boolean", val)
+}
+
+// ValidateExpressions checks the syntax of every porter.yaml expression found in the given value.
+// If dependencies is not nil, it also checks that every resource referenced by the "output"
+// function is one of the given dependencies.
+func ValidateExpressions(val interface{}, dependencies []string) []error {
+	var errs []error
+
+	validateExpressionValue(val, "", dependencies, &errs)
+
+	return errs
+}
+
+func validateExpressionValue(val interface{}, path string, dependencies []string, errs *[]error) {
+	switch v := val.(type) {
+	case string:
+		if !HasExpression(v) {
+			return
+		}
+
+		tmpl, err := parseExpression(v, nil)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("invalid expression at '%s': %w", path, err))
+			return
+		}
+
+		if dependencies == nil {
+			return
+		}
+
+		for _, ref := range getOutputReferences(tmpl.Tree.Root) {
+			found := false
+
+			for _, dep := range dependencies {
+				if dep == ref {
+					found = true
+					break
+				}
+			}
+
+			if !found {
+				*errs = append(*errs, fmt.Errorf("invalid expression at '%s': output of resource '%s' is used "+
+					"but '%s' is not listed in depends_on", path, ref, ref))
+			}
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+
+		for key := range v {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			validateExpressionValue(v[key], joinDiffPath(path, key), dependencies, errs)
+		}
+	case []interface{}:
+		for i, inner := range v {
+			validateExpressionValue(inner, fmt.Sprintf("%s[%d]", path, i), dependencies, errs)
+		}
+	}
+}
+
+// getOutputReferences returns the resource names passed as string literals to the "output" function
+func getOutputReferences(node parse.Node) []string {
+	var res []string
+
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return res
+		}
+
+		for _, inner := range n.Nodes {
+			res = append(res, getOutputReferences(inner)...)
+		}
+	case *parse.ActionNode:
+		res = append(res, getOutputReferences(n.Pipe)...)
+	case *parse.PipeNode:
+		if n == nil {
+			return res
+		}
+
+		for _, cmd := range n.Cmds {
+			res = append(res, getOutputReferences(cmd)...)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) > 1 {
+			if ident, ok := n.Args[0].(*parse.IdentifierNode); ok && ident.Ident == "output" {
+				if str, ok := n.Args[1].(*parse.StringNode); ok {
+					res = append(res, str.Text)
+				}
+			}
+		}
+
+		for _, arg := range n.Args {
+			res = append(res, getOutputReferences(arg)...)
+		}
+	case *parse.IfNode:
+		res = append(res, getOutputReferences(n.Pipe)...)
+		res = append(res, getOutputReferences(n.List)...)
+		res = append(res, getOutputReferences(n.ElseList)...)
+	case *parse.RangeNode:
+		res = append(res, getOutputReferences(n.Pipe)...)
+		res = append(res, getOutputReferences(n.List)...)
+		res = append(res, getOutputReferences(n.ElseList)...)
+	case *parse.WithNode:
+		res = append(res, getOutputReferences(n.Pipe)...)
+		res = append(res, getOutputReferences(n.List)...)
+		res = append(res, getOutputReferences(n.ElseList)...)
+	}
+
+	return res
+}
@@ -0,0 +1,256 @@
+package preview_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/internal/integrations/preview"
+)
+
+var testExpressionContext = &preview.ExpressionContext{
+	Env: map[string]string{
+		"REPLICAS":    "3",
+		"AUTOSCALING": "true",
+		"DOMAIN":      "example.com",
+	},
+	Git: map[string]string{
+		"branch":    "Feature/Login",
+		"sha":       "0123456789abcdef",
+		"short_sha": "0123456",
+	},
+	Namespace: "pr-1",
+	Outputs: map[string]map[string]interface{}{
+		"postgres": {
+			"service": map[string]interface{}{
+				"hostname": "postgres.pr-1.svc.cluster.local",
+			},
+		},
+	},
+}
+
+type evaluateExpressionTest struct {
+	description string
+	value       string
+	expected    interface{}
+}
+
+var evaluateExpressionTests = []evaluateExpressionTest{
+	{
+		description: "string without expression",
+		value:       "nginx",
+		expected:    "nginx",
+	},
+	{
+		description: "field access",
+		value:       "${{ .namespace }}",
+		expected:    "pr-1",
+	},
+	{
+		description: "pipeline with sprig functions",
+		value:       "${{ .git.branch | lower | replace \"/\" \"-\" }}",
+		expected:    "feature-login",
+	},
+	{
+		description: "expression embedded in a string",
+		value:       "https://${{ .git.short_sha }}.${{ .env.DOMAIN }}",
+		expected:    "https://0123456.example.com",
+	},
+	{
+		description: "conditional",
+		value:       "${{ if eq .git.branch \"main\" }}prod${{ else }}preview${{ end }}",
+		expected:    "preview",
+	},
+	{
+		description: "default for missing value",
+		value:       "${{ default \"1\" .env.MISSING }}",
+		expected:    "1",
+	},
+	{
+		description: "output of a dependency",
+		value:       "${{ output \"postgres\" \"service.hostname\" }}",
+		expected:    "postgres.pr-1.svc.cluster.local",
+	},
+	{
+		description: "single expression which looks like a number stays a string",
+		value:       "${{ .env.REPLICAS }}",
+		expected:    "3",
+	},
+	{
+		description: "single expression which looks like a boolean stays a string",
+		value:       "${{ .env.AUTOSCALING }}",
+		expected:    "true",
+	},
+	{
+		description: "explicit integer conversion in a pipeline",
+		value:       "${{ .env.REPLICAS | toInt }}",
+		expected:    3,
+	},
+	{
+		description: "explicit integer conversion surrounded by whitespace",
+		value:       "  ${{ toInt .env.REPLICAS }}  ",
+		expected:    3,
+	},
+	{
+		description: "explicit boolean conversion",
+		value:       "${{ toBool .env.AUTOSCALING }}",
+		expected:    true,
+	},
+	{
+		description: "conversion inside a larger string stays a string",
+		value:       "replicas-${{ .env.REPLICAS | toInt }}",
+		expected:    "replicas-3",
+	},
+	{
+		description: "legacy porter.env syntax",
+		value:       "${{ porter.env.DOMAIN }}",
+		expected:    "example.com",
+	},
+}
+
+func TestEvaluateExpressions(t *testing.T) {
+	for _, test := range evaluateExpressionTests {
+		res, err := preview.EvaluateExpressions(map[string]interface{}{
+			"value": test.value,
+		}, testExpressionContext)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.description, err)
+			continue
+		}
+
+		if diff := deep.Equal(test.expected, res["value"]); diff != nil {
+			t.Errorf("%s: incorrect result", test.description)
+			t.Error(diff)
+		}
+	}
+}
+
+func TestEvaluateExpressionsNested(t *testing.T) {
+	config := map[string]interface{}{
+		"replicaCount": 1,
+		"ingress": map[string]interface{}{
+			"hosts": []interface{}{"${{ .namespace }}.${{ .env.DOMAIN }}"},
+		},
+	}
+
+	res, err := preview.EvaluateExpressions(config, testExpressionContext)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expected := map[string]interface{}{
+		"replicaCount": 1,
+		"ingress": map[string]interface{}{
+			"hosts": []interface{}{"pr-1.example.com"},
+		},
+	}
+
+	if diff := deep.Equal(expected, res); diff != nil {
+		t.Error(diff)
+	}
+
+	// the config of the resource must not be modified
+	if config["ingress"].(map[string]interface{})["hosts"].([]interface{})[0] != "${{ .namespace }}.${{ .env.DOMAIN }}" {
+		t.Errorf("expected the original config to be left unchanged")
+	}
+}
+
+func TestEvaluateExpressionsErrors(t *testing.T) {
+	tests := []struct {
+		description string
+		value       interface{}
+		expErr      string
+	}{
+		{
+			description: "unterminated expression",
+			value:       "${{ .namespace ",
+			expErr:      "error evaluating expression at 'value'",
+		},
+		{
+			description: "unknown function",
+			value:       "${{ env \"HOME\" }}",
+			expErr:      "function \"env\" not defined",
+		},
+		{
+			description: "output of a resource which is not a dependency",
+			value:       "${{ output \"redis\" \"host\" }}",
+			expErr:      "no output found for resource 'redis'",
+		},
+		{
+			description: "missing output path",
+			value:       "${{ output \"postgres\" \"service.port\" }}",
+			expErr:      "path 'service.port' not found",
+		},
+		{
+			description: "invalid integer conversion",
+			value:       "${{ .env.DOMAIN | toInt }}",
+			expErr:      "cannot convert 'example.com' to an integer",
+		},
+		{
+			description: "invalid boolean conversion",
+			value:       "${{ toBool .env.REPLICAS }}",
+			expErr:      "cannot convert '3' to a boolean",
+		},
+		{
+			description: "path of nested error",
+			value:       []interface{}{"ok", "${{ .namespace | toBool }}"},
+			expErr:      "at 'value[1]'",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := preview.EvaluateExpressions(map[string]interface{}{
+			"value": test.value,
+		}, testExpressionContext)
+
+		if err == nil {
+			t.Errorf("%s: expected error", test.description)
+		} else if !strings.Contains(err.Error(), test.expErr) {
+			t.Errorf("%s: expected error containing %q, got %q", test.description, test.expErr, err.Error())
+		}
+	}
+}
+
+func TestValidateExpressions(t *testing.T) {
+	config := map[string]interface{}{
+		"image": "${{ .git.sha }}",
+		"env": map[string]interface{}{
+			"DB_HOST":  "${{ output \"postgres\" \"service.hostname\" }}",
+			"CACHE":    "${{ output \"redis\" \"host\" }}",
+			"BROKEN":   "${{ if .git.branch }}",
+			"UNKNOWN":  "${{ nope }}",
+			"LEGACY":   "${{ porter.env.DOMAIN }}",
+			"REPLICAS": "${{ .env.REPLICAS | toInt }}",
+		},
+	}
+
+	errs := preview.ValidateExpressions(config, []string{"postgres"})
+
+	var msgs []string
+
+	for _, err := range errs {
+		msgs = append(msgs, err.Error())
+	}
+
+	if len(msgs) != 3 {
+		t.Fatalf("expected 3 errors, got %d: %v", len(msgs), msgs)
+	}
+
+	// errors are sorted by the path of the value
+	expPrefixes := []string{
+		"invalid expression at 'env.BROKEN'",
+		"invalid expression at 'env.CACHE': output of resource 'redis' is used but 'redis' is not listed in depends_on",
+		"invalid expression at 'env.UNKNOWN'",
+	}
+
+	for i, prefix := range expPrefixes {
+		if !strings.HasPrefix(msgs[i], prefix) {
+			t.Errorf("expected error starting with %q, got %q", prefix, msgs[i])
+		}
+	}
+
+	// dependencies are not checked when they are not known
+	if errs := preview.ValidateExpressions(config["env"].(map[string]interface{})["CACHE"], nil); len(errs) != 0 {
+		t.Errorf("expected no errors without dependencies, got %v", errs)
+	}
+}
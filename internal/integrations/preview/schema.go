@@ -0,0 +1,80 @@
+package preview
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+)
+
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+const schemaID = "https://raw.githubusercontent.com/porter-dev/porter/master/internal/integrations/preview/schemas/porter.schema.json"
+
+// SchemaVersions are the porter.yaml versions for which a JSON Schema is available
+var SchemaVersions = []string{"v1", "v2beta1"}
+
+// GetSchema returns the JSON Schema of the given porter.yaml version. If the version is empty,
+// the returned schema accepts every porter.yaml version and picks the schema to validate
+// against using the version field of the file.
+func GetSchema(version string) ([]byte, error) {
+	if version != "" {
+		schema, err := schemaFS.ReadFile(fmt.Sprintf("schemas/porter.%s.schema.json", version))
+		if err != nil {
+			return nil, fmt.Errorf("no schema found for porter.yaml version '%s'", version)
+		}
+
+		return schema, nil
+	}
+
+	definitions := make(map[string]interface{})
+
+	for _, version := range SchemaVersions {
+		schemaBytes, err := schemaFS.ReadFile(fmt.Sprintf("schemas/porter.%s.schema.json", version))
+		if err != nil {
+			return nil, fmt.Errorf("error reading schema for porter.yaml version '%s': %w", version, err)
+		}
+
+		schema := make(map[string]interface{})
+
+		if err := json.Unmarshal(schemaBytes, &schema); err != nil {
+			return nil, fmt.Errorf("error parsing schema for porter.yaml version '%s': %w", version, err)
+		}
+
+		// the nested schemas keep their $id, so that their local references are resolved
+		// relative to themselves
+		delete(schema, "$schema")
+
+		definitions[version] = schema
+	}
+
+	combined := map[string]interface{}{
+		"$schema":     "http://json-schema.org/draft-07/schema#",
+		"$id":         schemaID,
+		"title":       "porter.yaml",
+		"description": "A porter.yaml file, applied with `porter apply`.",
+		"type":        "object",
+		"required":    []string{"version"},
+		"properties": map[string]interface{}{
+			"version": map[string]interface{}{
+				"enum": SchemaVersions,
+			},
+		},
+		"if": map[string]interface{}{
+			"properties": map[string]interface{}{
+				"version": map[string]interface{}{
+					"const": "v2beta1",
+				},
+			},
+		},
+		"then": map[string]interface{}{
+			"$ref": "#/definitions/v2beta1",
+		},
+		"else": map[string]interface{}{
+			"$ref": "#/definitions/v1",
+		},
+		"definitions": definitions,
+	}
+
+	return json.MarshalIndent(combined, "", "  ")
+}
@@ -0,0 +1,202 @@
+package preview_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/integrations/preview"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"sigs.k8s.io/yaml"
+)
+
+const validV1PorterYAML = `
+version: v1
+resources:
+- name: web
+  source:
+    name: web
+  config:
+    values:
+      replicaCount: 2
+      container:
+        port: 8080
+        env:
+          normal:
+            LOG_LEVEL: debug
+      ingress:
+        enabled: true
+        hosts:
+        - example.com
+- name: cron
+  source:
+    name: job
+  config:
+    values:
+      schedule:
+        enabled: true
+        value: "*/5 * * * *"
+`
+
+const validV2Beta1PorterYAML = `
+version: v2beta1
+builds:
+- name: api
+  method: docker
+  dockerfile: ./Dockerfile
+  use_cache: true
+apps:
+- name: api
+  build_ref: api
+  helm_chart:
+    name: web
+  helm_values:
+    container:
+      port: 8080
+    autoscaling:
+      enabled: true
+      minReplicas: 1
+      maxReplicas: 5
+    resources:
+      requests:
+        cpu: 100m
+        memory: 256Mi
+- name: worker
+  build_ref: api
+  helm_chart:
+    name: worker
+  helm_values:
+    replicaCount: "{ .variables.replicas }"
+`
+
+// compileSchema compiles the schema of the given porter.yaml version without loading any remote
+// references, so that the embedded schemas can be used by editors and offline
+func compileSchema(t *testing.T, version string) *jsonschema.Schema {
+	schemaBytes, err := preview.GetSchema(version)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	schemaDoc := make(map[string]interface{})
+
+	if err := json.Unmarshal(schemaBytes, &schemaDoc); err != nil {
+		t.Fatalf("error parsing schema for version '%s': %v", version, err)
+	}
+
+	id, _ := schemaDoc["$id"].(string)
+
+	compiler := jsonschema.NewCompiler()
+	compiler.LoadURL = func(s string) (io.ReadCloser, error) {
+		return nil, fmt.Errorf("remote reference %s is not allowed", s)
+	}
+
+	if err := compiler.AddResource(id, bytes.NewReader(schemaBytes)); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	schema, err := compiler.Compile(id)
+	if err != nil {
+		t.Fatalf("error compiling schema for version '%s': %v", version, err)
+	}
+
+	return schema
+}
+
+func validatePorterYAML(t *testing.T, schema *jsonschema.Schema, raw string) error {
+	jsonBytes, err := yaml.YAMLToJSON([]byte(raw))
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	var doc interface{}
+
+	if err := json.Unmarshal(jsonBytes, &doc); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return schema.Validate(doc)
+}
+
+func TestSchemasCompile(t *testing.T) {
+	for _, version := range append([]string{""}, preview.SchemaVersions...) {
+		compileSchema(t, version)
+	}
+}
+
+func TestSchemaValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		version string
+		raw     string
+		wantErr bool
+	}{
+		{
+			name:    "valid v1 file",
+			version: "v1",
+			raw:     validV1PorterYAML,
+		},
+		{
+			name:    "valid v2beta1 file",
+			version: "v2beta1",
+			raw:     validV2Beta1PorterYAML,
+		},
+		{
+			name: "combined schema picks the v1 schema",
+			raw:  validV1PorterYAML,
+		},
+		{
+			name: "combined schema picks the v2beta1 schema",
+			raw:  validV2Beta1PorterYAML,
+		},
+		{
+			name:    "web values with an invalid port",
+			version: "v2beta1",
+			raw: `
+version: v2beta1
+apps:
+- name: api
+  helm_chart:
+    name: web
+  helm_values:
+    container:
+      port: [8080]
+`,
+			wantErr: true,
+		},
+		{
+			name:    "job values with an invalid schedule",
+			version: "v1",
+			raw: `
+version: v1
+resources:
+- name: cron
+  source:
+    name: job
+  config:
+    values:
+      schedule: "*/5 * * * *"
+`,
+			wantErr: true,
+		},
+		{
+			name:    "unknown version",
+			version: "",
+			raw:     "version: v3\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validatePorterYAML(t, compileSchema(t, tt.version), tt.raw)
+
+			if tt.wantErr && err == nil {
+				t.Errorf("expected a validation error")
+			} else if !tt.wantErr && err != nil {
+				t.Errorf("expected no validation error, got %v", err)
+			}
+		})
+	}
+}
@@ -53,7 +53,9 @@ type PushDriverConfig struct {
 
 type BuildDriverConfig struct {
 	Build struct {
-		UsePackCache bool `mapstructure:"use_pack_cache"`
+		UsePackCache bool   `mapstructure:"use_pack_cache"`
+		UseCache     bool   `mapstructure:"use_cache"`
+		CacheImage   string `mapstructure:"cache_image"`
 		Method       string
 		Context      string
 		Dockerfile   string
@@ -3,10 +3,12 @@ package preview
 import (
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/porter-dev/switchboard/pkg/parser"
 	"github.com/porter-dev/switchboard/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation"
+	"sigs.k8s.io/yaml"
 )
 
 var (
@@ -61,6 +63,10 @@ func Validate(contents string) []error {
 			errors = append(errors, fmt.Errorf("%s", str))
 		}
 
+		for _, exprErr := range ValidateExpressions(res.Config, append([]string{}, res.DependsOn...)) {
+			errors = append(errors, fmt.Errorf("for resource '%s': %w", res.Name, exprErr))
+		}
+
 		if validator, ok := driverValidators[res.Driver]; ok {
 			if err := validator(res); err != nil {
 				errors = append(errors, err)
@@ -72,3 +78,48 @@ func Validate(contents string) []error {
 
 	return errors
 }
+
+// ValidateV2Beta1 validates the expressions in a porter.yaml file of version v2beta1
+func ValidateV2Beta1(contents string) []error {
+	var errors []error
+
+	parsed := make(map[string]interface{})
+
+	if err := yaml.Unmarshal([]byte(contents), &parsed); err != nil {
+		errors = append(errors, fmt.Errorf("error parsing porter.yaml: %w", err))
+		return errors
+	}
+
+	for _, section := range []string{"apps", "addons"} {
+		resources, _ := parsed[section].([]interface{})
+
+		for _, resInter := range resources {
+			res, ok := resInter.(map[string]interface{})
+
+			if !ok {
+				continue
+			}
+
+			name, _ := res["name"].(string)
+			dependsOn := []string{}
+
+			if deps, ok := res["depends_on"].([]interface{}); ok {
+				for _, dep := range deps {
+					if depStr, ok := dep.(string); ok {
+						dependsOn = append(dependsOn, depStr)
+					}
+				}
+			}
+
+			for _, exprErr := range ValidateExpressions(res["helm_values"], dependsOn) {
+				errors = append(errors, fmt.Errorf("for %s '%s': %w", strings.TrimSuffix(section, "s"), name, exprErr))
+			}
+		}
+	}
+
+	for _, exprErr := range ValidateExpressions(parsed["builds"], nil) {
+		errors = append(errors, fmt.Errorf("for builds: %w", exprErr))
+	}
+
+	return errors
+}
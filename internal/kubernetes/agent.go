@@ -705,6 +705,137 @@ func (a *Agent) DeleteNamespace(name string) error {
 	)
 }
 
+// SleepReplicasAnnotation stores the number of replicas that a workload had before its namespace
+// was put to sleep
+const SleepReplicasAnnotation = "porter.run/sleep-replicas"
+
+// SleepNamespace scales every deployment and statefulset in the namespace to zero replicas,
+// storing the previous number of replicas so that they can be restored by WakeNamespace
+func (a *Agent) SleepNamespace(namespace string) error {
+	depls, err := a.Clientset.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, depl := range depls.Items {
+		if depl.Spec.Replicas == nil || *depl.Spec.Replicas == 0 {
+			continue
+		}
+
+		if depl.Annotations == nil {
+			depl.Annotations = make(map[string]string)
+		}
+
+		depl.Annotations[SleepReplicasAnnotation] = strconv.Itoa(int(*depl.Spec.Replicas))
+		depl.Spec.Replicas = new(int32)
+
+		_, err := a.Clientset.AppsV1().Deployments(namespace).Update(context.Background(), &depl, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("error scaling down deployment %s: %w", depl.Name, err)
+		}
+	}
+
+	statefulSets, err := a.Clientset.AppsV1().StatefulSets(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, ss := range statefulSets.Items {
+		if ss.Spec.Replicas == nil || *ss.Spec.Replicas == 0 {
+			continue
+		}
+
+		if ss.Annotations == nil {
+			ss.Annotations = make(map[string]string)
+		}
+
+		ss.Annotations[SleepReplicasAnnotation] = strconv.Itoa(int(*ss.Spec.Replicas))
+		ss.Spec.Replicas = new(int32)
+
+		_, err := a.Clientset.AppsV1().StatefulSets(namespace).Update(context.Background(), &ss, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("error scaling down statefulset %s: %w", ss.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// WakeNamespace restores the number of replicas of every deployment and statefulset in the
+// namespace which was scaled to zero by SleepNamespace
+func (a *Agent) WakeNamespace(namespace string) error {
+	depls, err := a.Clientset.AppsV1().Deployments(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, depl := range depls.Items {
+		replicas, err := getSleepReplicas(depl.Annotations)
+		if err != nil {
+			return fmt.Errorf("error waking up deployment %s: %w", depl.Name, err)
+		} else if replicas == nil {
+			continue
+		}
+
+		// the replicas may have been set since the namespace was put to sleep, for example by
+		// a new deploy, in which case they are left untouched
+		if depl.Spec.Replicas == nil || *depl.Spec.Replicas == 0 {
+			depl.Spec.Replicas = replicas
+		}
+
+		delete(depl.Annotations, SleepReplicasAnnotation)
+
+		_, err = a.Clientset.AppsV1().Deployments(namespace).Update(context.Background(), &depl, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("error scaling up deployment %s: %w", depl.Name, err)
+		}
+	}
+
+	statefulSets, err := a.Clientset.AppsV1().StatefulSets(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return err
+	}
+
+	for _, ss := range statefulSets.Items {
+		replicas, err := getSleepReplicas(ss.Annotations)
+		if err != nil {
+			return fmt.Errorf("error waking up statefulset %s: %w", ss.Name, err)
+		} else if replicas == nil {
+			continue
+		}
+
+		if ss.Spec.Replicas == nil || *ss.Spec.Replicas == 0 {
+			ss.Spec.Replicas = replicas
+		}
+
+		delete(ss.Annotations, SleepReplicasAnnotation)
+
+		_, err = a.Clientset.AppsV1().StatefulSets(namespace).Update(context.Background(), &ss, metav1.UpdateOptions{})
+		if err != nil {
+			return fmt.Errorf("error scaling up statefulset %s: %w", ss.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func getSleepReplicas(annotations map[string]string) (*int32, error) {
+	replicasStr, ok := annotations[SleepReplicasAnnotation]
+
+	if !ok {
+		return nil, nil
+	}
+
+	replicas, err := strconv.ParseInt(replicasStr, 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for annotation %s: %w", SleepReplicasAnnotation, err)
+	}
+
+	res := int32(replicas)
+
+	return &res, nil
+}
+
 func (a *Agent) GetPorterAgent() (*appsv1.Deployment, error) {
 	depl, err := a.Clientset.AppsV1().Deployments("porter-agent-system").Get(
 		context.TODO(),
@@ -1,6 +1,7 @@
 package kubernetes_test
 
 import (
+	"context"
 	"testing"
 
 	"github.com/porter-dev/porter/internal/kubernetes"
@@ -10,6 +11,7 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 
+	appsv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -62,3 +64,69 @@ func TestOutOfClusterConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestSleepAndWakeNamespace(t *testing.T) {
+	replicas := int32(3)
+
+	k8sAgent := newAgentFixture(t, &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: "pr-1",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	}, &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "postgres",
+			Namespace: "pr-1",
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas: &replicas,
+		},
+	})
+
+	if err := k8sAgent.SleepNamespace("pr-1"); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	depl, err := k8sAgent.Clientset.AppsV1().Deployments("pr-1").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if *depl.Spec.Replicas != 0 {
+		t.Errorf("deployment replicas do not match after sleep: expected 0, got %d\n", *depl.Spec.Replicas)
+	}
+
+	if depl.Annotations[kubernetes.SleepReplicasAnnotation] != "3" {
+		t.Errorf("sleep replicas annotation does not match: expected 3, got %s\n",
+			depl.Annotations[kubernetes.SleepReplicasAnnotation])
+	}
+
+	if err := k8sAgent.WakeNamespace("pr-1"); err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	depl, err = k8sAgent.Clientset.AppsV1().Deployments("pr-1").Get(context.Background(), "web", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if *depl.Spec.Replicas != 3 {
+		t.Errorf("deployment replicas do not match after wake: expected 3, got %d\n", *depl.Spec.Replicas)
+	}
+
+	if _, ok := depl.Annotations[kubernetes.SleepReplicasAnnotation]; ok {
+		t.Errorf("sleep replicas annotation was not removed after wake\n")
+	}
+
+	ss, err := k8sAgent.Clientset.AppsV1().StatefulSets("pr-1").Get(context.Background(), "postgres", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+
+	if *ss.Spec.Replicas != 3 {
+		t.Errorf("statefulset replicas do not match after wake: expected 3, got %d\n", *ss.Spec.Replicas)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/porter-dev/porter/api/types"
+	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -22,6 +23,15 @@ type APIToken struct {
 
 	// SecretKey is hashed like a password before storage
 	SecretKey []byte
+
+	// PreviousSecretKey is the hashed secret key before the last rotation, which remains
+	// valid until PreviousSecretExpiry so that clients can switch to the new secret
+	PreviousSecretKey    []byte
+	PreviousSecretExpiry *time.Time
+
+	LastUsedAt        *time.Time
+	LastUsedIP        string
+	LastUsedUserAgent string
 }
 
 func (p *APIToken) IsExpired() bool {
@@ -29,6 +39,20 @@ func (p *APIToken) IsExpired() bool {
 	return timeLeft < 0
 }
 
+// IsValidSecret returns true if the secret matches the secret key of the token, or the
+// previous secret key if the token was rotated and the overlap window has not passed yet
+func (p *APIToken) IsValidSecret(secret string) bool {
+	if bcrypt.CompareHashAndPassword(p.SecretKey, []byte(secret)) == nil {
+		return true
+	}
+
+	if len(p.PreviousSecretKey) == 0 || p.PreviousSecretExpiry == nil || p.PreviousSecretExpiry.Before(time.Now()) {
+		return false
+	}
+
+	return bcrypt.CompareHashAndPassword(p.PreviousSecretKey, []byte(secret)) == nil
+}
+
 func (p *APIToken) ToAPITokenMetaType() *types.APITokenMeta {
 	return &types.APITokenMeta{
 		ID:         p.UniqueID,
@@ -37,6 +61,13 @@ func (p *APIToken) ToAPITokenMetaType() *types.APITokenMeta {
 		PolicyName: p.PolicyName,
 		PolicyUID:  p.PolicyUID,
 		Name:       p.Name,
+		Revoked:    p.Revoked,
+
+		LastUsedAt:        p.LastUsedAt,
+		LastUsedIP:        p.LastUsedIP,
+		LastUsedUserAgent: p.LastUsedUserAgent,
+
+		PreviousSecretExpiresAt: p.PreviousSecretExpiry,
 	}
 }
 
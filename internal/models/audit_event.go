@@ -0,0 +1,111 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// AuditEvent is a record of a mutating API call in a project
+type AuditEvent struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"index"`
+
+	// ActorKind is either "user" or "api_token"
+	ActorKind  string
+	UserID     uint
+	ActorName  string
+	APITokenID string
+	ClientIP   string
+
+	ClusterID uint
+	Namespace string
+
+	Verb     string
+	Method   string
+	Endpoint string
+	Path     string
+
+	// ResourceScope and Resource identify the most specific resource which the call targets,
+	// such as the release in a release-scoped endpoint
+	ResourceScope string
+	Resource      string
+
+	// RequestSummary is a summary of the top-level fields of the request body, which does not
+	// contain nested values or fields which may hold secrets
+	RequestSummary string
+
+	StatusCode int
+	Outcome    string
+}
+
+func (a *AuditEvent) ToAuditEventType() *types.AuditEvent {
+	return &types.AuditEvent{
+		ID:        a.ID,
+		CreatedAt: a.CreatedAt,
+		ProjectID: a.ProjectID,
+		Actor: &types.AuditActor{
+			Kind:       types.AuditActorKind(a.ActorKind),
+			UserID:     a.UserID,
+			Name:       a.ActorName,
+			APITokenID: a.APITokenID,
+			ClientIP:   a.ClientIP,
+		},
+		ClusterID:      a.ClusterID,
+		Namespace:      a.Namespace,
+		Verb:           types.APIVerb(a.Verb),
+		Method:         a.Method,
+		Endpoint:       a.Endpoint,
+		Path:           a.Path,
+		ResourceScope:  types.PermissionScope(a.ResourceScope),
+		Resource:       a.Resource,
+		RequestSummary: a.RequestSummary,
+		StatusCode:     a.StatusCode,
+		Outcome:        types.AuditOutcome(a.Outcome),
+	}
+}
+
+// AuditSink is an external destination which receives the audit events of a project. Events
+// are delivered in order of their ID, so the sink only stores the ID of the last delivered event.
+type AuditSink struct {
+	gorm.Model
+
+	ProjectID uint
+
+	Name string
+	Kind string
+
+	// LastEventID is the ID of the last audit event which was delivered to the sink
+	LastEventID    uint
+	LastExportedAt *time.Time
+
+	// FailureCount is the number of failed exports since the last successful export, which is
+	// used to back off from sinks which are down
+	FailureCount  uint
+	NextAttemptAt *time.Time
+	LastError     string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// Config is the JSON-encoded types.AuditSinkConfig, which contains the credentials of the sink
+	Config []byte
+}
+
+func (s *AuditSink) ToAuditSinkType() *types.AuditSink {
+	return &types.AuditSink{
+		ID:             s.ID,
+		CreatedAt:      s.CreatedAt,
+		ProjectID:      s.ProjectID,
+		Name:           s.Name,
+		Kind:           types.AuditSinkKind(s.Kind),
+		LastEventID:    s.LastEventID,
+		LastExportedAt: s.LastExportedAt,
+		FailureCount:   s.FailureCount,
+		NextAttemptAt:  s.NextAttemptAt,
+		LastError:      s.LastError,
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"strings"
+	"time"
 
 	"github.com/porter-dev/porter/api/types"
 	"gorm.io/gorm"
@@ -27,11 +28,37 @@ type Environment struct {
 	NamespaceAnnotations []byte
 	GitDeployBranches    string
 
+	// GithubChecksEnabled reports the status of deployments as GitHub check runs, which can be
+	// required by branch protection rules
+	GithubChecksEnabled bool
+
+	// DeploymentTTLHours is the number of hours after the last push after which a deployment is
+	// deleted. If 0, the TTL configured for the whole instance is used.
+	DeploymentTTLHours uint
+
+	// IdleSleepHours is the number of hours after the last push after which all workloads in
+	// the namespace of a deployment are scaled to zero. If 0, deployments are never put to sleep.
+	IdleSleepHours uint
+
 	// WebhookID uniquely identifies the environment when other fields (project, cluster)
 	// aren't present
 	WebhookID string `gorm:"unique"`
 
 	GithubWebhookID int64
+
+	// GitlabIntegrationID is set for environments of GitLab repositories, in which case
+	// GitInstallationID is not set
+	GitlabIntegrationID uint
+
+	// GitlabUserID is the user whose GitLab OAuth token is used to call the GitLab API
+	GitlabUserID uint
+
+	GitlabWebhookID int
+}
+
+// IsGitlab returns true if the environment is linked to a GitLab repository
+func (e *Environment) IsGitlab() bool {
+	return e.GitlabIntegrationID != 0
 }
 
 func getGitRepoBranches(branches string) []string {
@@ -54,18 +81,23 @@ func getGitRepoBranches(branches string) []string {
 
 func (e *Environment) ToEnvironmentType() *types.Environment {
 	env := &types.Environment{
-		ID:                e.Model.ID,
-		ProjectID:         e.ProjectID,
-		ClusterID:         e.ClusterID,
-		GitInstallationID: e.GitInstallationID,
-		GitRepoOwner:      e.GitRepoOwner,
-		GitRepoName:       e.GitRepoName,
+		ID:                  e.Model.ID,
+		ProjectID:           e.ProjectID,
+		ClusterID:           e.ClusterID,
+		GitInstallationID:   e.GitInstallationID,
+		GitlabIntegrationID: e.GitlabIntegrationID,
+		GitRepoOwner:        e.GitRepoOwner,
+		GitRepoName:         e.GitRepoName,
 
 		NewCommentsDisabled: e.NewCommentsDisabled,
+		GithubChecksEnabled: e.GithubChecksEnabled,
 		NamespaceLabels:     make(map[string]string),
 
 		Name: e.Name,
 		Mode: e.Mode,
+
+		DeploymentTTLHours: e.DeploymentTTLHours,
+		IdleSleepHours:     e.IdleSleepHours,
 	}
 
 	branches := getGitRepoBranches(e.GitRepoBranches)
@@ -110,6 +142,8 @@ type Deployment struct {
 	PullRequestID  uint
 	GHDeploymentID int64
 	GHPRCommentID  int64
+	GHCheckRunID   int64
+	GitlabMRNoteID int
 	PRName         string
 	RepoName       string
 	RepoOwner      string
@@ -117,11 +151,19 @@ type Deployment struct {
 	PRBranchFrom   string
 	PRBranchInto   string
 	LastErrors     string
+
+	// LastPushedAt is the time of the last push to the branch of the deployment
+	LastPushedAt time.Time
+
+	// IsSleeping is set when the workloads of the deployment have been scaled to zero
+	// because the deployment was idle
+	IsSleeping bool
 }
 
 func (d *Deployment) ToDeploymentType() *types.Deployment {
 	ghMetadata := &types.GitHubMetadata{
 		DeploymentID: d.GHDeploymentID,
+		CheckRunID:   d.GHCheckRunID,
 		PRName:       d.PRName,
 		RepoName:     d.RepoName,
 		RepoOwner:    d.RepoOwner,
@@ -141,9 +183,21 @@ func (d *Deployment) ToDeploymentType() *types.Deployment {
 		PullRequestID:  d.PullRequestID,
 		GitHubMetadata: ghMetadata,
 		LastErrors:     d.LastErrors,
+		LastPushedAt:   d.LastPushedAt,
+		IsSleeping:     d.IsSleeping,
 	}
 }
 
+// LastActiveAt returns the time of the last push to the deployment. Deployments created before
+// pushes were tracked fall back to the time they were last updated.
+func (d *Deployment) LastActiveAt() time.Time {
+	if d.LastPushedAt.IsZero() {
+		return d.UpdatedAt
+	}
+
+	return d.LastPushedAt
+}
+
 func (d *Deployment) IsBranchDeploy() bool {
 	return d.PullRequestID == 0 && d.PRBranchFrom != "" && d.PRBranchInto != "" && d.PRBranchFrom == d.PRBranchInto
 }
@@ -0,0 +1,36 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// DiscordIntegration is an incoming webhook notifier to a specific channel in a Discord server.
+type DiscordIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint `json:"user_id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// The name of the Discord channel that the webhook posts to
+	Channel string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The webhook to call
+	Webhook []byte
+}
+
+func (i *DiscordIntegration) ToDiscordIntegrationType() *types.DiscordIntegration {
+	return &types.DiscordIntegration{
+		ID:        i.ID,
+		ProjectID: i.ProjectID,
+		Channel:   i.Channel,
+	}
+}
@@ -0,0 +1,36 @@
+package integrations
+
+import (
+	"gorm.io/gorm"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+// TeamsIntegration is an incoming webhook notifier to a specific channel in Microsoft Teams.
+type TeamsIntegration struct {
+	gorm.Model
+
+	// The id of the user that linked this integration
+	UserID uint `json:"user_id"`
+
+	// The project that this integration belongs to
+	ProjectID uint `json:"project_id"`
+
+	// The name of the Teams channel that the webhook posts to
+	Channel string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// The webhook to call
+	Webhook []byte
+}
+
+func (i *TeamsIntegration) ToTeamsIntegrationType() *types.TeamsIntegration {
+	return &types.TeamsIntegration{
+		ID:        i.ID,
+		ProjectID: i.ProjectID,
+		Channel:   i.Channel,
+	}
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"time"
 
 	"github.com/porter-dev/porter/api/types"
@@ -15,15 +16,25 @@ type NotificationConfig struct {
 	Success bool
 	Failure bool
 
+	// Events is a comma-separated list of the deployment events which are notified
+	Events string
+
 	LastNotifiedTime time.Time
 	NotifLimit       string
 }
 
 func (conf *NotificationConfig) ToNotificationConfigType() *types.NotificationConfig {
+	events := make([]string, 0)
+
+	if conf.Events != "" {
+		events = strings.Split(conf.Events, ",")
+	}
+
 	return &types.NotificationConfig{
 		Enabled:    conf.Enabled,
 		Success:    conf.Success,
 		Failure:    conf.Failure,
+		Events:     events,
 		NotifLimit: conf.NotifLimit,
 	}
 }
@@ -34,8 +45,12 @@ func (conf *NotificationConfig) ShouldNotify() bool {
 }
 
 func notifLimitToTime(notifTime string) time.Time {
-	// TODO: compute a time that's not just 5 min
-	return time.Now().Add(-10 * time.Minute)
+	limit, err := time.ParseDuration(notifTime)
+	if err != nil || limit < 0 {
+		limit = 10 * time.Minute
+	}
+
+	return time.Now().Add(-limit)
 }
 
 type JobNotificationConfig struct {
@@ -54,3 +69,37 @@ func (conf *JobNotificationConfig) ShouldNotify() bool {
 	// check the last notified time against the notification limit
 	return conf.LastNotifiedTime.Before(time.Now().Add(-24 * time.Hour))
 }
+
+// MonitorNotificationConfig configures the notifications sent when the status of a recommender
+// monitor changes in a project
+type MonitorNotificationConfig struct {
+	gorm.Model
+
+	ProjectID uint `gorm:"unique"`
+
+	Enabled bool
+
+	// if email notifications are sent to the members of the project, in addition to Slack
+	Email bool
+
+	// MinSeverity is the lowest severity of the monitors which are notified
+	MinSeverity string
+
+	// MutedCategories is a comma-separated list of the monitor categories which are not notified
+	MutedCategories string
+}
+
+func (conf *MonitorNotificationConfig) ToMonitorNotificationConfigType() *types.MonitorNotificationConfig {
+	mutedCategories := make([]string, 0)
+
+	if conf.MutedCategories != "" {
+		mutedCategories = strings.Split(conf.MutedCategories, ",")
+	}
+
+	return &types.MonitorNotificationConfig{
+		Enabled:         conf.Enabled,
+		Email:           conf.Email,
+		MinSeverity:     types.MonitorTestSeverity(conf.MinSeverity),
+		MutedCategories: mutedCategories,
+	}
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// NotificationThrottle records when a notification was last sent to a channel, so that repeated
+// notifications about the same release or incident are dropped
+type NotificationThrottle struct {
+	gorm.Model
+
+	ProjectID uint
+
+	// Channel identifies the destination of the notification, such as "slack:12"
+	Channel string `gorm:"uniqueIndex:idx_notification_throttle_channel_key"`
+
+	// EventKey identifies the release or incident which the notification refers to
+	EventKey string `gorm:"uniqueIndex:idx_notification_throttle_channel_key"`
+
+	LastSentAt time.Time
+
+	// Suppressed is the number of notifications which were dropped since the last one was sent
+	Suppressed uint
+}
+
+// NotificationChannelUsage counts the notifications sent to a channel in the current window, so
+// that the rate limit of the channel is shared by every server replica
+type NotificationChannelUsage struct {
+	gorm.Model
+
+	Channel string `gorm:"uniqueIndex"`
+
+	WindowStart time.Time
+	Count       uint
+}
+
+// NotificationDigestEntry is a low severity notification which is batched into the next digest
+// of its channel
+type NotificationDigestEntry struct {
+	gorm.Model
+
+	ProjectID uint
+	Channel   string `gorm:"index"`
+
+	// Frequency is either "hourly" or "daily"
+	Frequency string
+
+	// EventTime is the time of the notification, which can be earlier than the time it was queued
+	EventTime time.Time
+
+	Title       string
+	Name        string
+	Namespace   string
+	ClusterName string
+	URL         string
+	Failure     bool
+}
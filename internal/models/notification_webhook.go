@@ -0,0 +1,84 @@
+package models
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// NotificationWebhook is a generic HTTP endpoint which receives signed deployment and incident
+// events of a project. If ClusterID is set, the webhook only receives the events of the release
+// with the given name and namespace in the cluster.
+type NotificationWebhook struct {
+	gorm.Model
+
+	ProjectID uint
+
+	Name string
+	URL  string
+
+	ClusterID        uint
+	ReleaseName      string
+	ReleaseNamespace string
+
+	// ------------------------------------------------------------------
+	// All fields below encrypted before storage.
+	// ------------------------------------------------------------------
+
+	// Secret signs the payloads sent to the webhook
+	Secret []byte
+}
+
+func (w *NotificationWebhook) ToNotificationWebhookType() *types.NotificationWebhook {
+	return &types.NotificationWebhook{
+		ID:               w.ID,
+		CreatedAt:        w.CreatedAt,
+		ProjectID:        w.ProjectID,
+		Name:             w.Name,
+		URL:              w.URL,
+		ClusterID:        w.ClusterID,
+		ReleaseName:      w.ReleaseName,
+		ReleaseNamespace: w.ReleaseNamespace,
+	}
+}
+
+// MatchesRelease returns whether the webhook receives the events of the given release
+func (w *NotificationWebhook) MatchesRelease(clusterID uint, name, namespace string) bool {
+	if w.ClusterID == 0 {
+		return true
+	}
+
+	return w.ClusterID == clusterID && w.ReleaseName == name && w.ReleaseNamespace == namespace
+}
+
+// NotificationWebhookDelivery records an attempt to deliver an event to a NotificationWebhook
+type NotificationWebhookDelivery struct {
+	gorm.Model
+
+	NotificationWebhookID uint
+
+	// DeliveryID is sent in the X-Porter-Delivery header
+	DeliveryID string
+	Event      string
+
+	Attempts    uint
+	StatusCode  int
+	Error       string
+	CompletedAt *time.Time
+}
+
+func (d *NotificationWebhookDelivery) ToNotificationWebhookDeliveryType() *types.NotificationWebhookDelivery {
+	return &types.NotificationWebhookDelivery{
+		ID:                    d.ID,
+		CreatedAt:             d.CreatedAt,
+		NotificationWebhookID: d.NotificationWebhookID,
+		DeliveryID:            d.DeliveryID,
+		Event:                 d.Event,
+		Attempts:              d.Attempts,
+		StatusCode:            d.StatusCode,
+		Error:                 d.Error,
+		Success:               d.Error == "" && d.StatusCode >= 200 && d.StatusCode < 300,
+		CompletedAt:           d.CompletedAt,
+	}
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+
+	"github.com/porter-dev/porter/api/types"
+	"gorm.io/gorm"
+)
+
+// OPAPolicyCollection is a set of rego policies uploaded by a project, which the recommender
+// evaluates alongside the built-in policies
+type OPAPolicyCollection struct {
+	gorm.Model
+
+	ProjectID uint
+	Name      string
+
+	Kind             string
+	MatchBytes       []byte
+	MustExist        bool
+	OverrideSeverity string
+	PoliciesBytes    []byte
+}
+
+func (o *OPAPolicyCollection) ToOPAPolicyCollectionType() (*types.OPAPolicyCollection, error) {
+	res := &types.OPAPolicyCollection{
+		ID:               o.ID,
+		CreatedAt:        o.CreatedAt,
+		UpdatedAt:        o.UpdatedAt,
+		ProjectID:        o.ProjectID,
+		Name:             o.Name,
+		Kind:             o.Kind,
+		MustExist:        o.MustExist,
+		OverrideSeverity: o.OverrideSeverity,
+		Policies:         make([]*types.OPAPolicy, 0),
+	}
+
+	if len(o.MatchBytes) > 0 {
+		if err := json.Unmarshal(o.MatchBytes, &res.Match); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(o.PoliciesBytes) > 0 {
+		if err := json.Unmarshal(o.PoliciesBytes, &res.Policies); err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
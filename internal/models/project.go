@@ -1,6 +1,8 @@
 package models
 
 import (
+	"time"
+
 	"gorm.io/gorm"
 
 	"github.com/porter-dev/porter/api/types"
@@ -64,6 +66,37 @@ type Project struct {
 	StacksEnabled          bool
 	APITokensEnabled       bool
 	CapiProvisionerEnabled bool
+
+	// OPAAdmissionMode is either "enforce" or "audit" if OPA policies are evaluated before
+	// releases are deployed, or empty if they are not
+	OPAAdmissionMode string
+
+	// NotificationDigest is either "hourly" or "daily" if low severity notifications are batched
+	// into digests, or empty if they are sent immediately
+	NotificationDigest string
+
+	// NotificationChannelLimit is the maximum number of notifications sent to a channel per hour,
+	// or 0 to use the default limit of the server
+	NotificationChannelLimit uint
+
+	// AuditLogRetentionDays is the number of days for which audit events of the project are kept,
+	// or 0 to use the default retention
+	AuditLogRetentionDays uint
+}
+
+// DefaultAuditLogRetentionDays is the number of days for which audit events are kept if the
+// project does not configure a retention
+const DefaultAuditLogRetentionDays = 90
+
+// GetAuditLogRetention returns the duration for which audit events of the project are kept
+func (p *Project) GetAuditLogRetention() time.Duration {
+	days := p.AuditLogRetentionDays
+
+	if days == 0 {
+		days = DefaultAuditLogRetentionDays
+	}
+
+	return time.Duration(days) * 24 * time.Hour
 }
 
 // ToProjectType generates an external types.Project to be shared over REST
@@ -84,5 +117,11 @@ func (p *Project) ToProjectType() *types.Project {
 		StacksEnabled:          p.StacksEnabled,
 		APITokensEnabled:       p.APITokensEnabled,
 		CapiProvisionerEnabled: p.CapiProvisionerEnabled,
+		OPAAdmissionMode:       p.OPAAdmissionMode,
+
+		NotificationDigest:       p.NotificationDigest,
+		NotificationChannelLimit: p.NotificationChannelLimit,
+
+		AuditLogRetentionDays: p.AuditLogRetentionDays,
 	}
 }
@@ -23,6 +23,9 @@ type User struct {
 	// The github user id used for login (optional)
 	GithubUserID int64
 	GoogleUserID string
+
+	// The subject of the user at the OIDC provider used for login (optional)
+	OIDCUserID string `gorm:"column:oidc_user_id"`
 }
 
 // ToUserType generates an external types.User to be shared over REST
@@ -1,6 +1,10 @@
 package notifier
 
-import "time"
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+)
 
 type Notifier interface {
 	Notify(opts *NotifyOpts) error
@@ -9,11 +13,83 @@ type Notifier interface {
 type DeploymentStatus string
 
 const (
-	StatusHelmDeployed DeploymentStatus = "helm_deployed"
-	StatusPodCrashed   DeploymentStatus = "pod_crashed"
-	StatusHelmFailed   DeploymentStatus = "helm_failed"
+	StatusHelmDeployed     DeploymentStatus = "helm_deployed"
+	StatusPodCrashed       DeploymentStatus = "pod_crashed"
+	StatusHelmFailed       DeploymentStatus = "helm_failed"
+	StatusRollback         DeploymentStatus = "rollback"
+	StatusJobFailed        DeploymentStatus = "job_failed"
+	StatusJobSucceeded     DeploymentStatus = "job_succeeded"
+	StatusReadinessTimeout DeploymentStatus = "readiness_timeout"
+	StatusOOMKilled        DeploymentStatus = "oom_killed"
+	StatusHPAMaxedOut      DeploymentStatus = "hpa_maxed_out"
 )
 
+// IsFailure returns true if the status reports that the deployment is unhealthy
+func (s DeploymentStatus) IsFailure() bool {
+	switch s {
+	case StatusHelmDeployed, StatusRollback, StatusJobSucceeded:
+		return false
+	}
+
+	return true
+}
+
+// Description completes the sentence "Your application ... on Porter", and is used by the notifiers
+// which do not have a dedicated message for the status
+func (s DeploymentStatus) Description() string {
+	switch s {
+	case StatusHelmDeployed:
+		return "was successfully updated"
+	case StatusHelmFailed:
+		return "failed to deploy"
+	case StatusPodCrashed:
+		return "crashed"
+	case StatusRollback:
+		return "was rolled back"
+	case StatusJobFailed:
+		return "has a job run which failed"
+	case StatusJobSucceeded:
+		return "has a job run which succeeded"
+	case StatusReadinessTimeout:
+		return "did not become ready in time"
+	case StatusOOMKilled:
+		return "ran out of memory"
+	case StatusHPAMaxedOut:
+		return "reached its maximum number of replicas"
+	}
+
+	return string(s)
+}
+
+// IsSubscribed returns true if the notification config of a release subscribes to the status. When the
+// config lists events, only these events are notified. Otherwise, the success and failure settings only
+// apply to the statuses which predate the event list, so that existing releases are not notified about
+// new kinds of events unless they opt in.
+func IsSubscribed(conf *types.NotificationConfig, status DeploymentStatus) bool {
+	if conf != nil && !conf.Enabled {
+		return false
+	}
+
+	if conf != nil && len(conf.Events) > 0 {
+		for _, event := range conf.Events {
+			if event == string(status) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	switch status {
+	case StatusHelmDeployed:
+		return conf == nil || conf.Success
+	case StatusPodCrashed, StatusHelmFailed:
+		return conf == nil || conf.Failure
+	}
+
+	return false
+}
+
 type NotifyOpts struct {
 	// ProjectID is the id of the Porter project that this deployment belongs to
 	ProjectID uint
@@ -43,3 +119,24 @@ type NotifyOpts struct {
 
 	Version int
 }
+
+type MultiNotifier struct {
+	notifiers []Notifier
+}
+
+// NewMultiNotifier returns a Notifier which notifies every notifier, even if some of them fail
+func NewMultiNotifier(notifiers ...Notifier) Notifier {
+	return &MultiNotifier{notifiers}
+}
+
+func (m *MultiNotifier) Notify(opts *NotifyOpts) error {
+	var firstErr error
+
+	for _, n := range m.notifiers {
+		if err := n.Notify(opts); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
@@ -0,0 +1,142 @@
+package notifier
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+)
+
+var allStatuses = []DeploymentStatus{
+	StatusHelmDeployed,
+	StatusPodCrashed,
+	StatusHelmFailed,
+	StatusRollback,
+	StatusJobFailed,
+	StatusJobSucceeded,
+	StatusReadinessTimeout,
+	StatusOOMKilled,
+	StatusHPAMaxedOut,
+}
+
+func TestIsSubscribed(t *testing.T) {
+	tests := []struct {
+		name       string
+		conf       *types.NotificationConfig
+		subscribed []DeploymentStatus
+	}{
+		{
+			name:       "releases without a config are notified about the legacy statuses",
+			conf:       nil,
+			subscribed: []DeploymentStatus{StatusHelmDeployed, StatusPodCrashed, StatusHelmFailed},
+		},
+		{
+			name: "disabled configs are never notified",
+			conf: &types.NotificationConfig{
+				Enabled: false,
+				Success: true,
+				Failure: true,
+				Events:  []string{string(StatusJobFailed)},
+			},
+			subscribed: []DeploymentStatus{},
+		},
+		{
+			name:       "legacy config with success and failure",
+			conf:       &types.NotificationConfig{Enabled: true, Success: true, Failure: true},
+			subscribed: []DeploymentStatus{StatusHelmDeployed, StatusPodCrashed, StatusHelmFailed},
+		},
+		{
+			name:       "legacy config with success only",
+			conf:       &types.NotificationConfig{Enabled: true, Success: true},
+			subscribed: []DeploymentStatus{StatusHelmDeployed},
+		},
+		{
+			name:       "legacy config with failure only",
+			conf:       &types.NotificationConfig{Enabled: true, Failure: true},
+			subscribed: []DeploymentStatus{StatusPodCrashed, StatusHelmFailed},
+		},
+		{
+			name:       "legacy config with neither success nor failure",
+			conf:       &types.NotificationConfig{Enabled: true},
+			subscribed: []DeploymentStatus{},
+		},
+		{
+			name: "events override the success and failure settings",
+			conf: &types.NotificationConfig{
+				Enabled: true,
+				Success: true,
+				Failure: true,
+				Events:  []string{string(StatusJobFailed), string(StatusOOMKilled)},
+			},
+			subscribed: []DeploymentStatus{StatusJobFailed, StatusOOMKilled},
+		},
+		{
+			name: "events can subscribe to the legacy statuses",
+			conf: &types.NotificationConfig{
+				Enabled: true,
+				Events:  []string{string(StatusHelmFailed)},
+			},
+			subscribed: []DeploymentStatus{StatusHelmFailed},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, status := range allStatuses {
+				expected := false
+
+				for _, s := range tc.subscribed {
+					if s == status {
+						expected = true
+					}
+				}
+
+				if got := IsSubscribed(tc.conf, status); got != expected {
+					t.Errorf("IsSubscribed(%s): expected %t, got %t", status, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestDeploymentStatusIsFailure(t *testing.T) {
+	tests := map[DeploymentStatus]bool{
+		StatusHelmDeployed:     false,
+		StatusPodCrashed:       true,
+		StatusHelmFailed:       true,
+		StatusRollback:         false,
+		StatusJobFailed:        true,
+		StatusJobSucceeded:     false,
+		StatusReadinessTimeout: true,
+		StatusOOMKilled:        true,
+		StatusHPAMaxedOut:      true,
+	}
+
+	for status, expected := range tests {
+		if got := status.IsFailure(); got != expected {
+			t.Errorf("%s.IsFailure(): expected %t, got %t", status, expected, got)
+		}
+	}
+}
+
+func TestDeploymentStatusDescription(t *testing.T) {
+	seen := make(map[string]DeploymentStatus)
+
+	for _, status := range allStatuses {
+		desc := status.Description()
+
+		if desc == "" || desc == string(status) || strings.Contains(desc, "_") {
+			t.Errorf("%s has no description, got %q", status, desc)
+		}
+
+		if other, ok := seen[desc]; ok {
+			t.Errorf("%s and %s have the same description %q", status, other, desc)
+		}
+
+		seen[desc] = status
+	}
+
+	if got := DeploymentStatus("unknown").Description(); got != "unknown" {
+		t.Errorf("expected unknown statuses to be described by their name, got %q", got)
+	}
+}
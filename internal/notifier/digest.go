@@ -0,0 +1,63 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+)
+
+type DigestFrequency string
+
+const (
+	DigestHourly DigestFrequency = "hourly"
+	DigestDaily  DigestFrequency = "daily"
+)
+
+// MaxDigestEntries is the number of entries which are listed in a digest message, since chat
+// integrations limit the size of messages
+const MaxDigestEntries = 20
+
+// Digest is a batch of low severity notifications which is sent periodically to a channel
+type Digest struct {
+	Frequency DigestFrequency
+	Entries   []*DigestEntry
+}
+
+type DigestEntry struct {
+	Time        time.Time
+	Title       string
+	Name        string
+	Namespace   string
+	ClusterName string
+	URL         string
+	Failure     bool
+}
+
+// DigestNotifier is implemented by the notifiers which can send digests
+type DigestNotifier interface {
+	NotifyDigest(digest *Digest) error
+}
+
+// Title returns the title of the digest message
+func (d *Digest) Title() string {
+	period := "hour"
+
+	if d.Frequency == DigestDaily {
+		period = "day"
+	}
+
+	if len(d.Entries) == 1 {
+		return fmt.Sprintf("Porter digest: 1 notification in the last %s", period)
+	}
+
+	return fmt.Sprintf("Porter digest: %d notifications in the last %s", len(d.Entries), period)
+}
+
+// ListedEntries returns the entries which are listed in the digest message, and the number of
+// entries which are omitted
+func (d *Digest) ListedEntries() ([]*DigestEntry, int) {
+	if len(d.Entries) <= MaxDigestEntries {
+		return d.Entries, 0
+	}
+
+	return d.Entries[:MaxDigestEntries], len(d.Entries) - MaxDigestEntries
+}
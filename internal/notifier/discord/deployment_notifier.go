@@ -0,0 +1,81 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type DeploymentNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+	Config      *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, discordInts ...*integrations.DiscordIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		discordInts: discordInts,
+		Config:      conf,
+	}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.IsSubscribed(d.Config, opts.Status) {
+		return nil
+	}
+
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	return postEmbed(d.discordInts, getDeploymentEmbed(opts))
+}
+
+// getDeploymentEmbed formats the deployment notification in the same way as the Slack blocks
+func getDeploymentEmbed(opts *notifier.NotifyOpts) *Embed {
+	embed := &Embed{
+		URL: opts.URL,
+		Fields: []*EmbedField{
+			getInlineField("Name", opts.Name),
+			getInlineField("Namespace", opts.Namespace),
+			getInlineField("Cluster", opts.ClusterName),
+		},
+	}
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		embed.Title = fmt.Sprintf("🚀 %s was successfully updated on Porter!", opts.Name)
+		embed.Color = colorSuccess
+	case notifier.StatusHelmFailed:
+		embed.Title = fmt.Sprintf("❌ %s failed to deploy on Porter", opts.Name)
+		embed.Color = colorFailure
+	case notifier.StatusPodCrashed:
+		embed.Title = fmt.Sprintf("❌ %s crashed on Porter", opts.Name)
+		embed.Color = colorFailure
+	default:
+		embed.Title = fmt.Sprintf("✅ %s %s on Porter", opts.Name, opts.Status.Description())
+		embed.Color = colorSuccess
+
+		if opts.Status.IsFailure() {
+			embed.Title = fmt.Sprintf("❌ %s %s on Porter", opts.Name, opts.Status.Description())
+			embed.Color = colorFailure
+		}
+	}
+
+	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed ||
+		opts.Status == notifier.StatusRollback {
+		embed.Fields = append(embed.Fields, getInlineField("Version", fmt.Sprintf("%d", opts.Version)))
+	}
+
+	if opts.Info != "" && opts.Status != notifier.StatusHelmDeployed {
+		embed.Description = getCodeText(opts.Info)
+	}
+
+	if opts.Timestamp != nil {
+		embed.Timestamp = opts.Timestamp.UTC().Format(time.RFC3339)
+	}
+
+	return embed
+}
@@ -0,0 +1,53 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func (d *DeploymentNotifier) NotifyDigest(digest *notifier.Digest) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0)
+	entries, omitted := digest.ListedEntries()
+
+	for _, entry := range entries {
+		emoji := "✅"
+
+		if entry.Failure {
+			emoji = "❌"
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"%s [%s](%s) %s <t:%d:f> `%s/%s`",
+			emoji,
+			entry.Name,
+			entry.URL,
+			entry.Title,
+			entry.Time.Unix(),
+			entry.ClusterName,
+			entry.Namespace,
+		))
+	}
+
+	if omitted > 0 {
+		lines = append(lines, fmt.Sprintf("_...and %d more_", omitted))
+	}
+
+	description := strings.Join(lines, "\n")
+
+	// descriptions are limited to 4096 characters, which long release names can exceed
+	if len(description) > 4000 {
+		description = strings.ToValidUTF8(description[0:4000], "") + "..."
+	}
+
+	return postEmbed(d.discordInts, &Embed{
+		Title:       digest.Title(),
+		Description: description,
+		Color:       colorSuccess,
+	})
+}
@@ -0,0 +1,86 @@
+package discord
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+const (
+	colorSuccess = 0x2EB67D
+	colorFailure = 0xE01E5A
+
+	// Discord rejects embeds with a description longer than 4096 characters
+	maxInfoLength = 1000
+)
+
+// Payload is the body accepted by Discord webhooks
+type Payload struct {
+	Username string   `json:"username,omitempty"`
+	Embeds   []*Embed `json:"embeds"`
+}
+
+type Embed struct {
+	Title       string        `json:"title"`
+	Description string        `json:"description,omitempty"`
+	URL         string        `json:"url,omitempty"`
+	Color       int           `json:"color"`
+	Fields      []*EmbedField `json:"fields,omitempty"`
+	Timestamp   string        `json:"timestamp,omitempty"`
+}
+
+type EmbedField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+func getInlineField(name, value string) *EmbedField {
+	return &EmbedField{
+		Name:   name,
+		Value:  "`" + value + "`",
+		Inline: true,
+	}
+}
+
+func getCodeText(text string) string {
+	if len(text) > maxInfoLength {
+		text = strings.ToValidUTF8(text[0:maxInfoLength], "") + "..."
+	}
+
+	return fmt.Sprintf("```\n%s\n```", text)
+}
+
+func postEmbed(discordInts []*integrations.DiscordIntegration, embed *Embed) error {
+	payload, err := json.Marshal(&Payload{
+		Username: "Porter",
+		Embeds:   []*Embed{embed},
+	})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, discordInt := range discordInts {
+		res, err := client.Post(string(discordInt.Webhook), "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		res.Body.Close()
+
+		if res.StatusCode >= 400 {
+			return fmt.Errorf("discord webhook for channel %s responded with status code %d", discordInt.Channel, res.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type IncidentNotifier struct {
+	discordInts []*integrations.DiscordIntegration
+}
+
+func NewIncidentNotifier(discordInts ...*integrations.DiscordIntegration) *IncidentNotifier {
+	return &IncidentNotifier{
+		discordInts: discordInts,
+	}
+}
+
+func (d *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	resourceKind := "application"
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == "job" {
+		resourceKind = "job"
+	}
+
+	return postEmbed(d.discordInts, &Embed{
+		Title:       fmt.Sprintf("⚠️ Your %s %s crashed on Porter", resourceKind, incident.ReleaseName),
+		Description: getCodeText(incident.Summary),
+		URL:         url,
+		Color:       colorFailure,
+		Fields: []*EmbedField{
+			getInlineField("Namespace", incident.ReleaseNamespace),
+			getInlineField("Name", incident.ReleaseName),
+		},
+		Timestamp: incident.CreatedAt.UTC().Format(time.RFC3339),
+	})
+}
+
+func (d *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	if len(d.discordInts) == 0 {
+		return nil
+	}
+
+	return postEmbed(d.discordInts, &Embed{
+		Title:       fmt.Sprintf("✅ The incident for application %s has been resolved", incident.ReleaseName),
+		Description: getCodeText(incident.Summary),
+		URL:         url,
+		Color:       colorSuccess,
+		Fields: []*EmbedField{
+			getInlineField("Namespace", incident.ReleaseNamespace),
+			getInlineField("Name", incident.ReleaseName),
+			getInlineField("Created at", incident.CreatedAt.Format("2006-01-02 15:04:05 UTC")),
+		},
+		Timestamp: incident.UpdatedAt.UTC().Format(time.RFC3339),
+	})
+}
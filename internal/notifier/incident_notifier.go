@@ -1,6 +1,10 @@
 package notifier
 
-import "github.com/porter-dev/porter/api/types"
+import (
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+)
 
 type IncidentNotifier interface {
 	NotifyNew(incident *types.Incident, url string) error
@@ -47,3 +51,30 @@ func (m *MultiIncidentNotifier) NotifyResolved(incident *types.Incident, url str
 
 	return nil
 }
+
+// GetIncidentDeploymentStatus returns the deployment event which corresponds to an incident. Incidents
+// reported by older agents do not set a reason, so job incidents and out of memory errors are detected
+// from the incident itself.
+func GetIncidentDeploymentStatus(incident *types.Incident) (DeploymentStatus, bool) {
+	switch incident.Reason {
+	case types.IncidentReasonOOMKilled:
+		return StatusOOMKilled, true
+	case types.IncidentReasonReadinessTimeout:
+		return StatusReadinessTimeout, true
+	case types.IncidentReasonHPAMaxedOut:
+		return StatusHPAMaxedOut, true
+	case types.IncidentReasonJobFailed:
+		return StatusJobFailed, true
+	}
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == string(types.InvolvedObjectJob) {
+		return StatusJobFailed, true
+	}
+
+	if text := strings.ToLower(incident.Summary + " " + incident.Detail); strings.Contains(text, "oomkilled") ||
+		strings.Contains(text, "out of memory") {
+		return StatusOOMKilled, true
+	}
+
+	return "", false
+}
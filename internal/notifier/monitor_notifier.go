@@ -0,0 +1,58 @@
+package notifier
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type MonitorNotifyOpts struct {
+	ClusterName string
+	Result      *types.MonitorTestResult
+	URL         string
+}
+
+// MonitorNotifier notifies the members of a project when a recommender monitor starts failing
+// or recovers, depending on Result.LastRunResult
+type MonitorNotifier interface {
+	NotifyStatusChange(opts *MonitorNotifyOpts) error
+}
+
+type MultiMonitorNotifier struct {
+	notifConf *types.MonitorNotificationConfig
+	notifiers []MonitorNotifier
+}
+
+func NewMultiMonitorNotifier(notifConf *types.MonitorNotificationConfig, notifiers ...MonitorNotifier) MonitorNotifier {
+	return &MultiMonitorNotifier{notifConf, notifiers}
+}
+
+func (m *MultiMonitorNotifier) NotifyStatusChange(opts *MonitorNotifyOpts) error {
+	if !ShouldNotifyMonitor(m.notifConf, opts.Result) {
+		return nil
+	}
+
+	for _, n := range m.notifiers {
+		if err := n.NotifyStatusChange(opts); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ShouldNotifyMonitor returns whether a status change of the monitor passes the severity threshold
+// and category mutes of the notification config. Monitor notifications are opt-in, so nothing is
+// notified without a config.
+func ShouldNotifyMonitor(notifConf *types.MonitorNotificationConfig, result *types.MonitorTestResult) bool {
+	if notifConf == nil || !notifConf.Enabled {
+		return false
+	}
+
+	for _, category := range notifConf.MutedCategories {
+		if category == result.Category {
+			return false
+		}
+	}
+
+	return models.GetSeverityEnum(string(result.Severity)) >= models.GetSeverityEnum(string(notifConf.MinSeverity))
+}
@@ -0,0 +1,86 @@
+package pipeline
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/discord"
+	"github.com/porter-dev/porter/internal/notifier/slack"
+	"github.com/porter-dev/porter/internal/notifier/teams"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+type ChannelKind string
+
+const (
+	ChannelSlack   ChannelKind = "slack"
+	ChannelTeams   ChannelKind = "teams"
+	ChannelDiscord ChannelKind = "discord"
+)
+
+// Channel is a single destination of notifications, such as a Slack integration
+type Channel struct {
+	Kind ChannelKind
+
+	// Key uniquely identifies the channel, and is used to persist its throttles and rate limit
+	Key string
+
+	Deployment notifier.Notifier
+
+	// Incident is nil if the channel does not receive incident notifications
+	Incident notifier.IncidentNotifier
+}
+
+func getChannelKey(kind ChannelKind, integrationID uint) string {
+	return fmt.Sprintf("%s:%d", kind, integrationID)
+}
+
+// ListChannels returns a channel for every Slack, Teams and Discord integration of the project
+func ListChannels(repo repository.Repository, projectID uint, notifConf *types.NotificationConfig) ([]*Channel, error) {
+	channels := make([]*Channel, 0)
+
+	slackInts, err := repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, slackInt := range slackInts {
+		channels = append(channels, &Channel{
+			Kind:       ChannelSlack,
+			Key:        getChannelKey(ChannelSlack, slackInt.ID),
+			Deployment: slack.NewDeploymentNotifier(notifConf, slackInt),
+			Incident:   slack.NewIncidentNotifier(slackInt),
+		})
+	}
+
+	teamsInts, err := repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, teamsInt := range teamsInts {
+		channels = append(channels, &Channel{
+			Kind:       ChannelTeams,
+			Key:        getChannelKey(ChannelTeams, teamsInt.ID),
+			Deployment: teams.NewDeploymentNotifier(notifConf, teamsInt),
+			Incident:   teams.NewIncidentNotifier(teamsInt),
+		})
+	}
+
+	discordInts, err := repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, discordInt := range discordInts {
+		channels = append(channels, &Channel{
+			Kind:       ChannelDiscord,
+			Key:        getChannelKey(ChannelDiscord, discordInt.ID),
+			Deployment: discord.NewDeploymentNotifier(notifConf, discordInt),
+			Incident:   discord.NewIncidentNotifier(discordInt),
+		})
+	}
+
+	return channels, nil
+}
@@ -0,0 +1,136 @@
+package pipeline
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// throttles are kept for a week, which is longer than any reasonable deduplication window
+const throttleRetention = 7 * 24 * time.Hour
+
+type digestGroup struct {
+	projectID uint
+	channel   string
+	frequency notifier.DigestFrequency
+	entries   []*models.NotificationDigestEntry
+}
+
+// FlushDigests sends the digests which are due, which are the hourly digests of the previous hours and
+// the daily digests of the previous days. Entries of channels which no longer exist are dropped, while
+// entries which fail to be sent are kept for the next run.
+func FlushDigests(repo repository.Repository, now time.Time) error {
+	entries, err := repo.NotificationPipeline().ListDueNotificationDigestEntries(
+		now.Truncate(time.Hour),
+		now.Truncate(24*time.Hour),
+	)
+	if err != nil {
+		return err
+	}
+
+	groups := make([]*digestGroup, 0)
+	groupsByKey := make(map[string]*digestGroup)
+
+	for _, entry := range entries {
+		key := entry.Channel + "/" + entry.Frequency
+
+		group, ok := groupsByKey[key]
+
+		if !ok {
+			group = &digestGroup{
+				projectID: entry.ProjectID,
+				channel:   entry.Channel,
+				frequency: notifier.DigestFrequency(entry.Frequency),
+			}
+
+			groupsByKey[key] = group
+			groups = append(groups, group)
+		}
+
+		group.entries = append(group.entries, entry)
+	}
+
+	var firstErr error
+
+	channelsByProject := make(map[uint]map[string]*Channel)
+
+	for _, group := range groups {
+		channels, ok := channelsByProject[group.projectID]
+
+		if !ok {
+			projChannels, err := ListChannels(repo, group.projectID, nil)
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+
+				continue
+			}
+
+			channels = make(map[string]*Channel)
+
+			for _, ch := range projChannels {
+				channels[ch.Key] = ch
+			}
+
+			channelsByProject[group.projectID] = channels
+		}
+
+		if ch, ok := channels[group.channel]; ok {
+			if digestNotifier, ok := ch.Deployment.(notifier.DigestNotifier); ok {
+				if err := digestNotifier.NotifyDigest(getDigest(group)); err != nil {
+					if firstErr == nil {
+						firstErr = err
+					}
+
+					continue
+				}
+			}
+		}
+
+		ids := make([]uint, 0)
+
+		for _, entry := range group.entries {
+			ids = append(ids, entry.ID)
+		}
+
+		if err := repo.NotificationPipeline().DeleteNotificationDigestEntries(ids); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if err := repo.NotificationPipeline().DeleteNotificationThrottlesBefore(now.Add(-throttleRetention)); err != nil && firstErr == nil {
+		firstErr = err
+	}
+
+	return firstErr
+}
+
+func getDigest(group *digestGroup) *notifier.Digest {
+	digest := &notifier.Digest{
+		Frequency: group.frequency,
+	}
+
+	for _, entry := range group.entries {
+		timestamp := entry.EventTime
+
+		// entries queued before the event time was stored only have the time they were queued
+		if timestamp.IsZero() {
+			timestamp = entry.CreatedAt
+		}
+
+		digest.Entries = append(digest.Entries, &notifier.DigestEntry{
+			Time:        timestamp,
+			Title:       entry.Title,
+			Name:        entry.Name,
+			Namespace:   entry.Namespace,
+			ClusterName: entry.ClusterName,
+			URL:         entry.URL,
+			Failure:     entry.Failure,
+		})
+	}
+
+	return digest
+}
@@ -0,0 +1,269 @@
+package pipeline
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+// DefaultDedupWindow is used when the notification config of a release does not set a limit
+const DefaultDedupWindow = 10 * time.Minute
+
+type Options struct {
+	// NotificationConfig is the notification config of the release, if any
+	NotificationConfig *types.NotificationConfig
+
+	// DedupWindow is the duration during which repeated notifications about the same release
+	// or incident are dropped
+	DedupWindow time.Duration
+
+	// ChannelLimit is the maximum number of notifications sent to a channel per hour. Notifications
+	// over the limit are added to the next digest of the channel instead. 0 disables the limit.
+	ChannelLimit uint
+
+	// Digest is the frequency of the digests which batch low severity notifications, or empty if
+	// low severity notifications are sent immediately
+	Digest notifier.DigestFrequency
+
+	// ClusterName is the name of the cluster of incident notifications
+	ClusterName string
+
+	// Logger logs the notifications which are dropped
+	Logger *logger.Logger
+}
+
+// GetDedupWindow parses the notification limit of a release, which is a duration such as "30m"
+func GetDedupWindow(notifConf *types.NotificationConfig) time.Duration {
+	if notifConf == nil || notifConf.NotifLimit == "" {
+		return DefaultDedupWindow
+	}
+
+	window, err := time.ParseDuration(notifConf.NotifLimit)
+	if err != nil || window < 0 {
+		return DefaultDedupWindow
+	}
+
+	return window
+}
+
+// Pipeline sends notifications to the channels of a project. Repeated notifications about the same
+// release or incident are dropped, the number of notifications per channel is limited, and low
+// severity notifications can be batched into periodic digests. The state of the pipeline is stored
+// in the database, so that limits are shared by every replica and survive restarts.
+type Pipeline struct {
+	repo      repository.NotificationPipelineRepository
+	projectID uint
+	opts      *Options
+	channels  []*Channel
+	now       func() time.Time
+}
+
+func New(
+	repo repository.NotificationPipelineRepository,
+	projectID uint,
+	opts *Options,
+	channels ...*Channel,
+) *Pipeline {
+	return &Pipeline{
+		repo:      repo,
+		projectID: projectID,
+		opts:      opts,
+		channels:  channels,
+		now:       time.Now,
+	}
+}
+
+// event is a notification which is processed for every channel of the pipeline
+type event struct {
+	key         string
+	incident    bool
+	lowSeverity bool
+	entry       *notifier.DigestEntry
+	send        func(ch *Channel) error
+}
+
+func (p *Pipeline) Notify(opts *notifier.NotifyOpts) error {
+	// subscriptions are checked here as well, since notifications which are added to a digest
+	// are not sent through the notifier of the channel
+	if !notifier.IsSubscribed(p.opts.NotificationConfig, opts.Status) {
+		return nil
+	}
+
+	key := fmt.Sprintf("deployment:%d:%s:%s:%s", opts.ClusterID, opts.Namespace, opts.Name, opts.Status)
+
+	// every version is a distinct deployment, so it is never a duplicate of the previous one
+	if opts.Version != 0 {
+		key = fmt.Sprintf("%s:%d", key, opts.Version)
+	}
+
+	timestamp := p.now()
+
+	if opts.Timestamp != nil {
+		timestamp = *opts.Timestamp
+	}
+
+	return p.process(&event{
+		key:         key,
+		lowSeverity: !opts.Status.IsFailure(),
+		entry: &notifier.DigestEntry{
+			Time:        timestamp,
+			Title:       opts.Status.Description(),
+			Name:        opts.Name,
+			Namespace:   opts.Namespace,
+			ClusterName: opts.ClusterName,
+			URL:         opts.URL,
+			Failure:     opts.Status.IsFailure(),
+		},
+		send: func(ch *Channel) error {
+			return ch.Deployment.Notify(opts)
+		},
+	})
+}
+
+func (p *Pipeline) NotifyNew(incident *types.Incident, url string) error {
+	return p.process(&event{
+		key:         fmt.Sprintf("incident:%s:new", incident.ID),
+		incident:    true,
+		lowSeverity: incident.Severity != types.SeverityCritical,
+		entry:       p.getIncidentDigestEntry(incident, incident.Summary, url, true),
+		send: func(ch *Channel) error {
+			return ch.Incident.NotifyNew(incident, url)
+		},
+	})
+}
+
+func (p *Pipeline) NotifyResolved(incident *types.Incident, url string) error {
+	return p.process(&event{
+		key:         fmt.Sprintf("incident:%s:resolved", incident.ID),
+		incident:    true,
+		lowSeverity: true,
+		entry:       p.getIncidentDigestEntry(incident, "resolved: "+incident.Summary, url, false),
+		send: func(ch *Channel) error {
+			return ch.Incident.NotifyResolved(incident, url)
+		},
+	})
+}
+
+func (p *Pipeline) getIncidentDigestEntry(incident *types.Incident, title, url string, failure bool) *notifier.DigestEntry {
+	timestamp := p.now()
+
+	if incident.LastSeen != nil {
+		timestamp = *incident.LastSeen
+	}
+
+	return &notifier.DigestEntry{
+		Time:        timestamp,
+		Title:       title,
+		Name:        incident.ReleaseName,
+		Namespace:   incident.ReleaseNamespace,
+		ClusterName: p.opts.ClusterName,
+		URL:         url,
+		Failure:     failure,
+	}
+}
+
+// process sends the event to every channel, and returns the first error
+func (p *Pipeline) process(e *event) error {
+	now := p.now()
+
+	var firstErr error
+
+	for _, ch := range p.channels {
+		if (e.incident && ch.Incident == nil) || (!e.incident && ch.Deployment == nil) {
+			continue
+		}
+
+		if err := p.processChannel(ch, e, now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	return firstErr
+}
+
+func (p *Pipeline) processChannel(ch *Channel, e *event, now time.Time) error {
+	throttle := &models.NotificationThrottle{
+		ProjectID:  p.projectID,
+		Channel:    ch.Key,
+		EventKey:   e.key,
+		LastSentAt: now,
+	}
+
+	// the notification is reserved before it is delivered, so that concurrent notifications about
+	// the same event are not both sent
+	reserved, err := p.repo.ReserveNotification(throttle, now.Add(-p.opts.DedupWindow))
+	if err != nil || !reserved {
+		return err
+	}
+
+	delivered, err := p.deliver(ch, e, now)
+	if err == nil && delivered {
+		return nil
+	}
+
+	// notifications which fail to be sent or are dropped are released, so that they are retried by
+	// the next event instead of being deduplicated
+	if releaseErr := p.repo.ReleaseNotification(throttle); releaseErr != nil && err == nil {
+		return releaseErr
+	}
+
+	return err
+}
+
+// deliver sends the event to the channel or adds it to the next digest of the channel, and returns
+// false if the event was dropped because the channel reached its limit
+func (p *Pipeline) deliver(ch *Channel, e *event, now time.Time) (bool, error) {
+	_, canDigest := ch.Deployment.(notifier.DigestNotifier)
+
+	if canDigest && e.lowSeverity && p.opts.Digest != "" {
+		return true, p.queue(ch, e, p.opts.Digest)
+	}
+
+	if p.opts.ChannelLimit > 0 {
+		count, err := p.repo.IncrementNotificationChannelUsage(ch.Key, now.Truncate(time.Hour))
+		if err != nil {
+			return false, err
+		}
+
+		if count > p.opts.ChannelLimit {
+			if !canDigest {
+				p.opts.Logger.Warn().Msgf("dropping notification %s for channel %s of project %d: limit of %d notifications per hour reached",
+					e.key, ch.Key, p.projectID, p.opts.ChannelLimit)
+
+				return false, nil
+			}
+
+			frequency := p.opts.Digest
+
+			if frequency == "" {
+				frequency = notifier.DigestHourly
+			}
+
+			return true, p.queue(ch, e, frequency)
+		}
+	}
+
+	return true, e.send(ch)
+}
+
+func (p *Pipeline) queue(ch *Channel, e *event, frequency notifier.DigestFrequency) error {
+	_, err := p.repo.CreateNotificationDigestEntry(&models.NotificationDigestEntry{
+		ProjectID:   p.projectID,
+		Channel:     ch.Key,
+		Frequency:   string(frequency),
+		EventTime:   e.entry.Time,
+		Title:       e.entry.Title,
+		Name:        e.entry.Name,
+		Namespace:   e.entry.Namespace,
+		ClusterName: e.entry.ClusterName,
+		URL:         e.entry.URL,
+		Failure:     e.entry.Failure,
+	})
+
+	return err
+}
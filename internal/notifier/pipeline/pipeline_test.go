@@ -0,0 +1,297 @@
+package pipeline
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"github.com/porter-dev/porter/pkg/logger"
+)
+
+type testNotifier struct {
+	sent []*notifier.NotifyOpts
+	err  error
+}
+
+func (n *testNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if n.err != nil {
+		return n.err
+	}
+
+	n.sent = append(n.sent, opts)
+
+	return nil
+}
+
+type testDigestNotifier struct {
+	testNotifier
+}
+
+func (n *testDigestNotifier) NotifyDigest(digest *notifier.Digest) error {
+	return nil
+}
+
+// newTestPipeline returns a pipeline with a single channel, whose clock is set by the returned function
+func newTestPipeline(opts *Options, deployment notifier.Notifier) (*Pipeline, repository.NotificationPipelineRepository, func(time.Time)) {
+	repo := test.NewNotificationPipelineRepository(true)
+
+	if opts.Logger == nil {
+		opts.Logger = logger.NewConsole(false)
+	}
+
+	p := New(repo, 1, opts, &Channel{
+		Kind:       ChannelSlack,
+		Key:        "slack:1",
+		Deployment: deployment,
+	})
+
+	now := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	p.now = func() time.Time {
+		return now
+	}
+
+	return p, repo, func(t time.Time) {
+		now = t
+	}
+}
+
+func crashedOpts(name string) *notifier.NotifyOpts {
+	return &notifier.NotifyOpts{
+		ClusterID: 1,
+		Name:      name,
+		Namespace: "default",
+		Status:    notifier.StatusPodCrashed,
+	}
+}
+
+func TestPipelineDedup(t *testing.T) {
+	deployment := &testNotifier{}
+	start := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	p, _, setNow := newTestPipeline(&Options{DedupWindow: 10 * time.Minute}, deployment)
+
+	// a failed send is not recorded, so the next notification is not a duplicate
+	deployment.err = fmt.Errorf("slack is down")
+
+	if err := p.Notify(crashedOpts("web")); err == nil {
+		t.Fatalf("expected the error of the channel to be returned")
+	}
+
+	deployment.err = nil
+
+	if err := p.Notify(crashedOpts("web")); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	setNow(start.Add(5 * time.Minute))
+
+	if err := p.Notify(crashedOpts("web")); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// other releases are not deduplicated
+	if err := p.Notify(crashedOpts("worker")); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(deployment.sent) != 2 {
+		t.Fatalf("expected 2 notifications within the dedup window, got %d", len(deployment.sent))
+	}
+
+	setNow(start.Add(11 * time.Minute))
+
+	if err := p.Notify(crashedOpts("web")); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(deployment.sent) != 3 {
+		t.Errorf("expected the notification to be sent again after the dedup window, got %d", len(deployment.sent))
+	}
+}
+
+func TestPipelineChannelLimit(t *testing.T) {
+	deployment := &testNotifier{}
+	start := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	p, _, setNow := newTestPipeline(&Options{DedupWindow: 10 * time.Minute, ChannelLimit: 2}, deployment)
+
+	for _, name := range []string{"web", "worker", "cron"} {
+		if err := p.Notify(crashedOpts(name)); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	if len(deployment.sent) != 2 {
+		t.Fatalf("expected notifications over the limit to be dropped, got %d", len(deployment.sent))
+	}
+
+	// the dropped notification was never sent, so it is not deduplicated in the next hour
+	setNow(start.Add(time.Hour))
+
+	if err := p.Notify(crashedOpts("cron")); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(deployment.sent) != 3 || deployment.sent[2].Name != "cron" {
+		t.Errorf("expected the limit to be reset in the next hour, got %d notifications", len(deployment.sent))
+	}
+}
+
+func TestPipelineDigest(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       *Options
+		notifs     []*notifier.NotifyOpts
+		expSent    int
+		expDigests map[string]int
+	}{
+		{
+			name: "low severity notifications are added to the digest",
+			opts: &Options{DedupWindow: 10 * time.Minute, Digest: notifier.DigestDaily},
+			notifs: []*notifier.NotifyOpts{
+				{ClusterID: 1, Name: "web", Namespace: "default", Status: notifier.StatusHelmDeployed, Version: 1},
+				{ClusterID: 1, Name: "web", Namespace: "default", Status: notifier.StatusPodCrashed},
+			},
+			expSent:    1,
+			expDigests: map[string]int{"daily": 1},
+		},
+		{
+			name: "notifications over the limit are added to the hourly digest",
+			opts: &Options{DedupWindow: 10 * time.Minute, ChannelLimit: 1},
+			notifs: []*notifier.NotifyOpts{
+				crashedOpts("web"),
+				crashedOpts("worker"),
+				crashedOpts("cron"),
+			},
+			expSent:    1,
+			expDigests: map[string]int{"hourly": 2},
+		},
+		{
+			name: "duplicates are not added to the digest",
+			opts: &Options{DedupWindow: 10 * time.Minute, Digest: notifier.DigestHourly},
+			notifs: []*notifier.NotifyOpts{
+				{ClusterID: 1, Name: "web", Namespace: "default", Status: notifier.StatusHelmDeployed},
+				{ClusterID: 1, Name: "web", Namespace: "default", Status: notifier.StatusHelmDeployed},
+			},
+			expSent:    0,
+			expDigests: map[string]int{"hourly": 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			deployment := &testDigestNotifier{}
+
+			p, repo, _ := newTestPipeline(tt.opts, deployment)
+
+			for _, opts := range tt.notifs {
+				if err := p.Notify(opts); err != nil {
+					t.Fatalf("%v", err)
+				}
+			}
+
+			if len(deployment.sent) != tt.expSent {
+				t.Errorf("expected %d notifications to be sent, got %d", tt.expSent, len(deployment.sent))
+			}
+
+			farFuture := time.Now().Add(48 * time.Hour)
+
+			entries, err := repo.ListDueNotificationDigestEntries(farFuture, farFuture)
+			if err != nil {
+				t.Fatalf("%v", err)
+			}
+
+			digests := make(map[string]int)
+
+			for _, entry := range entries {
+				digests[entry.Frequency]++
+			}
+
+			if len(digests) != len(tt.expDigests) {
+				t.Errorf("expected digest entries %v, got %v", tt.expDigests, digests)
+			}
+
+			for frequency, count := range tt.expDigests {
+				if digests[frequency] != count {
+					t.Errorf("expected %d %s digest entries, got %d", count, frequency, digests[frequency])
+				}
+			}
+		})
+	}
+}
+
+// reentrantNotifier notifies the pipeline again while the first notification is being delivered, as a
+// concurrent request about the same event would
+type reentrantNotifier struct {
+	testNotifier
+	pipeline  *Pipeline
+	reentered bool
+	innerErr  error
+}
+
+func (n *reentrantNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !n.reentered {
+		n.reentered = true
+		n.innerErr = n.pipeline.Notify(opts)
+	}
+
+	return n.testNotifier.Notify(opts)
+}
+
+func TestPipelineReservesBeforeDelivery(t *testing.T) {
+	deployment := &reentrantNotifier{}
+
+	p, _, _ := newTestPipeline(&Options{DedupWindow: 10 * time.Minute}, deployment)
+	deployment.pipeline = p
+
+	if err := p.Notify(crashedOpts("web")); err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if deployment.innerErr != nil {
+		t.Fatalf("%v", deployment.innerErr)
+	}
+
+	if len(deployment.sent) != 1 {
+		t.Errorf("expected a notification sent during the delivery of the same event to be suppressed, got %d", len(deployment.sent))
+	}
+}
+
+func TestPipelineDigestEventTime(t *testing.T) {
+	deployment := &testDigestNotifier{}
+	deployedAt := time.Date(2023, 1, 10, 11, 45, 0, 0, time.UTC)
+
+	p, repo, _ := newTestPipeline(&Options{DedupWindow: 10 * time.Minute, Digest: notifier.DigestHourly}, deployment)
+
+	err := p.Notify(&notifier.NotifyOpts{
+		ClusterID: 1,
+		Name:      "web",
+		Namespace: "default",
+		Status:    notifier.StatusHelmDeployed,
+		Timestamp: &deployedAt,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	farFuture := time.Now().Add(48 * time.Hour)
+
+	entries, err := repo.ListDueNotificationDigestEntries(farFuture, farFuture)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 digest entry, got %d", len(entries))
+	}
+
+	digest := getDigest(&digestGroup{frequency: notifier.DigestHourly, entries: entries})
+
+	if !digest.Entries[0].Time.Equal(deployedAt) {
+		t.Errorf("expected the digest entry to have the time of the event %s, got %s", deployedAt, digest.Entries[0].Time)
+	}
+}
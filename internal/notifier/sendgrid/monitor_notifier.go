@@ -0,0 +1,79 @@
+package sendgrid
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/sendgrid/sendgrid-go"
+	"github.com/sendgrid/sendgrid-go/helpers/mail"
+)
+
+type MonitorNotifier struct {
+	opts *MonitorNotifierOpts
+}
+
+type MonitorNotifierOpts struct {
+	*SharedOpts
+	MonitorStatusTemplateID string
+	Users                   []*models.User
+}
+
+func NewMonitorNotifier(opts *MonitorNotifierOpts) notifier.MonitorNotifier {
+	return &MonitorNotifier{opts}
+}
+
+func (s *MonitorNotifier) NotifyStatusChange(opts *notifier.MonitorNotifyOpts) error {
+	request := sendgrid.GetRequest(s.opts.APIKey, "/v3/mail/send", "https://api.sendgrid.com")
+	request.Method = "POST"
+
+	personalizations := make([]*mail.Personalization, 0)
+
+	var subject string
+
+	if opts.Result.LastRunResult == types.MonitorTestStatusFailed {
+		subject = fmt.Sprintf("[%s] %s is failing on cluster %s", strings.ToUpper(string(opts.Result.Severity)),
+			opts.Result.Title, opts.ClusterName)
+	} else {
+		subject = fmt.Sprintf("[Resolved] %s is passing again on cluster %s", opts.Result.Title, opts.ClusterName)
+	}
+
+	templData := map[string]interface{}{
+		"monitor_text": opts.Result.Message,
+		"cluster_url":  opts.URL,
+		"subject":      subject,
+		"preheader":    opts.Result.Message,
+		"category":     opts.Result.Category,
+		"object_id":    opts.Result.ObjectID,
+		"status":       string(opts.Result.LastRunResult),
+		"severity":     string(opts.Result.Severity),
+	}
+
+	for _, user := range s.opts.Users {
+		personalizations = append(personalizations, &mail.Personalization{
+			To: []*mail.Email{
+				{
+					Address: user.Email,
+				},
+			},
+			DynamicTemplateData: templData,
+		})
+	}
+
+	sgMail := &mail.SGMailV3{
+		Personalizations: personalizations,
+		From: &mail.Email{
+			Address: s.opts.SenderEmail,
+			Name:    "Porter Notifications",
+		},
+		TemplateID: s.opts.MonitorStatusTemplateID,
+	}
+
+	request.Body = mail.GetRequestBody(sgMail)
+
+	_, err := sendgrid.API(request)
+
+	return err
+}
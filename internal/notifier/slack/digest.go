@@ -0,0 +1,70 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func (s *DeploymentNotifier) NotifyDigest(digest *notifier.Digest) error {
+	blocks := []*SlackBlock{
+		getMarkdownBlock(fmt.Sprintf("*%s*", digest.Title())),
+		getDividerBlock(),
+	}
+
+	entries, omitted := digest.ListedEntries()
+
+	for _, entry := range entries {
+		emoji := ":white_check_mark:"
+
+		if entry.Failure {
+			emoji = ":x:"
+		}
+
+		blocks = append(blocks, getMarkdownBlock(fmt.Sprintf(
+			"%s <%s|%s> %s\n<!date^%d^{date_num} {time_secs}|%s> · %s/%s",
+			emoji,
+			entry.URL,
+			"`"+entry.Name+"`",
+			entry.Title,
+			entry.Time.Unix(),
+			entry.Time.Format("2006-01-02 15:04:05 UTC"),
+			entry.ClusterName,
+			entry.Namespace,
+		)))
+	}
+
+	if omitted > 0 {
+		blocks = append(blocks, getMarkdownBlock(fmt.Sprintf("_...and %d more_", omitted)))
+	}
+
+	payload, err := json.Marshal(&SlackPayload{
+		Blocks: blocks,
+	})
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, slackInt := range s.slackInts {
+		resp, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("slack webhook responded with status code %d", resp.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -14,6 +14,8 @@ func getSlackBlocks(opts *notifier.NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 		res = append(res, getHelmMessageBlock(opts))
 	} else if opts.Status == notifier.StatusPodCrashed {
 		res = append(res, getPodCrashedMessageBlock(opts))
+	} else {
+		res = append(res, getEventMessageBlock(opts))
 	}
 
 	res = append(
@@ -32,7 +34,8 @@ func getSlackBlocks(opts *notifier.NotifyOpts) ([]*SlackBlock, []*SlackBlock) {
 		)
 	}
 
-	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed {
+	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed ||
+		opts.Status == notifier.StatusRollback {
 		res = append(res, getMarkdownBlock(fmt.Sprintf("*Version:* %d", opts.Version)))
 	}
 
@@ -86,6 +89,24 @@ func getPodCrashedMessageBlock(opts *notifier.NotifyOpts) *SlackBlock {
 	return getMarkdownBlock(md)
 }
 
+func getEventMessageBlock(opts *notifier.NotifyOpts) *SlackBlock {
+	emoji := ":white_check_mark:"
+
+	if opts.Status.IsFailure() {
+		emoji = ":x:"
+	}
+
+	md := fmt.Sprintf(
+		"%s Your application %s %s on Porter. <%s|View the application.>",
+		emoji,
+		"`"+opts.Name+"`",
+		opts.Status.Description(),
+		opts.URL,
+	)
+
+	return getMarkdownBlock(md)
+}
+
 func getInfoBlock(opts *notifier.NotifyOpts) *SlackBlock {
 	var md string
 
@@ -94,8 +115,14 @@ func getInfoBlock(opts *notifier.NotifyOpts) *SlackBlock {
 		md = getFailedInfoMessage(opts)
 	case notifier.StatusPodCrashed:
 		md = getFailedInfoMessage(opts)
-	default:
+	case notifier.StatusHelmDeployed:
 		return nil
+	default:
+		if opts.Info == "" {
+			return nil
+		}
+
+		md = getFailedInfoMessage(opts)
 	}
 
 	return getMarkdownBlock(md)
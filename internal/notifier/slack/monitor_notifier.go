@@ -0,0 +1,81 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type MonitorNotifier struct {
+	slackInts []*integrations.SlackIntegration
+}
+
+func NewMonitorNotifier(slackInts ...*integrations.SlackIntegration) *MonitorNotifier {
+	return &MonitorNotifier{
+		slackInts: slackInts,
+	}
+}
+
+func (s *MonitorNotifier) NotifyStatusChange(opts *notifier.MonitorNotifyOpts) error {
+	res := []*SlackBlock{}
+
+	var topSectionMarkdwn string
+
+	if opts.Result.LastRunResult == types.MonitorTestStatusFailed {
+		topSectionMarkdwn = fmt.Sprintf(
+			":warning: *[%s]* %s is failing on cluster %s. <%s|View the cluster status.>",
+			strings.ToUpper(string(opts.Result.Severity)),
+			opts.Result.Title,
+			"`"+opts.ClusterName+"`",
+			opts.URL,
+		)
+	} else {
+		topSectionMarkdwn = fmt.Sprintf(
+			":white_check_mark: %s is passing again on cluster %s. <%s|View the cluster status.>",
+			opts.Result.Title,
+			"`"+opts.ClusterName+"`",
+			opts.URL,
+		)
+	}
+
+	res = append(
+		res,
+		getMarkdownBlock(topSectionMarkdwn),
+		getDividerBlock(),
+		getMarkdownBlock(fmt.Sprintf("*Category:* %s", "`"+opts.Result.Category+"`")),
+		getMarkdownBlock(fmt.Sprintf("*Object:* %s", "`"+opts.Result.ObjectID+"`")),
+	)
+
+	if opts.Result.Message != "" {
+		res = append(res, getMarkdownBlock(fmt.Sprintf("```\n%s\n```", opts.Result.Message)))
+	}
+
+	slackPayload := &SlackPayload{
+		Blocks: res,
+	}
+
+	payload, err := json.Marshal(slackPayload)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, slackInt := range s.slackInts {
+		_, err := client.Post(string(slackInt.Webhook), "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package teams
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+const (
+	colorSuccess = "2EB67D"
+	colorFailure = "E01E5A"
+
+	// text in cards is truncated to keep them readable, as Teams does not collapse long messages
+	maxInfoLength = 1000
+)
+
+// MessageCard is the card format accepted by Teams incoming webhooks
+type MessageCard struct {
+	Type            string     `json:"@type"`
+	Context         string     `json:"@context"`
+	ThemeColor      string     `json:"themeColor,omitempty"`
+	Summary         string     `json:"summary"`
+	Sections        []*Section `json:"sections"`
+	PotentialAction []*Action  `json:"potentialAction,omitempty"`
+}
+
+type Section struct {
+	ActivityTitle string  `json:"activityTitle,omitempty"`
+	Facts         []*Fact `json:"facts,omitempty"`
+	Text          string  `json:"text,omitempty"`
+	Markdown      bool    `json:"markdown"`
+}
+
+type Fact struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type Action struct {
+	Type    string    `json:"@type"`
+	Name    string    `json:"name"`
+	Targets []*Target `json:"targets"`
+}
+
+type Target struct {
+	OS  string `json:"os"`
+	URI string `json:"uri"`
+}
+
+func newMessageCard(summary, color string, sections ...*Section) *MessageCard {
+	return &MessageCard{
+		Type:       "MessageCard",
+		Context:    "http://schema.org/extensions",
+		ThemeColor: color,
+		Summary:    summary,
+		Sections:   sections,
+	}
+}
+
+func getOpenURIAction(name, url string) *Action {
+	return &Action{
+		Type: "OpenUri",
+		Name: name,
+		Targets: []*Target{
+			{
+				OS:  "default",
+				URI: url,
+			},
+		},
+	}
+}
+
+func getCodeText(text string) string {
+	if len(text) > maxInfoLength {
+		text = strings.ToValidUTF8(text[0:maxInfoLength], "") + "..."
+	}
+
+	return fmt.Sprintf("```\n%s\n```", text)
+}
+
+func postCard(teamsInts []*integrations.TeamsIntegration, card *MessageCard) error {
+	payload, err := json.Marshal(card)
+	if err != nil {
+		return err
+	}
+
+	client := &http.Client{
+		Timeout: time.Second * 5,
+	}
+
+	for _, teamsInt := range teamsInts {
+		res, err := client.Post(string(teamsInt.Webhook), "application/json", bytes.NewReader(payload))
+		if err != nil {
+			return err
+		}
+
+		res.Body.Close()
+
+		if res.StatusCode >= 400 {
+			return fmt.Errorf("teams webhook for channel %s responded with status code %d", teamsInt.Channel, res.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package teams
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+type DeploymentNotifier struct {
+	teamsInts []*integrations.TeamsIntegration
+	Config    *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(conf *types.NotificationConfig, teamsInts ...*integrations.TeamsIntegration) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		teamsInts: teamsInts,
+		Config:    conf,
+	}
+}
+
+func (t *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.IsSubscribed(t.Config, opts.Status) {
+		return nil
+	}
+
+	if len(t.teamsInts) == 0 {
+		return nil
+	}
+
+	return postCard(t.teamsInts, getDeploymentCard(opts))
+}
+
+// getDeploymentCard formats the deployment notification in the same way as the Slack blocks
+func getDeploymentCard(opts *notifier.NotifyOpts) *MessageCard {
+	var title, color, actionName string
+
+	switch opts.Status {
+	case notifier.StatusHelmDeployed:
+		title = fmt.Sprintf("🚀 Your application **%s** was successfully updated on Porter!", opts.Name)
+		color = colorSuccess
+		actionName = "View the new release"
+	case notifier.StatusHelmFailed:
+		title = fmt.Sprintf("❌ Your application **%s** failed to deploy on Porter.", opts.Name)
+		color = colorFailure
+		actionName = "View the status"
+	case notifier.StatusPodCrashed:
+		title = fmt.Sprintf("❌ Your application **%s** crashed on Porter.", opts.Name)
+		color = colorFailure
+		actionName = "View the application"
+	default:
+		title = fmt.Sprintf("✅ Your application **%s** %s on Porter.", opts.Name, opts.Status.Description())
+		color = colorSuccess
+		actionName = "View the application"
+
+		if opts.Status.IsFailure() {
+			title = fmt.Sprintf("❌ Your application **%s** %s on Porter.", opts.Name, opts.Status.Description())
+			color = colorFailure
+		}
+	}
+
+	facts := []*Fact{
+		{Name: "Name", Value: opts.Name},
+		{Name: "Namespace", Value: opts.Namespace},
+		{Name: "Cluster", Value: opts.ClusterName},
+	}
+
+	if opts.Timestamp != nil {
+		facts = append(facts, &Fact{Name: "Timestamp", Value: opts.Timestamp.Format("2006-01-02 15:04:05 UTC")})
+	}
+
+	if opts.Status == notifier.StatusHelmDeployed || opts.Status == notifier.StatusHelmFailed ||
+		opts.Status == notifier.StatusRollback {
+		facts = append(facts, &Fact{Name: "Version", Value: fmt.Sprintf("%d", opts.Version)})
+	}
+
+	section := &Section{
+		ActivityTitle: title,
+		Facts:         facts,
+		Markdown:      true,
+	}
+
+	if opts.Info != "" && opts.Status != notifier.StatusHelmDeployed {
+		section.Text = getCodeText(opts.Info)
+	}
+
+	card := newMessageCard(fmt.Sprintf("%s: %s", opts.Name, opts.Status), color, section)
+	card.PotentialAction = []*Action{getOpenURIAction(actionName, opts.URL)}
+
+	return card
+}
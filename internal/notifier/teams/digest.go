@@ -0,0 +1,48 @@
+package teams
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/internal/notifier"
+)
+
+func (t *DeploymentNotifier) NotifyDigest(digest *notifier.Digest) error {
+	if len(t.teamsInts) == 0 {
+		return nil
+	}
+
+	lines := make([]string, 0)
+	entries, omitted := digest.ListedEntries()
+
+	for _, entry := range entries {
+		emoji := "✅"
+
+		if entry.Failure {
+			emoji = "❌"
+		}
+
+		lines = append(lines, fmt.Sprintf(
+			"%s [**%s**](%s) %s (%s, %s/%s)",
+			emoji,
+			entry.Name,
+			entry.URL,
+			entry.Title,
+			entry.Time.Format("2006-01-02 15:04:05 UTC"),
+			entry.ClusterName,
+			entry.Namespace,
+		))
+	}
+
+	if omitted > 0 {
+		lines = append(lines, fmt.Sprintf("_...and %d more_", omitted))
+	}
+
+	card := newMessageCard(digest.Title(), colorSuccess, &Section{
+		ActivityTitle: digest.Title(),
+		Text:          strings.Join(lines, "\n\n"),
+		Markdown:      true,
+	})
+
+	return postCard(t.teamsInts, card)
+}
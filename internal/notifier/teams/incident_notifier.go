@@ -0,0 +1,76 @@
+package teams
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models/integrations"
+)
+
+type IncidentNotifier struct {
+	teamsInts []*integrations.TeamsIntegration
+}
+
+func NewIncidentNotifier(teamsInts ...*integrations.TeamsIntegration) *IncidentNotifier {
+	return &IncidentNotifier{
+		teamsInts: teamsInts,
+	}
+}
+
+func (t *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	if len(t.teamsInts) == 0 {
+		return nil
+	}
+
+	resourceKind := "application"
+
+	if strings.ToLower(string(incident.InvolvedObjectKind)) == "job" {
+		resourceKind = "job"
+	}
+
+	card := newMessageCard(
+		fmt.Sprintf("Your %s %s crashed on Porter", resourceKind, incident.ReleaseName),
+		colorFailure,
+		&Section{
+			ActivityTitle: fmt.Sprintf("⚠️ Your %s **%s** crashed on Porter.", resourceKind, incident.ReleaseName),
+			Facts: []*Fact{
+				{Name: "Namespace", Value: incident.ReleaseNamespace},
+				{Name: "Name", Value: incident.ReleaseName},
+				{Name: "Created at", Value: incident.CreatedAt.Format("2006-01-02 15:04:05 UTC")},
+			},
+			Text:     getCodeText(incident.Summary),
+			Markdown: true,
+		},
+	)
+
+	card.PotentialAction = []*Action{getOpenURIAction("View the incident", url)}
+
+	return postCard(t.teamsInts, card)
+}
+
+func (t *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	if len(t.teamsInts) == 0 {
+		return nil
+	}
+
+	card := newMessageCard(
+		fmt.Sprintf("The incident for application %s has been resolved", incident.ReleaseName),
+		colorSuccess,
+		&Section{
+			ActivityTitle: fmt.Sprintf("✅ The incident for application **%s** has been resolved.", incident.ReleaseName),
+			Facts: []*Fact{
+				{Name: "Namespace", Value: incident.ReleaseNamespace},
+				{Name: "Name", Value: incident.ReleaseName},
+				{Name: "Created at", Value: incident.CreatedAt.Format("2006-01-02 15:04:05 UTC")},
+				{Name: "Resolved at", Value: incident.UpdatedAt.Format("2006-01-02 15:04:05 UTC")},
+			},
+			Text:     getCodeText(incident.Summary),
+			Markdown: true,
+		},
+	)
+
+	card.PotentialAction = []*Action{getOpenURIAction("View the incident", url)}
+
+	return postCard(t.teamsInts, card)
+}
@@ -0,0 +1,169 @@
+package webhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/porter-dev/porter/internal/egress"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	// SignatureHeader contains "sha256=" followed by the hex-encoded HMAC-SHA256 of the request
+	// body, keyed by the secret of the webhook
+	SignatureHeader = "X-Porter-Signature"
+
+	// EventHeader contains the event of the payload
+	EventHeader = "X-Porter-Event"
+
+	// DeliveryHeader contains the ID of the delivery, which is the same across retries
+	DeliveryHeader = "X-Porter-Delivery"
+
+	maxAttempts    = 4
+	initialBackoff = 2 * time.Second
+)
+
+// Payload is the body sent to notification webhooks
+type Payload struct {
+	ID        string      `json:"id"`
+	Event     string      `json:"event"`
+	Timestamp time.Time   `json:"timestamp"`
+	ProjectID uint        `json:"project_id"`
+	Data      interface{} `json:"data"`
+}
+
+// Sign returns the value of the signature header for the body
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+type sender struct {
+	repo     repository.NotificationWebhookRepository
+	webhooks []*models.NotificationWebhook
+	client   *http.Client
+}
+
+func newSender(repo repository.NotificationWebhookRepository, webhooks []*models.NotificationWebhook) *sender {
+	return &sender{
+		repo:     repo,
+		webhooks: webhooks,
+		client:   egress.NewHTTPClient(10 * time.Second),
+	}
+}
+
+// send delivers the event to every webhook which receives the events of the release. Deliveries are
+// retried in the background, so the result of each delivery is only recorded in the delivery log.
+func (s *sender) send(event string, projectID, clusterID uint, name, namespace string, data interface{}) {
+	for _, webhook := range s.webhooks {
+		if !webhook.MatchesRelease(clusterID, name, namespace) {
+			continue
+		}
+
+		payload := &Payload{
+			ID:        uuid.New().String(),
+			Event:     event,
+			Timestamp: time.Now().UTC(),
+			ProjectID: projectID,
+			Data:      data,
+		}
+
+		go s.deliver(webhook, payload)
+	}
+}
+
+func (s *sender) deliver(webhook *models.NotificationWebhook, payload *Payload) {
+	delivery, err := s.repo.CreateNotificationWebhookDelivery(&models.NotificationWebhookDelivery{
+		NotificationWebhookID: webhook.ID,
+		DeliveryID:            payload.ID,
+		Event:                 payload.Event,
+	})
+	if err != nil {
+		log.Printf("error recording delivery %s to notification webhook %d: %v", payload.ID, webhook.ID, err)
+		return
+	}
+
+	body, err := json.Marshal(payload)
+
+	if err == nil {
+		backoff := initialBackoff
+
+		for delivery.Attempts < maxAttempts {
+			if delivery.Attempts > 0 {
+				time.Sleep(backoff)
+				backoff *= 2
+			}
+
+			delivery.Attempts++
+
+			var retry bool
+
+			delivery.StatusCode, retry, err = s.post(webhook, payload, body)
+
+			if err == nil || !retry {
+				break
+			}
+		}
+	}
+
+	if err != nil {
+		delivery.Error = err.Error()
+	}
+
+	completedAt := time.Now()
+	delivery.CompletedAt = &completedAt
+
+	if _, err := s.repo.UpdateNotificationWebhookDelivery(delivery); err != nil {
+		log.Printf("error recording delivery %s to notification webhook %d: %v", payload.ID, webhook.ID, err)
+	}
+}
+
+// post sends the payload once, returning whether a failed delivery should be retried
+func (s *sender) post(webhook *models.NotificationWebhook, payload *Payload, body []byte) (int, bool, error) {
+	// webhooks created before URLs were validated are not sent
+	if err := egress.ValidateHTTPSURL(webhook.URL); err != nil {
+		return 0, false, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Porter-Webhook")
+	req.Header.Set(EventHeader, payload.Event)
+	req.Header.Set(DeliveryHeader, payload.ID)
+	req.Header.Set(SignatureHeader, Sign(webhook.Secret, body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+
+	defer res.Body.Close()
+
+	// drain the body so that the connection can be reused
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<20))
+
+	if res.StatusCode >= 200 && res.StatusCode < 300 {
+		return res.StatusCode, false, nil
+	}
+
+	// other client errors will fail again, so they are not retried
+	retry := res.StatusCode >= 500 || res.StatusCode == http.StatusRequestTimeout || res.StatusCode == http.StatusTooManyRequests
+
+	return res.StatusCode, retry, fmt.Errorf("webhook responded with status code %d", res.StatusCode)
+}
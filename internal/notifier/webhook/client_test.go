@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/egress"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestPostRejectsUnsafeURLs(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{
+			name: "http url",
+			url:  "http://hooks.example.com/porter",
+		},
+		{
+			name: "link-local address",
+			url:  "https://169.254.169.254/latest/meta-data/",
+		},
+		{
+			name: "localhost",
+			url:  "https://localhost:8443/porter",
+		},
+	}
+
+	s := newSender(nil, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			payload := &Payload{ID: "delivery-id", Event: "deployment.succeeded"}
+
+			_, retry, err := s.post(&models.NotificationWebhook{URL: tt.url, Secret: []byte("secret")}, payload, []byte("{}"))
+
+			if err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+
+			if retry {
+				t.Errorf("expected invalid URL not to be retried")
+			}
+		})
+	}
+}
+
+func TestClientRejectsPrivateAddresses(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("webhook should not be delivered to %s", r.Host)
+	}))
+	defer server.Close()
+
+	// URLs are validated before they are sent, so the client is used directly to check that
+	// addresses are also rejected when they are dialed
+	_, err := newSender(nil, nil).client.Get(server.URL)
+
+	if !errors.Is(err, egress.ErrPrivateAddress) {
+		t.Errorf("expected a private address error, got %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"fmt"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// DeploymentEventData is the data of "deployment.*" events
+type DeploymentEventData struct {
+	ClusterID   uint   `json:"cluster_id"`
+	ClusterName string `json:"cluster_name"`
+	Name        string `json:"name"`
+	Namespace   string `json:"namespace"`
+	Status      string `json:"status"`
+	Info        string `json:"info,omitempty"`
+	Version     int    `json:"version,omitempty"`
+	URL         string `json:"url"`
+}
+
+type DeploymentNotifier struct {
+	sender *sender
+	Config *types.NotificationConfig
+}
+
+func NewDeploymentNotifier(
+	conf *types.NotificationConfig,
+	repo repository.NotificationWebhookRepository,
+	webhooks ...*models.NotificationWebhook,
+) *DeploymentNotifier {
+	return &DeploymentNotifier{
+		sender: newSender(repo, webhooks),
+		Config: conf,
+	}
+}
+
+func (d *DeploymentNotifier) Notify(opts *notifier.NotifyOpts) error {
+	if !notifier.IsSubscribed(d.Config, opts.Status) {
+		return nil
+	}
+
+	d.sender.send(fmt.Sprintf("deployment.%s", opts.Status), opts.ProjectID, opts.ClusterID, opts.Name, opts.Namespace,
+		&DeploymentEventData{
+			ClusterID:   opts.ClusterID,
+			ClusterName: opts.ClusterName,
+			Name:        opts.Name,
+			Namespace:   opts.Namespace,
+			Status:      string(opts.Status),
+			Info:        opts.Info,
+			Version:     opts.Version,
+			URL:         opts.URL,
+		},
+	)
+
+	return nil
+}
@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+const (
+	EventIncidentCreated  = "incident.created"
+	EventIncidentResolved = "incident.resolved"
+)
+
+// IncidentEventData is the data of "incident.*" events
+type IncidentEventData struct {
+	ClusterID   uint            `json:"cluster_id"`
+	ClusterName string          `json:"cluster_name"`
+	Incident    *types.Incident `json:"incident"`
+	URL         string          `json:"url"`
+}
+
+type IncidentNotifier struct {
+	sender  *sender
+	cluster *models.Cluster
+}
+
+func NewIncidentNotifier(
+	cluster *models.Cluster,
+	repo repository.NotificationWebhookRepository,
+	webhooks ...*models.NotificationWebhook,
+) *IncidentNotifier {
+	return &IncidentNotifier{
+		sender:  newSender(repo, webhooks),
+		cluster: cluster,
+	}
+}
+
+func (i *IncidentNotifier) NotifyNew(incident *types.Incident, url string) error {
+	i.notify(EventIncidentCreated, incident, url)
+	return nil
+}
+
+func (i *IncidentNotifier) NotifyResolved(incident *types.Incident, url string) error {
+	i.notify(EventIncidentResolved, incident, url)
+	return nil
+}
+
+func (i *IncidentNotifier) notify(event string, incident *types.Incident, url string) {
+	i.sender.send(event, i.cluster.ProjectID, i.cluster.ID, incident.ReleaseName, incident.ReleaseNamespace,
+		&IncidentEventData{
+			ClusterID:   i.cluster.ID,
+			ClusterName: i.cluster.Name,
+			Incident:    incident,
+			URL:         url,
+		},
+	)
+}
@@ -0,0 +1,469 @@
+package oauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// oidcKeyRefreshInterval limits how often the signing keys of the provider are fetched again
+	// when an ID token is signed by an unknown key
+	oidcKeyRefreshInterval = time.Minute
+
+	// oidcClockSkew is the leeway given to the time claims of ID tokens
+	oidcClockSkew = time.Minute
+)
+
+// OIDCConfig is the configuration of a generic OpenID Connect provider
+type OIDCConfig struct {
+	Config
+
+	// DiscoveryURL is the URL of the OpenID configuration of the provider, usually
+	// <issuer>/.well-known/openid-configuration
+	DiscoveryURL string
+
+	// ProviderName is the name of the provider shown on the login page
+	ProviderName string
+
+	// EmailClaim and GroupsClaim are the ID token claims which hold the email of the user and the
+	// groups the user belongs to
+	EmailClaim  string
+	GroupsClaim string
+
+	// RoleMappings assign project roles to the members of groups of the provider
+	RoleMappings []*OIDCRoleMapping
+
+	// LinkExistingUsers allows users who signed up with another login method to log in with the
+	// provider, if the provider has verified their email
+	LinkExistingUsers bool
+}
+
+// OIDCClient logs users in with a generic OpenID Connect provider. The provider configuration is
+// discovered when the client is first used, so that the server starts even if the provider is
+// unavailable.
+type OIDCClient struct {
+	*OIDCConfig
+
+	httpClient *http.Client
+
+	mu            sync.Mutex
+	discovery     *oidcDiscovery
+	keys          map[string]interface{}
+	keysFetchedAt time.Time
+}
+
+type oidcDiscovery struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type oidcJWK struct {
+	Kid string `json:"kid"`
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// OIDCIdentity is the identity of a user read from a verified ID token
+type OIDCIdentity struct {
+	// Subject is the unique id of the user at the provider
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Groups        []string
+}
+
+func NewOIDCClient(cfg *OIDCConfig) *OIDCClient {
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "profile", "email"}
+	}
+
+	if cfg.EmailClaim == "" {
+		cfg.EmailClaim = "email"
+	}
+
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+
+	if cfg.ProviderName == "" {
+		cfg.ProviderName = "SSO"
+	}
+
+	return &OIDCClient{
+		OIDCConfig: cfg,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// OAuth2Config returns the OAuth2 configuration of the provider, discovering its endpoints if
+// they are not known yet
+func (c *OIDCClient) OAuth2Config(ctx context.Context) (*oauth2.Config, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &oauth2.Config{
+		ClientID:     c.ClientID,
+		ClientSecret: c.ClientSecret,
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  discovery.AuthorizationEndpoint,
+			TokenURL: discovery.TokenEndpoint,
+		},
+		RedirectURL: c.BaseURL + "/api/oauth/oidc/callback",
+		Scopes:      c.Scopes,
+	}, nil
+}
+
+// VerifyIDToken checks the signature, issuer, audience, expiry and nonce of an ID token, and
+// returns the identity of the user from its claims
+func (c *OIDCClient) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*OIDCIdentity, error) {
+	discovery, err := c.getDiscovery(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := &jwt.Parser{
+		// the time claims are verified below with some leeway for clock skew
+		SkipClaimsValidation: true,
+	}
+
+	token, err := parser.Parse(rawIDToken, func(token *jwt.Token) (interface{}, error) {
+		switch token.Method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS, *jwt.SigningMethodECDSA:
+		default:
+			return nil, fmt.Errorf("unsupported signing algorithm %s", token.Method.Alg())
+		}
+
+		kid, _ := token.Header["kid"].(string)
+
+		return c.getKey(ctx, discovery, kid)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid ID token: %w", err)
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("invalid ID token")
+	}
+
+	if iss, _ := claims["iss"].(string); iss != discovery.Issuer {
+		return nil, fmt.Errorf("ID token issuer %s does not match %s", iss, discovery.Issuer)
+	}
+
+	if !hasAudience(claims["aud"], c.ClientID) {
+		return nil, fmt.Errorf("ID token was not issued for this client")
+	}
+
+	now := time.Now()
+
+	if exp, ok := getNumericClaim(claims, "exp"); !ok || now.After(exp.Add(oidcClockSkew)) {
+		return nil, fmt.Errorf("ID token is expired")
+	}
+
+	if nbf, ok := getNumericClaim(claims, "nbf"); ok && now.Add(oidcClockSkew).Before(nbf) {
+		return nil, fmt.Errorf("ID token is not valid yet")
+	}
+
+	if tokenNonce, _ := claims["nonce"].(string); tokenNonce != nonce {
+		return nil, fmt.Errorf("ID token nonce does not match")
+	}
+
+	identity := &OIDCIdentity{}
+
+	identity.Subject, _ = claims["sub"].(string)
+
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("ID token has no subject")
+	}
+
+	identity.Email, _ = claims[c.EmailClaim].(string)
+
+	if identity.Email == "" {
+		return nil, fmt.Errorf("ID token has no %s claim", c.EmailClaim)
+	}
+
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		identity.EmailVerified = verified
+	case string:
+		identity.EmailVerified, _ = strconv.ParseBool(verified)
+	}
+
+	identity.Groups = getStringListClaim(claims[c.GroupsClaim])
+
+	return identity, nil
+}
+
+func (c *OIDCClient) getDiscovery(ctx context.Context) (*oidcDiscovery, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.discovery != nil {
+		return c.discovery, nil
+	}
+
+	discovery := &oidcDiscovery{}
+
+	if err := c.getJSON(ctx, c.DiscoveryURL, discovery); err != nil {
+		return nil, fmt.Errorf("could not read OIDC discovery document: %w", err)
+	}
+
+	if discovery.Issuer == "" || discovery.AuthorizationEndpoint == "" ||
+		discovery.TokenEndpoint == "" || discovery.JWKSURI == "" {
+		return nil, fmt.Errorf("OIDC discovery document is missing required fields")
+	}
+
+	c.discovery = discovery
+
+	return discovery, nil
+}
+
+// getKey returns the signing key with the given id, and fetches the keys of the provider again if
+// the key is not known, since providers rotate their keys
+func (c *OIDCClient) getKey(ctx context.Context, discovery *oidcDiscovery, kid string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key, ok := c.findKey(kid); ok {
+		return key, nil
+	}
+
+	if time.Since(c.keysFetchedAt) < oidcKeyRefreshInterval {
+		return nil, fmt.Errorf("signing key %s not found", kid)
+	}
+
+	keySet := struct {
+		Keys []oidcJWK `json:"keys"`
+	}{}
+
+	if err := c.getJSON(ctx, discovery.JWKSURI, &keySet); err != nil {
+		return nil, fmt.Errorf("could not read OIDC signing keys: %w", err)
+	}
+
+	c.keys = make(map[string]interface{})
+	c.keysFetchedAt = time.Now()
+
+	for _, jwk := range keySet.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		key, err := jwk.publicKey()
+		if err != nil {
+			continue
+		}
+
+		c.keys[jwk.Kid] = key
+	}
+
+	if key, ok := c.findKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("signing key %s not found", kid)
+}
+
+// findKey returns the key with the given id. Tokens without a key id can only be verified if the
+// provider has a single key.
+func (c *OIDCClient) findKey(kid string) (interface{}, bool) {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+
+	key, ok := c.keys[kid]
+
+	return key, ok
+}
+
+func (c *OIDCClient) getJSON(ctx context.Context, url string, res interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request to %s failed with status code %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(res)
+}
+
+func (k *oidcJWK) publicKey() (interface{}, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %s", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: curve,
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %s", k.Kty)
+}
+
+// hasAudience checks the "aud" claim, which is either a single audience or a list of audiences
+func hasAudience(aud interface{}, clientID string) bool {
+	for _, val := range getStringListClaim(aud) {
+		if val == clientID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func getNumericClaim(claims jwt.MapClaims, name string) (time.Time, bool) {
+	switch val := claims[name].(type) {
+	case float64:
+		return time.Unix(int64(val), 0), true
+	case json.Number:
+		i, err := val.Int64()
+
+		return time.Unix(i, 0), err == nil
+	}
+
+	return time.Time{}, false
+}
+
+// getStringListClaim reads a claim which is either a list of strings, or a single string with
+// comma-separated values
+func getStringListClaim(claim interface{}) []string {
+	res := make([]string, 0)
+
+	switch val := claim.(type) {
+	case string:
+		for _, item := range strings.Split(val, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				res = append(res, item)
+			}
+		}
+	case []interface{}:
+		for _, item := range val {
+			if str, ok := item.(string); ok && str != "" {
+				res = append(res, str)
+			}
+		}
+	}
+
+	return res
+}
+
+// OIDCRoleMapping assigns a role in a project to the members of a group of the provider
+type OIDCRoleMapping struct {
+	Group     string
+	ProjectID uint
+
+	// Role is either a preset role (admin, developer or viewer), or the unique id of a policy
+	// which is assigned as a custom role
+	Role string
+}
+
+// ParseOIDCRoleMappings parses a comma-separated list of mappings in the form
+// group=project_id:role, such as "platform=1:admin,deployers=1:<policy id>"
+func ParseOIDCRoleMappings(mappings string) ([]*OIDCRoleMapping, error) {
+	res := make([]*OIDCRoleMapping, 0)
+
+	for _, mapping := range strings.Split(mappings, ",") {
+		mapping = strings.TrimSpace(mapping)
+
+		if mapping == "" {
+			continue
+		}
+
+		group, target, ok := strings.Cut(mapping, "=")
+
+		if !ok {
+			return nil, fmt.Errorf("invalid role mapping %s: expected group=project_id:role", mapping)
+		}
+
+		projectIDStr, role, ok := strings.Cut(target, ":")
+
+		if !ok || strings.TrimSpace(group) == "" || strings.TrimSpace(role) == "" {
+			return nil, fmt.Errorf("invalid role mapping %s: expected group=project_id:role", mapping)
+		}
+
+		projectID, err := strconv.ParseUint(strings.TrimSpace(projectIDStr), 10, 64)
+		if err != nil || projectID == 0 {
+			return nil, fmt.Errorf("invalid project id in role mapping %s", mapping)
+		}
+
+		res = append(res, &OIDCRoleMapping{
+			Group:     strings.TrimSpace(group),
+			ProjectID: uint(projectID),
+			Role:      strings.TrimSpace(role),
+		})
+	}
+
+	return res, nil
+}
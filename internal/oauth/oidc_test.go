@@ -0,0 +1,264 @@
+package oauth_test
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testOIDCClientID = "porter"
+	testOIDCKeyID    = "test-key"
+	testOIDCNonce    = "test-nonce"
+)
+
+// mockOIDCProvider serves the discovery document and signing keys of an OIDC provider, and
+// issues ID tokens signed by its key
+type mockOIDCProvider struct {
+	server *httptest.Server
+	key    *rsa.PrivateKey
+}
+
+func newMockOIDCProvider(t *testing.T) *mockOIDCProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	provider := &mockOIDCProvider{key: key}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 provider.server.URL,
+			"authorization_endpoint": provider.server.URL + "/authorize",
+			"token_endpoint":         provider.server.URL + "/token",
+			"jwks_uri":               provider.server.URL + "/keys",
+		})
+	})
+
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kid": testOIDCKeyID,
+					"kty": "RSA",
+					"use": "sig",
+					"alg": "RS256",
+					"n":   base64.RawURLEncoding.EncodeToString(key.PublicKey.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.PublicKey.E)).Bytes()),
+				},
+			},
+		})
+	})
+
+	provider.server = httptest.NewServer(mux)
+
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+func (p *mockOIDCProvider) client() *oauth.OIDCClient {
+	return oauth.NewOIDCClient(&oauth.OIDCConfig{
+		Config: oauth.Config{
+			ClientID:     testOIDCClientID,
+			ClientSecret: "secret",
+			BaseURL:      "http://localhost:8080",
+		},
+		DiscoveryURL: p.server.URL + "/.well-known/openid-configuration",
+	})
+}
+
+// claims returns the claims of a valid ID token, which are changed by the test cases
+func (p *mockOIDCProvider) claims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":            p.server.URL,
+		"sub":            "user-1",
+		"aud":            testOIDCClientID,
+		"exp":            time.Now().Add(time.Hour).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          testOIDCNonce,
+		"email":          "user@example.com",
+		"email_verified": true,
+		"groups":         []string{"engineering", "deployers"},
+	}
+}
+
+func (p *mockOIDCProvider) sign(t *testing.T, claims jwt.MapClaims, key *rsa.PrivateKey) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = testOIDCKeyID
+
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	return signed
+}
+
+func TestOIDCOAuth2Config(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+
+	conf, err := provider.client().OAuth2Config(context.Background())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, provider.server.URL+"/authorize", conf.Endpoint.AuthURL)
+	assert.Equal(t, provider.server.URL+"/token", conf.Endpoint.TokenURL)
+	assert.Equal(t, "http://localhost:8080/api/oauth/oidc/callback", conf.RedirectURL)
+	assert.Equal(t, []string{"openid", "profile", "email"}, conf.Scopes)
+}
+
+func TestOIDCVerifyIDToken(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+	client := provider.client()
+
+	identity, err := client.VerifyIDToken(context.Background(), provider.sign(t, provider.claims(), provider.key), testOIDCNonce)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, &oauth.OIDCIdentity{
+		Subject:       "user-1",
+		Email:         "user@example.com",
+		EmailVerified: true,
+		Groups:        []string{"engineering", "deployers"},
+	}, identity)
+}
+
+func TestOIDCVerifyIDTokenAudienceList(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+
+	claims := provider.claims()
+	claims["aud"] = []string{"other-client", testOIDCClientID}
+
+	_, err := provider.client().VerifyIDToken(context.Background(), provider.sign(t, claims, provider.key), testOIDCNonce)
+
+	assert.NoError(t, err)
+}
+
+func TestOIDCVerifyIDTokenInvalid(t *testing.T) {
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	tests := []struct {
+		description string
+		claims      func(claims jwt.MapClaims)
+		key         *rsa.PrivateKey
+		nonce       string
+	}{
+		{
+			description: "wrong audience",
+			claims:      func(claims jwt.MapClaims) { claims["aud"] = "other-client" },
+		},
+		{
+			description: "wrong issuer",
+			claims:      func(claims jwt.MapClaims) { claims["iss"] = "https://attacker.example.com" },
+		},
+		{
+			description: "expired token",
+			claims:      func(claims jwt.MapClaims) { claims["exp"] = time.Now().Add(-time.Hour).Unix() },
+		},
+		{
+			description: "missing expiry",
+			claims:      func(claims jwt.MapClaims) { delete(claims, "exp") },
+		},
+		{
+			description: "wrong nonce",
+			claims:      func(claims jwt.MapClaims) {},
+			nonce:       "other-nonce",
+		},
+		{
+			description: "missing email",
+			claims:      func(claims jwt.MapClaims) { delete(claims, "email") },
+		},
+		{
+			description: "signed by another key",
+			claims:      func(claims jwt.MapClaims) {},
+			key:         otherKey,
+		},
+	}
+
+	provider := newMockOIDCProvider(t)
+	client := provider.client()
+
+	for _, test := range tests {
+		claims := provider.claims()
+		test.claims(claims)
+
+		key := provider.key
+
+		if test.key != nil {
+			key = test.key
+		}
+
+		nonce := testOIDCNonce
+
+		if test.nonce != "" {
+			nonce = test.nonce
+		}
+
+		_, err := client.VerifyIDToken(context.Background(), provider.sign(t, claims, key), nonce)
+
+		assert.Error(t, err, test.description)
+	}
+}
+
+func TestOIDCVerifyIDTokenRejectsHMAC(t *testing.T) {
+	provider := newMockOIDCProvider(t)
+
+	// a token signed with HMAC must not be verified with the public key as secret
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, provider.claims())
+	token.Header["kid"] = testOIDCKeyID
+
+	signed, err := token.SignedString(provider.key.PublicKey.N.Bytes())
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = provider.client().VerifyIDToken(context.Background(), signed, testOIDCNonce)
+
+	assert.Error(t, err)
+}
+
+func TestParseOIDCRoleMappings(t *testing.T) {
+	mappings, err := oauth.ParseOIDCRoleMappings("platform=1:admin, deployers = 2:abc123 ,")
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	assert.Equal(t, []*oauth.OIDCRoleMapping{
+		{
+			Group:     "platform",
+			ProjectID: 1,
+			Role:      "admin",
+		},
+		{
+			Group:     "deployers",
+			ProjectID: 2,
+			Role:      "abc123",
+		},
+	}, mappings)
+
+	for _, invalid := range []string{"platform", "platform=admin", "platform=0:admin", "=1:admin", "platform=1:"} {
+		_, err := oauth.ParseOIDCRoleMappings(invalid)
+
+		assert.Error(t, err, invalid)
+	}
+}
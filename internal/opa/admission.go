@@ -0,0 +1,328 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/pkg/logger"
+	"github.com/stefanmcshane/helm/pkg/release"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/yaml"
+)
+
+// AdmissionMode is how policy failures are handled when a release is deployed
+type AdmissionMode string
+
+const (
+	// AdmissionModeEnforce rejects releases which fail any policy
+	AdmissionModeEnforce AdmissionMode = "enforce"
+
+	// AdmissionModeAudit deploys releases which fail policies, returning the failures as warnings
+	AdmissionModeAudit AdmissionMode = "audit"
+)
+
+// objectKinds maps the kind of rendered objects to the policy kind evaluated against them
+var objectKinds = map[string]KubernetesBuiltInKind{
+	"DaemonSet":               Daemonset,
+	"Deployment":              Deployment,
+	"StatefulSet":             StatefulSet,
+	"Service":                 Service,
+	"Ingress":                 Ingress,
+	"HorizontalPodAutoscaler": HorizontalPodAutoscaler,
+	"PodDisruptionBudget":     PodDisruptionBudget,
+	"NetworkPolicy":           NetworkPolicy,
+}
+
+var manifestSeparator = regexp.MustCompile(`(?m)^---\s*$`)
+
+// AdmissionError is returned when a release fails one or more policies in enforce mode
+type AdmissionError struct {
+	Failures []*OPARecommenderQueryResult
+}
+
+func (e *AdmissionError) Error() string {
+	return fmt.Sprintf("release rejected by %d policy violation(s): %s", len(e.Failures), strings.Join(e.Messages(), "; "))
+}
+
+// Messages returns a message describing each failure
+func (e *AdmissionError) Messages() []string {
+	messages := make([]string, 0)
+
+	for _, failure := range e.Failures {
+		messages = append(messages, fmt.Sprintf("%s (%s): %s", failure.PolicyTitle, failure.ObjectID, failure.PolicyMessage))
+	}
+
+	return messages
+}
+
+// ReleaseAdmitter evaluates the policies against a release rendered by a dry run, before it is installed
+// or upgraded. Helm release policies are evaluated against the release, while daemonset and resource kind
+// policies are evaluated against each matching object of the manifest, with related objects taken from the
+// same manifest. Pod, crd_list and resource policies depend on the state of the cluster, so they are only
+// evaluated by the recommender.
+type ReleaseAdmitter struct {
+	policies *KubernetesPolicies
+	cluster  *models.Cluster
+	mode     AdmissionMode
+	logger   *logger.Logger
+}
+
+func NewReleaseAdmitter(
+	policies *KubernetesPolicies,
+	cluster *models.Cluster,
+	mode AdmissionMode,
+	logger *logger.Logger,
+) *ReleaseAdmitter {
+	return &ReleaseAdmitter{policies, cluster, mode, logger}
+}
+
+// ValidateRelease evaluates the policies along with the custom policy collections of a project against a
+// release rendered by a dry run. Custom collections which fail to compile are logged and skipped, which
+// includes collections stored before their builtins were restricted.
+func (p *KubernetesPolicies) ValidateRelease(
+	rel *release.Release,
+	collections []*types.OPAPolicyCollection,
+	cluster *models.Cluster,
+	mode string,
+	logger *logger.Logger,
+) ([]string, error) {
+	// collections are compiled with the same capabilities when they are created, so compilation errors
+	// are not expected here
+	policies, errs := p.WithCustomPolicies(collections)
+
+	for _, err := range errs {
+		logger.Warn().Msgf("skipping policy collection during admission: %v", err)
+	}
+
+	return NewReleaseAdmitter(policies, cluster, AdmissionMode(mode), logger).ValidateRelease(rel)
+}
+
+// ValidateRelease returns an AdmissionError if the release fails any policy in enforce mode, while in
+// audit mode the failures are returned as warnings. Releases which cannot be evaluated are rejected in
+// enforce mode, and deployed with a warning in audit mode.
+func (a *ReleaseAdmitter) ValidateRelease(rel *release.Release) ([]string, error) {
+	objects, err := decodeManifest(rel.Manifest)
+	if err != nil {
+		return a.evalError(rel, fmt.Errorf("error decoding manifest of release %s: %w", rel.Name, err))
+	}
+
+	failures := make([]*OPARecommenderQueryResult, 0)
+
+	for name, collection := range a.policies.Policies {
+		if s := collection.Match.KubernetesService; s != "" && strings.ToLower(string(a.cluster.ToClusterType().Service)) != s {
+			continue
+		}
+
+		var results []*OPARecommenderQueryResult
+
+		switch {
+		case collection.Kind == HelmRelease:
+			results, err = evalHelmReleaseAdmission(name, collection, rel)
+		case collection.Kind == Daemonset || (IsResourceKind(collection.Kind) && collection.Kind != Resource):
+			results, err = evalObjectsAdmission(name, collection, rel, objects)
+		default:
+			continue
+		}
+
+		if err != nil {
+			return a.evalError(rel, fmt.Errorf("error evaluating policies of %s: %w", name, err))
+		}
+
+		for _, res := range results {
+			if !res.Allow {
+				failures = append(failures, res)
+			}
+		}
+	}
+
+	if len(failures) == 0 {
+		return nil, nil
+	}
+
+	admissionErr := &AdmissionError{failures}
+
+	if a.mode == AdmissionModeAudit {
+		a.logger.Warn().Msgf("release %s in namespace %s of cluster %d deployed in audit mode: %s",
+			rel.Name, rel.Namespace, a.cluster.ID, admissionErr.Error())
+
+		return admissionErr.Messages(), nil
+	}
+
+	return nil, admissionErr
+}
+
+// evalError returns err in enforce mode, so that releases which cannot be evaluated are not deployed
+func (a *ReleaseAdmitter) evalError(rel *release.Release, err error) ([]string, error) {
+	if a.mode == AdmissionModeAudit {
+		a.logger.Warn().Msgf("release %s in namespace %s of cluster %d deployed in audit mode without evaluating policies: %v",
+			rel.Name, rel.Namespace, a.cluster.ID, err)
+
+		return []string{fmt.Sprintf("policies were not evaluated: %v", err)}, nil
+	}
+
+	return nil, err
+}
+
+func evalHelmReleaseAdmission(name string, collection KubernetesOPAQueryCollection, rel *release.Release) ([]*OPARecommenderQueryResult, error) {
+	if rel.Chart == nil || (collection.Match.Namespace != "" && collection.Match.Namespace != rel.Namespace) {
+		return nil, nil
+	}
+
+	if collection.Match.Name != "" && collection.Match.Name != rel.Name {
+		return nil, nil
+	} else if collection.Match.Name == "" && collection.Match.ChartName != rel.Chart.Name() {
+		return nil, nil
+	}
+
+	res := make([]*OPARecommenderQueryResult, 0)
+
+	for _, query := range collection.Queries {
+		rawQueryRes, err := evalAdmissionQuery(query, map[string]interface{}{
+			"version":   rel.Chart.Metadata.Version,
+			"values":    rel.Config,
+			"name":      rel.Name,
+			"namespace": rel.Namespace,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		if rawQueryRes != nil {
+			res = append(res, rawQueryResToRecommenderQueryResult(
+				rawQueryRes,
+				fmt.Sprintf("helm_release/%s/%s/%s", rel.Namespace, rel.Name, rawQueryRes.PolicyID),
+				name,
+				collection,
+			))
+		}
+	}
+
+	return res, nil
+}
+
+func evalObjectsAdmission(
+	name string,
+	collection KubernetesOPAQueryCollection,
+	rel *release.Release,
+	objects []*unstructured.Unstructured,
+) ([]*OPARecommenderQueryResult, error) {
+	res := make([]*OPARecommenderQueryResult, 0)
+
+	for _, obj := range objects {
+		if objectKinds[obj.GetKind()] != collection.Kind {
+			continue
+		}
+
+		namespace := obj.GetNamespace()
+
+		if namespace == "" {
+			namespace = rel.Namespace
+		}
+
+		if collection.Match.Namespace != "" && collection.Match.Namespace != namespace {
+			continue
+		}
+
+		if !matchesLabels(obj.GetLabels(), collection.Match.Labels) {
+			continue
+		}
+
+		input := make(map[string]interface{})
+
+		for k, v := range obj.Object {
+			input[k] = v
+		}
+
+		if len(collection.Match.Related) > 0 {
+			relatedInput := make(map[string]interface{})
+
+			for _, relatedKind := range collection.Match.Related {
+				relatedObjs := make([]interface{}, 0)
+
+				for _, relatedObj := range objects {
+					if string(objectKinds[relatedObj.GetKind()]) == relatedKind {
+						relatedObjs = append(relatedObjs, relatedObj.Object)
+					}
+				}
+
+				relatedInput[relatedKind] = relatedObjs
+			}
+
+			input["related"] = relatedInput
+		}
+
+		for _, query := range collection.Queries {
+			rawQueryRes, err := evalAdmissionQuery(query, input)
+			if err != nil {
+				return nil, err
+			}
+
+			if rawQueryRes != nil {
+				res = append(res, rawQueryResToRecommenderQueryResult(
+					rawQueryRes,
+					fmt.Sprintf("%s/%s/%s/%s", collection.Kind, namespace, obj.GetName(), rawQueryRes.PolicyID),
+					name,
+					collection,
+				))
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func evalAdmissionQuery(query rego.PreparedEvalQuery, input map[string]interface{}) (*rawQueryResult, error) {
+	results, err := query.Eval(context.Background(), rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) != 1 {
+		return nil, nil
+	}
+
+	rawQueryRes := &rawQueryResult{}
+
+	if err := mapstructure.Decode(results[0].Expressions[0].Value, rawQueryRes); err != nil {
+		return nil, err
+	}
+
+	return rawQueryRes, nil
+}
+
+func matchesLabels(labels, selector map[string]string) bool {
+	for k, v := range selector {
+		if labels[k] != v {
+			return false
+		}
+	}
+
+	return true
+}
+
+// decodeManifest splits the multi-document manifest of a release into objects
+func decodeManifest(manifest string) ([]*unstructured.Unstructured, error) {
+	res := make([]*unstructured.Unstructured, 0)
+
+	for _, doc := range manifestSeparator.Split(manifest, -1) {
+		obj := make(map[string]interface{})
+
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return nil, err
+		}
+
+		if len(obj) == 0 {
+			continue
+		}
+
+		res = append(res, &unstructured.Unstructured{Object: obj})
+	}
+
+	return res, nil
+}
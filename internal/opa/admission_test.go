@@ -0,0 +1,229 @@
+package opa_test
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/opa"
+	"github.com/porter-dev/porter/pkg/logger"
+	"github.com/stefanmcshane/helm/pkg/release"
+)
+
+const deploymentReplicasModule = `package custom.deployment_replicas
+
+POLICY_ID := "deployment_replicas"
+
+POLICY_TITLE := "Deployments should run at least 2 replicas"
+
+allow {
+	input.spec.replicas >= 2
+}
+`
+
+const podDenyModule = `package custom.pod_deny
+
+POLICY_ID := "pod_deny"
+
+allow {
+	false
+}
+`
+
+// metadataModule calls a network builtin, so collections using it can only have been stored before
+// builtins were restricted
+const metadataModule = `package custom.metadata
+
+POLICY_ID := "metadata"
+
+allow {
+	http.send({"method": "get", "url": "http://169.254.169.254/latest/meta-data/"}).status_code == 200
+}
+`
+
+const renderedManifest = `---
+# Source: web/templates/service.yaml
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+  labels:
+    porter.run/chart-name: web
+spec:
+  ports:
+  - port: 80
+---
+# Source: web/templates/deployment.yaml
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  labels:
+    porter.run/chart-name: web
+spec:
+  replicas: %s
+`
+
+func renderRelease(replicas string) *release.Release {
+	return &release.Release{
+		Name:      "web",
+		Namespace: "default",
+		Manifest:  fmt.Sprintf(renderedManifest, replicas),
+	}
+}
+
+func TestValidateRelease(t *testing.T) {
+	deploymentCollection := &types.OPAPolicyCollection{
+		Name: "deployment-replicas",
+		Kind: "deployment",
+		Match: types.OPAPolicyMatch{
+			Labels: map[string]string{"porter.run/chart-name": "web"},
+		},
+		Policies: []*types.OPAPolicy{
+			{Name: "custom.deployment_replicas", Module: deploymentReplicasModule},
+		},
+	}
+
+	podCollection := &types.OPAPolicyCollection{
+		Name:  "pod-deny",
+		Kind:  "pod",
+		Match: types.OPAPolicyMatch{Namespace: "default"},
+		Policies: []*types.OPAPolicy{
+			{Name: "custom.pod_deny", Module: podDenyModule},
+		},
+	}
+
+	metadataCollection := &types.OPAPolicyCollection{
+		Name: "metadata",
+		Kind: "deployment",
+		Match: types.OPAPolicyMatch{
+			Labels: map[string]string{"porter.run/chart-name": "web"},
+		},
+		Policies: []*types.OPAPolicy{
+			{Name: "custom.metadata", Module: metadataModule},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		mode        opa.AdmissionMode
+		collections []*types.OPAPolicyCollection
+		rel         *release.Release
+		wantErr     bool
+		wantAdmErr  bool
+
+		// each warning must start with the expected warning at the same index
+		wantWarnings []string
+	}{
+		{
+			name:        "enforce rejects a failing release",
+			mode:        opa.AdmissionModeEnforce,
+			collections: []*types.OPAPolicyCollection{deploymentCollection},
+			rel:         renderRelease("1"),
+			wantErr:     true,
+			wantAdmErr:  true,
+		},
+		{
+			name:        "enforce admits a passing release",
+			mode:        opa.AdmissionModeEnforce,
+			collections: []*types.OPAPolicyCollection{deploymentCollection},
+			rel:         renderRelease("3"),
+		},
+		{
+			name:        "audit admits a failing release with a warning",
+			mode:        opa.AdmissionModeAudit,
+			collections: []*types.OPAPolicyCollection{deploymentCollection},
+			rel:         renderRelease("1"),
+			wantWarnings: []string{
+				"Deployments should run at least 2 replicas (deployment/default/web/deployment_replicas): ",
+			},
+		},
+		{
+			name:        "audit admits a passing release without warnings",
+			mode:        opa.AdmissionModeAudit,
+			collections: []*types.OPAPolicyCollection{deploymentCollection},
+			rel:         renderRelease("3"),
+		},
+		{
+			name:        "collections calling network builtins are skipped",
+			mode:        opa.AdmissionModeEnforce,
+			collections: []*types.OPAPolicyCollection{metadataCollection},
+			rel:         renderRelease("1"),
+		},
+		{
+			name:        "pod policies are skipped at admission",
+			mode:        opa.AdmissionModeEnforce,
+			collections: []*types.OPAPolicyCollection{podCollection},
+			rel:         renderRelease("1"),
+		},
+		{
+			name:        "enforce rejects a manifest which cannot be decoded",
+			mode:        opa.AdmissionModeEnforce,
+			collections: []*types.OPAPolicyCollection{deploymentCollection},
+			rel:         renderRelease("[1"),
+			wantErr:     true,
+		},
+		{
+			name:        "audit admits a manifest which cannot be decoded with a warning",
+			mode:        opa.AdmissionModeAudit,
+			collections: []*types.OPAPolicyCollection{deploymentCollection},
+			rel:         renderRelease("[1"),
+			wantWarnings: []string{
+				"policies were not evaluated: error decoding manifest of release web",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			policies := &opa.KubernetesPolicies{
+				Policies: make(map[string]opa.KubernetesOPAQueryCollection),
+			}
+
+			warnings, err := policies.ValidateRelease(tt.rel, tt.collections, &models.Cluster{}, string(tt.mode), logger.NewConsole(false))
+
+			if !hasWarnings(warnings, tt.wantWarnings) {
+				t.Errorf("expected warnings %q, got %q", tt.wantWarnings, warnings)
+			}
+
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("expected no error, got %v", err)
+				}
+
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("expected an error, got nil")
+			}
+
+			var admissionErr *opa.AdmissionError
+
+			if isAdmErr := errors.As(err, &admissionErr); isAdmErr != tt.wantAdmErr {
+				t.Fatalf("expected admission error to be %t, got %v", tt.wantAdmErr, err)
+			}
+
+			if tt.wantAdmErr && len(admissionErr.Failures) != 1 {
+				t.Errorf("expected 1 failure, got %d", len(admissionErr.Failures))
+			}
+		})
+	}
+}
+
+func hasWarnings(warnings, wantWarnings []string) bool {
+	if len(warnings) != len(wantWarnings) {
+		return false
+	}
+
+	for i, warning := range warnings {
+		if !strings.HasPrefix(warning, wantWarnings[i]) {
+			return false
+		}
+	}
+
+	return true
+}
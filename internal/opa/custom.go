@@ -0,0 +1,119 @@
+package opa
+
+import (
+	"fmt"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"github.com/porter-dev/porter/api/types"
+)
+
+// CustomCollectionPrefix is prepended to the names of the policy collections uploaded by projects,
+// so that they never shadow the built-in collections
+const CustomCollectionPrefix = "custom."
+
+// CompileCustomPolicyCollection validates a policy collection uploaded by a project and compiles
+// each of its rego modules
+func CompileCustomPolicyCollection(collection *types.OPAPolicyCollection) (KubernetesOPAQueryCollection, error) {
+	kind := KubernetesBuiltInKind(collection.Kind)
+	match := MatchParameters{
+		KubernetesService: collection.Match.KubernetesService,
+		Name:              collection.Match.Name,
+		Namespace:         collection.Match.Namespace,
+		ChartName:         collection.Match.ChartName,
+		Labels:            collection.Match.Labels,
+		Group:             collection.Match.Group,
+		Version:           collection.Match.Version,
+		Resource:          collection.Match.Resource,
+		Related:           collection.Match.Related,
+	}
+
+	switch kind {
+	case HelmRelease:
+		if match.Name == "" && match.ChartName == "" {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("helm_release policies must match on name or chart_name")
+		}
+	case CRDList:
+		if match.Version == "" || match.Resource == "" {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("crd_list policies must match on version and resource")
+		}
+	case Pod, Daemonset:
+	default:
+		if !IsResourceKind(kind) {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("%s is not a supported policy kind", collection.Kind)
+		}
+
+		if _, err := getResourceKindGVR(kind, match); err != nil {
+			return KubernetesOPAQueryCollection{}, err
+		}
+	}
+
+	if len(match.Related) > 0 && !IsResourceKind(kind) {
+		return KubernetesOPAQueryCollection{}, fmt.Errorf("related kinds are not supported for %s policies", kind)
+	}
+
+	if err := validateRelatedKinds(match); err != nil {
+		return KubernetesOPAQueryCollection{}, err
+	}
+
+	if collection.MustExist && (kind != HelmRelease || match.Name == "") {
+		return KubernetesOPAQueryCollection{}, fmt.Errorf("mustExist is only supported for helm_release policies matching on name")
+	}
+
+	queries := make([]rego.PreparedEvalQuery, 0)
+
+	for _, policy := range collection.Policies {
+		module, err := ast.ParseModule(policy.Name, policy.Module)
+		if err != nil {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("error parsing policy %s: %w", policy.Name, err)
+		}
+
+		// the recommender queries the package named after the policy, so any other package would never be evaluated
+		if pkg := module.Package.Path.String(); pkg != fmt.Sprintf("data.%s", policy.Name) {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("policy %s must declare package %s, found package %s",
+				policy.Name, policy.Name, module.Package.String())
+		}
+
+		query, err := prepareQuery(policy.Name, policy.Module)
+		if err != nil {
+			return KubernetesOPAQueryCollection{}, fmt.Errorf("error compiling policy %s: %w", policy.Name, err)
+		}
+
+		queries = append(queries, query)
+	}
+
+	return KubernetesOPAQueryCollection{
+		Kind:             kind,
+		Match:            match,
+		MustExist:        collection.MustExist,
+		OverrideSeverity: collection.OverrideSeverity,
+		Queries:          queries,
+		Custom:           true,
+	}, nil
+}
+
+// WithCustomPolicies returns the built-in policies along with the compiled custom policy collections of
+// a project. Collections which fail to compile are skipped and their errors returned.
+func (p *KubernetesPolicies) WithCustomPolicies(collections []*types.OPAPolicyCollection) (*KubernetesPolicies, []error) {
+	res := &KubernetesPolicies{
+		Policies: make(map[string]KubernetesOPAQueryCollection),
+	}
+
+	for name, collection := range p.Policies {
+		res.Policies[name] = collection
+	}
+
+	var errs []error
+
+	for _, collection := range collections {
+		queryCollection, err := CompileCustomPolicyCollection(collection)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("error compiling policy collection %s: %w", collection.Name, err))
+			continue
+		}
+
+		res.Policies[CustomCollectionPrefix+collection.Name] = queryCollection
+	}
+
+	return res, errs
+}
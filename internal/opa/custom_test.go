@@ -0,0 +1,183 @@
+package opa_test
+
+import (
+	"testing"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/opa"
+)
+
+const replicasModule = `package custom.replicas
+
+POLICY_ID := "replicas"
+
+allow {
+	input.values.replicaCount >= 2
+}
+`
+
+func TestCompileCustomPolicyCollection(t *testing.T) {
+	tests := []struct {
+		name       string
+		collection *types.OPAPolicyCollection
+		wantErr    bool
+	}{
+		{
+			name: "valid helm release collection",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "helm_release",
+				Match: types.OPAPolicyMatch{ChartName: "web"},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.replicas", Module: replicasModule},
+				},
+			},
+		},
+		{
+			name: "unsupported kind",
+			collection: &types.OPAPolicyCollection{
+				Kind: "configmap",
+			},
+			wantErr: true,
+		},
+		{
+			name: "helm release without name or chart name",
+			collection: &types.OPAPolicyCollection{
+				Kind: "helm_release",
+			},
+			wantErr: true,
+		},
+		{
+			name: "package does not match the policy name",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "helm_release",
+				Match: types.OPAPolicyMatch{ChartName: "web"},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.other", Module: replicasModule},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "deployment collection with related pod disruption budgets",
+			collection: &types.OPAPolicyCollection{
+				Kind: "deployment",
+				Match: types.OPAPolicyMatch{
+					Labels:  map[string]string{"porter.run/chart-name": "web"},
+					Related: []string{"pod_disruption_budget"},
+				},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.replicas", Module: replicasModule},
+				},
+			},
+		},
+		{
+			name: "generic resource without version",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "resource",
+				Match: types.OPAPolicyMatch{Group: "batch", Resource: "cronjobs"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported related kind",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "ingress",
+				Match: types.OPAPolicyMatch{Related: []string{"secret"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "related kinds for pods",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "pod",
+				Match: types.OPAPolicyMatch{Related: []string{"service"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid rego",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "pod",
+				Match: types.OPAPolicyMatch{Namespace: "default"},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.invalid", Module: "package custom.invalid\n\nallow {"},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "policy sends http requests",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "pod",
+				Match: types.OPAPolicyMatch{Namespace: "default"},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.http", Module: `package custom.http
+
+allow {
+	http.send({"method": "get", "url": "http://169.254.169.254/latest/meta-data/"}).status_code == 200
+}
+`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "policy looks up addresses",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "pod",
+				Match: types.OPAPolicyMatch{Namespace: "default"},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.lookup", Module: `package custom.lookup
+
+allow {
+	count(net.lookup_ip_addr("internal.example.com")) > 0
+}
+`},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "policy reads the server environment",
+			collection: &types.OPAPolicyCollection{
+				Kind:  "pod",
+				Match: types.OPAPolicyMatch{Namespace: "default"},
+				Policies: []*types.OPAPolicy{
+					{Name: "custom.runtime", Module: `package custom.runtime
+
+allow {
+	opa.runtime().env.DB_PASS != ""
+}
+`},
+				},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			collection, err := opa.CompileCustomPolicyCollection(tt.collection)
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got nil")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("%v\n", err)
+			}
+
+			if !collection.Custom {
+				t.Errorf("expected collection to be marked as custom")
+			}
+
+			if len(collection.Queries) != len(tt.collection.Policies) {
+				t.Errorf("expected %d queries, got %d", len(tt.collection.Policies), len(collection.Queries))
+			}
+		})
+	}
+}
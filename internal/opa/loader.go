@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"path/filepath"
 
+	"github.com/open-policy-agent/opa/ast"
 	"github.com/open-policy-agent/opa/rego"
 	"sigs.k8s.io/yaml"
 )
@@ -52,10 +53,7 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 				return nil, err
 			}
 
-			query, err := rego.New(
-				rego.Query(fmt.Sprintf("data.%s", cfPolicy.Name)),
-				rego.Module(cfPolicy.Name, string(fileBytes)),
-			).PrepareForEval(context.Background())
+			query, err := prepareQuery(cfPolicy.Name, string(fileBytes))
 			if err != nil {
 				// Handle error.
 				return nil, err
@@ -77,3 +75,37 @@ func LoadPolicies(configFilePathDir string) (*KubernetesPolicies, error) {
 		Policies: policies,
 	}, nil
 }
+
+// unsafeBuiltins are the builtins which are not available to policies, since they would let the
+// policies uploaded by projects make requests from the server or read its environment
+var unsafeBuiltins = map[string]bool{
+	ast.HTTPSend.Name:        true,
+	ast.NetLookupIPAddr.Name: true,
+	ast.OPARuntime.Name:      true,
+}
+
+// policyCapabilities returns the capabilities of this version of OPA without the unsafe builtins, so that
+// modules calling them fail to compile
+func policyCapabilities() *ast.Capabilities {
+	caps := ast.CapabilitiesForThisVersion()
+	builtins := make([]*ast.Builtin, 0, len(caps.Builtins))
+
+	for _, builtin := range caps.Builtins {
+		if !unsafeBuiltins[builtin.Name] {
+			builtins = append(builtins, builtin)
+		}
+	}
+
+	caps.Builtins = builtins
+
+	return caps
+}
+
+// prepareQuery compiles a rego module, returning a query for the package with the policy name
+func prepareQuery(name, module string) (rego.PreparedEvalQuery, error) {
+	return rego.New(
+		rego.Query(fmt.Sprintf("data.%s", name)),
+		rego.Module(name, module),
+		rego.Capabilities(policyCapabilities()),
+	).PrepareForEval(context.Background())
+}
@@ -39,6 +39,17 @@ const (
 	Pod         KubernetesBuiltInKind = "pod"
 	CRDList     KubernetesBuiltInKind = "crd_list"
 	Daemonset   KubernetesBuiltInKind = "daemonset"
+
+	Deployment              KubernetesBuiltInKind = "deployment"
+	StatefulSet             KubernetesBuiltInKind = "statefulset"
+	Service                 KubernetesBuiltInKind = "service"
+	Ingress                 KubernetesBuiltInKind = "ingress"
+	HorizontalPodAutoscaler KubernetesBuiltInKind = "horizontal_pod_autoscaler"
+	PodDisruptionBudget     KubernetesBuiltInKind = "pod_disruption_budget"
+	NetworkPolicy           KubernetesBuiltInKind = "network_policy"
+
+	// Resource matches objects of any group, version and resource
+	Resource KubernetesBuiltInKind = "resource"
 )
 
 type KubernetesOPAQueryCollection struct {
@@ -47,6 +58,9 @@ type KubernetesOPAQueryCollection struct {
 	MustExist        bool
 	OverrideSeverity string
 	Queries          []rego.PreparedEvalQuery
+
+	// Custom is set for collections uploaded by a project
+	Custom bool
 }
 
 type MatchParameters struct {
@@ -63,10 +77,14 @@ type MatchParameters struct {
 	// generic labels parameter
 	Labels map[string]string `json:"labels"`
 
-	// parameters for CRDs
+	// parameters for CRDs and generic resources
 	Group    string `json:"group"`
 	Version  string `json:"version"`
 	Resource string `json:"resource"`
+
+	// Related lists the kinds of the objects in the same namespace which are passed to the
+	// policies of resource kinds under input.related
+	Related []string `json:"related"`
 }
 
 type OPARecommenderQueryResult struct {
@@ -139,6 +157,8 @@ func (runner *KubernetesOPARunner) GetRecommendations(categories []string) ([]*O
 				currResults, err = runner.runCRDListQueries(name, queryCollection)
 			case Daemonset:
 				currResults, err = runner.runDaemonsetQueries(name, queryCollection)
+			case Deployment, StatefulSet, Service, Ingress, HorizontalPodAutoscaler, PodDisruptionBudget, NetworkPolicy, Resource:
+				currResults, err = runner.runResourceQueries(name, queryCollection)
 			default:
 				fmt.Printf("%s is not a supported query kind", queryCollection.Kind)
 				continue
@@ -149,6 +169,14 @@ func (runner *KubernetesOPARunner) GetRecommendations(categories []string) ([]*O
 				continue
 			}
 
+			if queryCollection.Custom {
+				// custom collections may target the same objects as the built-in ones, so their results
+				// are kept separate from the built-in results
+				for _, currRes := range currResults {
+					currRes.ObjectID = fmt.Sprintf("%s/%s", name, currRes.ObjectID)
+				}
+			}
+
 			res = append(res, currResults...)
 		}
 	}
@@ -0,0 +1,190 @@
+package opa
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/open-policy-agent/opa/rego"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// resourceKinds maps the kinds which are queried through the dynamic client to their resource. The
+// version can be overridden with the version match parameter, for clusters which do not serve it.
+var resourceKinds = map[KubernetesBuiltInKind]schema.GroupVersionResource{
+	Deployment:              {Group: "apps", Version: "v1", Resource: "deployments"},
+	StatefulSet:             {Group: "apps", Version: "v1", Resource: "statefulsets"},
+	Service:                 {Group: "", Version: "v1", Resource: "services"},
+	Ingress:                 {Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"},
+	HorizontalPodAutoscaler: {Group: "autoscaling", Version: "v2", Resource: "horizontalpodautoscalers"},
+	PodDisruptionBudget:     {Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"},
+	NetworkPolicy:           {Group: "networking.k8s.io", Version: "v1", Resource: "networkpolicies"},
+}
+
+// IsResourceKind returns true if the kind is evaluated against each matching object listed
+// through the dynamic client
+func IsResourceKind(kind KubernetesBuiltInKind) bool {
+	_, exists := resourceKinds[kind]
+
+	return exists || kind == Resource
+}
+
+func getResourceKindGVR(kind KubernetesBuiltInKind, match MatchParameters) (schema.GroupVersionResource, error) {
+	if kind == Resource {
+		if match.Version == "" || match.Resource == "" {
+			return schema.GroupVersionResource{}, fmt.Errorf("resource policies must match on version and resource")
+		}
+
+		res := schema.GroupVersionResource{
+			Group:    match.Group,
+			Version:  match.Version,
+			Resource: match.Resource,
+		}
+
+		// just case on the "core" group and unset it
+		if match.Group == "core" {
+			res.Group = ""
+		}
+
+		return res, nil
+	}
+
+	res, exists := resourceKinds[kind]
+
+	if !exists {
+		return schema.GroupVersionResource{}, fmt.Errorf("%s is not a supported resource kind", kind)
+	}
+
+	if match.Version != "" {
+		res.Version = match.Version
+	}
+
+	return res, nil
+}
+
+// validateRelatedKinds makes sure every related kind can be listed through the dynamic client
+func validateRelatedKinds(match MatchParameters) error {
+	for _, related := range match.Related {
+		if _, exists := resourceKinds[KubernetesBuiltInKind(related)]; !exists {
+			return fmt.Errorf("%s is not a supported related kind", related)
+		}
+	}
+
+	return nil
+}
+
+// runResourceQueries evaluates the queries against every object of the kind matching the namespace and labels. The
+// input of each query is the object, along with the objects of each related kind in the same namespace under
+// input.related, so that policies can check objects against each other (for example, that every deployment
+// is covered by a pod disruption budget).
+func (runner *KubernetesOPARunner) runResourceQueries(name string, collection KubernetesOPAQueryCollection) ([]*OPARecommenderQueryResult, error) {
+	res := make([]*OPARecommenderQueryResult, 0)
+
+	objRes, err := getResourceKindGVR(collection.Kind, collection.Match)
+	if err != nil {
+		return nil, err
+	}
+
+	lselArr := make([]string, 0)
+
+	for k, v := range collection.Match.Labels {
+		lselArr = append(lselArr, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	objList, err := runner.dynamicClient.Resource(objRes).Namespace(collection.Match.Namespace).List(context.Background(), v1.ListOptions{
+		LabelSelector: strings.Join(lselArr, ","),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// related objects, by kind and namespace
+	related := make(map[string]map[string][]interface{})
+
+	for _, relatedKind := range collection.Match.Related {
+		relatedRes := resourceKinds[KubernetesBuiltInKind(relatedKind)]
+
+		relatedList, err := runner.dynamicClient.Resource(relatedRes).Namespace(collection.Match.Namespace).List(context.Background(), v1.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		related[relatedKind] = make(map[string][]interface{})
+
+		for _, relatedObj := range relatedList.Items {
+			ns := relatedObj.GetNamespace()
+			related[relatedKind][ns] = append(related[relatedKind][ns], relatedObj.Object)
+		}
+	}
+
+	for _, obj := range objList.Items {
+		input := obj.Object
+
+		if len(related) > 0 {
+			input = make(map[string]interface{})
+
+			for k, v := range obj.Object {
+				input[k] = v
+			}
+
+			relatedInput := make(map[string]interface{})
+
+			for relatedKind, byNamespace := range related {
+				objs := byNamespace[obj.GetNamespace()]
+
+				if objs == nil {
+					objs = make([]interface{}, 0)
+				}
+
+				relatedInput[relatedKind] = objs
+			}
+
+			input["related"] = relatedInput
+		}
+
+		for _, query := range collection.Queries {
+			results, err := query.Eval(
+				context.Background(),
+				rego.EvalInput(input),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			if len(results) == 1 {
+				rawQueryRes := &rawQueryResult{}
+
+				err = mapstructure.Decode(results[0].Expressions[0].Value, rawQueryRes)
+
+				if err != nil {
+					return nil, err
+				}
+
+				res = append(res, rawQueryResToRecommenderQueryResult(
+					rawQueryRes,
+					getResourceObjectID(collection.Kind, objRes, obj.GetNamespace(), obj.GetName(), rawQueryRes.PolicyID),
+					name,
+					collection,
+				))
+			}
+		}
+	}
+
+	return res, nil
+}
+
+func getResourceObjectID(kind KubernetesBuiltInKind, objRes schema.GroupVersionResource, namespace, name, policyID string) string {
+	prefix := string(kind)
+
+	if kind == Resource {
+		prefix = fmt.Sprintf("%s/%s/%s", objRes.Group, objRes.Version, objRes.Resource)
+	}
+
+	if namespace == "" {
+		return fmt.Sprintf("%s/%s/%s", prefix, name, policyID)
+	}
+
+	return fmt.Sprintf("%s/%s/%s/%s", prefix, namespace, name, policyID)
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+)
+
+// AuditEventRepository represents the set of queries on the AuditEvent model
+type AuditEventRepository interface {
+	CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error)
+	ListAuditEventsByProjectID(
+		projectID uint,
+		opts *types.ListAuditEventsRequest,
+		limit, skip int,
+	) ([]*models.AuditEvent, int64, error)
+	ListAuditEventProjectIDs() ([]uint, error)
+	DeleteAuditEventsBefore(projectID uint, before time.Time, maxID uint) error
+	ListAuditEventsAfterID(projectID, afterID uint, createdBefore time.Time, limit int) ([]*models.AuditEvent, error)
+	GetLastAuditEventIDBefore(projectID uint, before time.Time) (uint, error)
+
+	CreateAuditSink(sink *models.AuditSink) (*models.AuditSink, error)
+	ReadAuditSink(projectID, sinkID uint) (*models.AuditSink, error)
+	ListAuditSinksByProjectID(projectID uint) ([]*models.AuditSink, error)
+	ListAuditSinks() ([]*models.AuditSink, error)
+	UpdateAuditSinkCursor(sink *models.AuditSink) (*models.AuditSink, error)
+	UpdateAuditSinkDelivery(sink *models.AuditSink, prevLastEventID uint) error
+	DeleteAuditSink(sink *models.AuditSink) (*models.AuditSink, error)
+}
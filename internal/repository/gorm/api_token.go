@@ -1,6 +1,8 @@
 package gorm
 
 import (
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
 	"gorm.io/gorm"
@@ -53,3 +55,32 @@ func (repo *APITokenRepository) UpdateAPIToken(
 
 	return token, nil
 }
+
+// UpdateAPITokenUsage only writes the usage columns of the token, so that a concurrent revocation
+// or rotation of the token is not overwritten
+func (repo *APITokenRepository) UpdateAPITokenUsage(token *models.APIToken) error {
+	return repo.db.Model(token).Updates(map[string]interface{}{
+		"last_used_at":         token.LastUsedAt,
+		"last_used_ip":         token.LastUsedIP,
+		"last_used_user_agent": token.LastUsedUserAgent,
+	}).Error
+}
+
+// ListStaleAPITokensByProjectID lists the tokens which are not revoked, and which have not been
+// used since the given time. Tokens which were never used are stale if they were created before
+// the given time.
+func (repo *APITokenRepository) ListStaleAPITokensByProjectID(
+	projectID uint,
+	unusedSince time.Time,
+) ([]*models.APIToken, error) {
+	tokens := []*models.APIToken{}
+
+	query := repo.db.Where("project_id = ? AND NOT revoked", projectID).
+		Where("(last_used_at < ? OR (last_used_at IS NULL AND created_at < ?))", unusedSince, unusedSince)
+
+	if err := query.Find(&tokens).Error; err != nil {
+		return nil, err
+	}
+
+	return tokens, nil
+}
@@ -0,0 +1,273 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// AuditEventRepository uses gorm.DB for querying the database
+type AuditEventRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewAuditEventRepository returns a AuditEventRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// the configuration of audit sinks
+func NewAuditEventRepository(db *gorm.DB, key *[32]byte) repository.AuditEventRepository {
+	return &AuditEventRepository{db, key}
+}
+
+// CreateAuditEvent creates a new audit event
+func (repo *AuditEventRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	if err := repo.db.Create(event).Error; err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// ListAuditEventsByProjectID lists the audit events of a project which match the filters,
+// newest first, and returns the total number of matching events
+func (repo *AuditEventRepository) ListAuditEventsByProjectID(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+	limit, skip int,
+) ([]*models.AuditEvent, int64, error) {
+	events := []*models.AuditEvent{}
+
+	query := repo.db.Where("project_id = ?", projectID)
+
+	if opts.Actor != "" {
+		query = query.Where("(LOWER(actor_name) = LOWER(?) OR api_token_id = ?)", opts.Actor, opts.Actor)
+	}
+
+	if opts.ClusterID != 0 {
+		query = query.Where("cluster_id = ?", opts.ClusterID)
+	}
+
+	if opts.Namespace != "" {
+		query = query.Where("namespace = ?", opts.Namespace)
+	}
+
+	if opts.Verb != "" {
+		query = query.Where("verb = ?", opts.Verb)
+	}
+
+	if opts.Outcome != "" {
+		query = query.Where("outcome = ?", opts.Outcome)
+	}
+
+	if opts.Resource != "" {
+		query = query.Where("resource = ?", opts.Resource)
+	}
+
+	if opts.Since != nil {
+		query = query.Where("created_at >= ?", *opts.Since)
+	}
+
+	if opts.Until != nil {
+		query = query.Where("created_at < ?", *opts.Until)
+	}
+
+	// get the count before limit and offset
+	var count int64
+
+	if err := query.Model([]*models.AuditEvent{}).Count(&count).Error; err != nil {
+		return nil, 0, err
+	}
+
+	query = query.Order("created_at desc").Order("id desc").Limit(limit).Offset(skip)
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return events, count, nil
+}
+
+// ListAuditEventProjectIDs lists the ids of the projects which have audit events
+func (repo *AuditEventRepository) ListAuditEventProjectIDs() ([]uint, error) {
+	var ids []uint
+
+	if err := repo.db.Model(&models.AuditEvent{}).Distinct().Pluck("project_id", &ids).Error; err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// DeleteAuditEventsBefore permanently deletes the audit events of a project which were created
+// before the given time and have an ID of at most maxID
+func (repo *AuditEventRepository) DeleteAuditEventsBefore(projectID uint, before time.Time, maxID uint) error {
+	return repo.db.Unscoped().
+		Where("project_id = ? AND created_at < ? AND id <= ?", projectID, before, maxID).
+		Delete(&models.AuditEvent{}).Error
+}
+
+// ListAuditEventsAfterID lists the audit events of a project with an ID greater than afterID which
+// were created before createdBefore, in order of their ID
+func (repo *AuditEventRepository) ListAuditEventsAfterID(
+	projectID, afterID uint,
+	createdBefore time.Time,
+	limit int,
+) ([]*models.AuditEvent, error) {
+	events := []*models.AuditEvent{}
+
+	query := repo.db.Where("project_id = ? AND id > ? AND created_at < ?", projectID, afterID, createdBefore).
+		Order("id asc").
+		Limit(limit)
+
+	if err := query.Find(&events).Error; err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// GetLastAuditEventIDBefore returns the ID of the last audit event of a project which was created
+// before the given time, or 0 if there is no such event
+func (repo *AuditEventRepository) GetLastAuditEventIDBefore(projectID uint, before time.Time) (uint, error) {
+	var id uint
+
+	err := repo.db.Model(&models.AuditEvent{}).
+		Select("COALESCE(MAX(id), 0)").
+		Where("project_id = ? AND created_at < ?", projectID, before).
+		Scan(&id).Error
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// CreateAuditSink creates a new audit sink
+func (repo *AuditEventRepository) CreateAuditSink(sink *models.AuditSink) (*models.AuditSink, error) {
+	config := sink.Config
+
+	cipherData, err := encryption.Encrypt(sink.Config, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	sink.Config = cipherData
+
+	if err := repo.db.Create(sink).Error; err != nil {
+		return nil, err
+	}
+
+	sink.Config = config
+
+	return sink, nil
+}
+
+// ReadAuditSink reads an audit sink of a project by ID
+func (repo *AuditEventRepository) ReadAuditSink(projectID, sinkID uint) (*models.AuditSink, error) {
+	sink := &models.AuditSink{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, sinkID).First(sink).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptConfig(sink); err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+// ListAuditSinksByProjectID lists the audit sinks of a project
+func (repo *AuditEventRepository) ListAuditSinksByProjectID(projectID uint) ([]*models.AuditSink, error) {
+	sinks := []*models.AuditSink{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&sinks).Error; err != nil {
+		return nil, err
+	}
+
+	for _, sink := range sinks {
+		if err := repo.decryptConfig(sink); err != nil {
+			return nil, err
+		}
+	}
+
+	return sinks, nil
+}
+
+// ListAuditSinks lists the audit sinks of every project
+func (repo *AuditEventRepository) ListAuditSinks() ([]*models.AuditSink, error) {
+	sinks := []*models.AuditSink{}
+
+	if err := repo.db.Order("id asc").Find(&sinks).Error; err != nil {
+		return nil, err
+	}
+
+	for _, sink := range sinks {
+		if err := repo.decryptConfig(sink); err != nil {
+			return nil, err
+		}
+	}
+
+	return sinks, nil
+}
+
+// UpdateAuditSinkCursor moves the cursor of a sink, and clears its backoff so that the next
+// export starts immediately
+func (repo *AuditEventRepository) UpdateAuditSinkCursor(sink *models.AuditSink) (*models.AuditSink, error) {
+	err := repo.db.Model(sink).Updates(map[string]interface{}{
+		"last_event_id":   sink.LastEventID,
+		"failure_count":   0,
+		"next_attempt_at": nil,
+	}).Error
+	if err != nil {
+		return nil, err
+	}
+
+	sink.FailureCount = 0
+	sink.NextAttemptAt = nil
+
+	return sink, nil
+}
+
+// UpdateAuditSinkDelivery stores the result of an export to the sink. The update is skipped if
+// the cursor of the sink was moved since prevLastEventID was read, so that a replay which is
+// requested during an export is not overwritten.
+func (repo *AuditEventRepository) UpdateAuditSinkDelivery(sink *models.AuditSink, prevLastEventID uint) error {
+	return repo.db.Model(&models.AuditSink{}).
+		Where("id = ? AND last_event_id = ?", sink.ID, prevLastEventID).
+		Updates(map[string]interface{}{
+			"last_event_id":    sink.LastEventID,
+			"last_exported_at": sink.LastExportedAt,
+			"failure_count":    sink.FailureCount,
+			"next_attempt_at":  sink.NextAttemptAt,
+			"last_error":       sink.LastError,
+		}).Error
+}
+
+// DeleteAuditSink deletes an audit sink
+func (repo *AuditEventRepository) DeleteAuditSink(sink *models.AuditSink) (*models.AuditSink, error) {
+	if err := repo.db.Delete(sink).Error; err != nil {
+		return nil, err
+	}
+
+	return sink, nil
+}
+
+func (repo *AuditEventRepository) decryptConfig(sink *models.AuditSink) error {
+	if len(sink.Config) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(sink.Config, repo.key)
+	if err != nil {
+		return err
+	}
+
+	sink.Config = plaintext
+
+	return nil
+}
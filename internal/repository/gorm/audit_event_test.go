@@ -0,0 +1,311 @@
+package gorm_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	orm "gorm.io/gorm"
+)
+
+func TestAuditEventRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_audit_event.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+
+	events := []*models.AuditEvent{
+		{
+			ProjectID:  projectID,
+			ActorKind:  string(types.AuditActorUser),
+			UserID:     1,
+			ActorName:  "belanger@getporter.dev",
+			ClientIP:   "203.0.113.7",
+			ClusterID:  1,
+			Namespace:  "default",
+			Verb:       string(types.APIVerbCreate),
+			Method:     "POST",
+			Path:       "/api/projects/1/clusters/1/namespaces/default/releases",
+			Resource:   "web",
+			StatusCode: 201,
+			Outcome:    string(types.AuditOutcomeSuccess),
+		},
+		{
+			ProjectID:  projectID,
+			ActorKind:  string(types.AuditActorAPIToken),
+			ActorName:  "ci",
+			APITokenID: "token-id",
+			Verb:       string(types.APIVerbDelete),
+			Method:     "DELETE",
+			Path:       "/api/projects/1/api_token/token-id",
+			StatusCode: 403,
+			Outcome:    string(types.AuditOutcomeForbidden),
+		},
+		{
+			ProjectID:  projectID + 1,
+			ActorKind:  string(types.AuditActorUser),
+			ActorName:  "other@getporter.dev",
+			Verb:       string(types.APIVerbUpdate),
+			StatusCode: 200,
+			Outcome:    string(types.AuditOutcomeSuccess),
+		},
+	}
+
+	for _, event := range events {
+		if _, err := tester.repo.AuditEvent().CreateAuditEvent(event); err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	listed, count, err := tester.repo.AuditEvent().ListAuditEventsByProjectID(projectID, &types.ListAuditEventsRequest{}, 10, 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 || len(listed) != 2 {
+		t.Fatalf("incorrect number of events: expected %d, got %d (count %d)\n", 2, len(listed), count)
+	}
+
+	// events are listed newest first
+	if diff := deep.Equal(events[1], listed[0]); diff != nil {
+		t.Errorf("events not equal:")
+		t.Error(diff)
+	}
+
+	// the actor filter matches the actor name case-insensitively, or the id of the api token
+	actorEvents := map[string]uint{
+		"BELANGER@getporter.dev": events[0].ID,
+		"token-id":               events[1].ID,
+	}
+
+	for actor, expID := range actorEvents {
+		listed, count, err = tester.repo.AuditEvent().ListAuditEventsByProjectID(projectID, &types.ListAuditEventsRequest{
+			Actor: actor,
+		}, 10, 0)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if count != 1 || len(listed) != 1 || listed[0].ID != expID {
+			t.Errorf("incorrect events for actor %s: got %d (count %d)\n", actor, len(listed), count)
+		}
+	}
+
+	listed, count, err = tester.repo.AuditEvent().ListAuditEventsByProjectID(projectID, &types.ListAuditEventsRequest{
+		Outcome: types.AuditOutcomeForbidden,
+	}, 10, 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 || listed[0].ID != events[1].ID {
+		t.Errorf("incorrect events for outcome filter: got %d\n", count)
+	}
+
+	// the count is not affected by the limit
+	listed, count, err = tester.repo.AuditEvent().ListAuditEventsByProjectID(projectID, &types.ListAuditEventsRequest{}, 1, 1)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 2 || len(listed) != 1 || listed[0].ID != events[0].ID {
+		t.Errorf("incorrect page of events: got %d (count %d)\n", len(listed), count)
+	}
+
+	projectIDs, err := tester.repo.AuditEvent().ListAuditEventProjectIDs()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(projectIDs) != 2 {
+		t.Errorf("incorrect number of project ids: expected %d, got %d\n", 2, len(projectIDs))
+	}
+
+	future := time.Now().Add(time.Hour)
+
+	afterFirst, err := tester.repo.AuditEvent().ListAuditEventsAfterID(projectID, events[0].ID, future, 10)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(afterFirst) != 1 || afterFirst[0].ID != events[1].ID {
+		t.Errorf("incorrect events after id %d: got %d\n", events[0].ID, len(afterFirst))
+	}
+
+	lastID, err := tester.repo.AuditEvent().GetLastAuditEventIDBefore(projectID, future)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if lastID != events[1].ID {
+		t.Errorf("incorrect last event id: expected %d, got %d\n", events[1].ID, lastID)
+	}
+
+	// events with an ID over maxID are kept
+	if err := tester.repo.AuditEvent().DeleteAuditEventsBefore(projectID, future, events[0].ID); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, count, err = tester.repo.AuditEvent().ListAuditEventsByProjectID(projectID, &types.ListAuditEventsRequest{}, 10, 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Errorf("incorrect number of events after deletion: expected %d, got %d\n", 1, count)
+	}
+
+	// events of other projects are kept
+	_, count, err = tester.repo.AuditEvent().ListAuditEventsByProjectID(projectID+1, &types.ListAuditEventsRequest{}, 10, 0)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected the events of other projects to be kept, got %d\n", count)
+	}
+}
+
+func TestAuditSinkRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_audit_sink.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+	config := []byte(`{"s3":{"bucket":"audit","region":"us-east-1","access_key_id":"AKIA","secret_access_key":"shh"}}`)
+
+	sink, err := tester.repo.AuditEvent().CreateAuditSink(&models.AuditSink{
+		ProjectID: projectID,
+		Name:      "archive",
+		Kind:      string(types.AuditSinkS3),
+		Config:    config,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !bytes.Equal(sink.Config, config) {
+		t.Errorf("expected the created sink to keep the plaintext config, got %s\n", sink.Config)
+	}
+
+	// the config is encrypted in the database
+	stored := &models.AuditSink{}
+
+	if err := tester.db.First(stored, sink.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if bytes.Equal(stored.Config, config) {
+		t.Errorf("expected the config to be encrypted in the database")
+	}
+
+	plaintext, err := encryption.Decrypt(stored.Config, tester.key)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !bytes.Equal(plaintext, config) {
+		t.Errorf("incorrect decrypted config: expected %s, got %s\n", config, plaintext)
+	}
+
+	readSink, err := tester.repo.AuditEvent().ReadAuditSink(projectID, sink.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff := deep.Equal(sink, readSink); diff != nil {
+		t.Errorf("sinks not equal:")
+		t.Error(diff)
+	}
+
+	// sinks of other projects are not returned
+	_, err = tester.repo.AuditEvent().ReadAuditSink(projectID+1, sink.ID)
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
+	}
+
+	projectSinks, err := tester.repo.AuditEvent().ListAuditSinksByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	allSinks, err := tester.repo.AuditEvent().ListAuditSinks()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, sinks := range [][]*models.AuditSink{projectSinks, allSinks} {
+		if len(sinks) != 1 || !bytes.Equal(sinks[0].Config, config) {
+			t.Errorf("expected a single sink with a decrypted config, got %d\n", len(sinks))
+		}
+	}
+
+	// a delivery is skipped if the cursor was moved since it was read
+	now := time.Now()
+
+	readSink.LastEventID = 10
+	readSink.LastExportedAt = &now
+
+	if err := tester.repo.AuditEvent().UpdateAuditSinkDelivery(readSink, 5); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readSink, err = tester.repo.AuditEvent().ReadAuditSink(projectID, sink.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if readSink.LastEventID != 0 || readSink.LastExportedAt != nil {
+		t.Errorf("expected the delivery to be skipped, got last event id %d\n", readSink.LastEventID)
+	}
+
+	readSink.LastEventID = 10
+	readSink.FailureCount = 2
+	readSink.NextAttemptAt = &now
+
+	if err := tester.repo.AuditEvent().UpdateAuditSinkDelivery(readSink, 0); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readSink.LastEventID = 3
+
+	if _, err := tester.repo.AuditEvent().UpdateAuditSinkCursor(readSink); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readSink, err = tester.repo.AuditEvent().ReadAuditSink(projectID, sink.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if readSink.LastEventID != 3 || readSink.FailureCount != 0 || readSink.NextAttemptAt != nil {
+		t.Errorf("expected the cursor to be moved and the backoff to be cleared, got last event id %d, failure count %d\n",
+			readSink.LastEventID, readSink.FailureCount)
+	}
+
+	if !bytes.Equal(readSink.Config, config) {
+		t.Errorf("expected the config to be kept after updates, got %s\n", readSink.Config)
+	}
+
+	if _, err := tester.repo.AuditEvent().DeleteAuditSink(readSink); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.AuditEvent().ReadAuditSink(projectID, sink.ID)
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
+	}
+}
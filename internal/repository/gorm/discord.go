@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// DiscordIntegrationRepository uses gorm.DB for querying the database
+type DiscordIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewDiscordIntegrationRepository returns a DiscordIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewDiscordIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.DiscordIntegrationRepository {
+	return &DiscordIntegrationRepository{db, key}
+}
+
+// CreateDiscordIntegration creates a new Discord integration
+func (repo *DiscordIntegrationRepository) CreateDiscordIntegration(
+	discordInt *ints.DiscordIntegration,
+) (*ints.DiscordIntegration, error) {
+	cipherData, err := encryption.Encrypt(discordInt.Webhook, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	discordInt.Webhook = cipherData
+
+	if err := repo.db.Create(discordInt).Error; err != nil {
+		return nil, err
+	}
+
+	return discordInt, nil
+}
+
+// ListDiscordIntegrationsByProjectID finds all Discord integrations
+// for a given project id
+func (repo *DiscordIntegrationRepository) ListDiscordIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.DiscordIntegration, error) {
+	discordInts := []*ints.DiscordIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&discordInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, discordInt := range discordInts {
+		if len(discordInt.Webhook) > 0 {
+			plaintext, err := encryption.Decrypt(discordInt.Webhook, repo.key)
+			if err != nil {
+				return nil, err
+			}
+
+			discordInt.Webhook = plaintext
+		}
+	}
+
+	return discordInts, nil
+}
+
+// DeleteDiscordIntegration deletes a Discord integration by ID
+func (repo *DiscordIntegrationRepository) DeleteDiscordIntegration(
+	integrationID uint,
+) error {
+	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.DiscordIntegration{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,86 @@
+package gorm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/encryption"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestDiscordIntegrationRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_discord_integration.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+	webhookURL := []byte("https://discord.com/api/webhooks/123/abc")
+
+	discordInt := &ints.DiscordIntegration{
+		UserID:    tester.initUsers[0].Model.ID,
+		ProjectID: projectID,
+		Channel:   "deploys",
+		Webhook:   webhookURL,
+	}
+
+	discordInt, err := tester.repo.DiscordIntegration().CreateDiscordIntegration(discordInt)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the webhook URL is encrypted in the database
+	stored := &ints.DiscordIntegration{}
+
+	if err := tester.db.First(stored, discordInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if bytes.Equal(stored.Webhook, webhookURL) {
+		t.Errorf("expected the webhook URL to be encrypted in the database")
+	}
+
+	plaintext, err := encryption.Decrypt(stored.Webhook, tester.key)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !bytes.Equal(plaintext, webhookURL) {
+		t.Errorf("incorrect decrypted webhook URL: expected %s, got %s\n", webhookURL, plaintext)
+	}
+
+	discordInts, err := tester.repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(discordInts) != 1 {
+		t.Fatalf("length of integrations incorrect: expected %d, got %d\n", 1, len(discordInts))
+	}
+
+	if !bytes.Equal(discordInts[0].Webhook, webhookURL) {
+		t.Errorf("incorrect listed webhook URL: expected %s, got %s\n", webhookURL, discordInts[0].Webhook)
+	}
+
+	if discordInts[0].Channel != "deploys" {
+		t.Errorf("incorrect channel: expected %s, got %s\n", "deploys", discordInts[0].Channel)
+	}
+
+	if err := tester.repo.DiscordIntegration().DeleteDiscordIntegration(discordInt.ID); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	discordInts, err = tester.repo.DiscordIntegration().ListDiscordIntegrationsByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(discordInts) != 0 {
+		t.Errorf("length of integrations incorrect: expected %d, got %d\n", 0, len(discordInts))
+	}
+}
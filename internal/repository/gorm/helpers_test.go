@@ -80,6 +80,15 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&models.Allowlist{},
 		&models.Tag{},
 		&models.APIToken{},
+		&models.OPAPolicyCollection{},
+		&models.MonitorNotificationConfig{},
+		&models.NotificationWebhook{},
+		&models.NotificationWebhookDelivery{},
+		&models.NotificationThrottle{},
+		&models.NotificationChannelUsage{},
+		&models.NotificationDigestEntry{},
+		&models.AuditEvent{},
+		&models.AuditSink{},
 		&ints.KubeIntegration{},
 		&ints.BasicIntegration{},
 		&ints.OIDCIntegration{},
@@ -90,6 +99,8 @@ func setupTestEnv(tester *tester, t *testing.T) {
 		&ints.RegTokenCache{},
 		&ints.HelmRepoTokenCache{},
 		&ints.GithubAppInstallation{},
+		&ints.TeamsIntegration{},
+		&ints.DiscordIntegration{},
 	)
 
 	if err != nil {
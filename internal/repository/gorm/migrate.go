@@ -38,6 +38,14 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.PWResetToken{},
 		&models.NotificationConfig{},
 		&models.JobNotificationConfig{},
+		&models.MonitorNotificationConfig{},
+		&models.NotificationWebhook{},
+		&models.NotificationWebhookDelivery{},
+		&models.NotificationThrottle{},
+		&models.NotificationChannelUsage{},
+		&models.NotificationDigestEntry{},
+		&models.AuditEvent{},
+		&models.AuditSink{},
 		&models.EventContainer{},
 		&models.SubEvent{},
 		&models.KubeEvent{},
@@ -58,6 +66,7 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&models.StackEnvGroup{},
 		&models.DbMigration{},
 		&models.MonitorTestResult{},
+		&models.OPAPolicyCollection{},
 		&models.APIContractRevision{},
 		&models.AWSAssumeRoleChain{},
 		&ints.KubeIntegration{},
@@ -76,5 +85,7 @@ func AutoMigrate(db *gorm.DB, debug bool) error {
 		&ints.GithubAppInstallation{},
 		&ints.GithubAppOAuthIntegration{},
 		&ints.SlackIntegration{},
+		&ints.TeamsIntegration{},
+		&ints.DiscordIntegration{},
 	)
 }
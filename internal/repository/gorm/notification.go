@@ -103,3 +103,41 @@ func (repo JobNotificationConfigRepository) UpdateNotificationConfig(am *models.
 
 	return am, nil
 }
+
+type MonitorNotificationConfigRepository struct {
+	db *gorm.DB
+}
+
+// NewMonitorNotificationConfigRepository creates a new MonitorNotificationConfigRepository
+func NewMonitorNotificationConfigRepository(db *gorm.DB) repository.MonitorNotificationConfigRepository {
+	return MonitorNotificationConfigRepository{db: db}
+}
+
+// CreateMonitorNotificationConfig creates a new MonitorNotificationConfig
+func (repo MonitorNotificationConfigRepository) CreateMonitorNotificationConfig(conf *models.MonitorNotificationConfig) (*models.MonitorNotificationConfig, error) {
+	if err := repo.db.Create(conf).Error; err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
+
+// ReadMonitorNotificationConfigByProjectID reads the MonitorNotificationConfig of a project
+func (repo MonitorNotificationConfigRepository) ReadMonitorNotificationConfigByProjectID(projectID uint) (*models.MonitorNotificationConfig, error) {
+	ret := &models.MonitorNotificationConfig{}
+
+	if err := repo.db.Where("project_id = ?", projectID).First(&ret).Error; err != nil {
+		return nil, err
+	}
+
+	return ret, nil
+}
+
+// UpdateMonitorNotificationConfig updates a given MonitorNotificationConfig
+func (repo MonitorNotificationConfigRepository) UpdateMonitorNotificationConfig(conf *models.MonitorNotificationConfig) (*models.MonitorNotificationConfig, error) {
+	if err := repo.db.Save(conf).Error; err != nil {
+		return nil, err
+	}
+
+	return conf, nil
+}
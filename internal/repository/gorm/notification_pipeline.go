@@ -0,0 +1,134 @@
+package gorm
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// NotificationPipelineRepository uses gorm.DB for querying the database
+type NotificationPipelineRepository struct {
+	db *gorm.DB
+}
+
+// NewNotificationPipelineRepository returns a NotificationPipelineRepository which uses
+// gorm.DB for querying the database
+func NewNotificationPipelineRepository(db *gorm.DB) repository.NotificationPipelineRepository {
+	return &NotificationPipelineRepository{db}
+}
+
+// ReserveNotification records that the notification of the throttle is being sent, unless the same key
+// was sent to the channel after sentAfter, in which case the notification is counted as suppressed. It
+// returns whether the notification was reserved. The check and the reservation are a single upsert, so
+// that only one of several concurrent notifications is reserved.
+func (repo *NotificationPipelineRepository) ReserveNotification(throttle *models.NotificationThrottle, sentAfter time.Time) (bool, error) {
+	throttle.Suppressed = 0
+
+	res := repo.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "channel"}, {Name: "event_key"}},
+		DoUpdates: clause.AssignmentColumns([]string{"project_id", "last_sent_at", "suppressed", "updated_at"}),
+		Where: clause.Where{Exprs: []clause.Expression{
+			clause.Lt{Column: clause.Column{Table: "notification_throttles", Name: "last_sent_at"}, Value: sentAfter},
+		}},
+	}).Create(throttle)
+
+	if res.Error != nil {
+		return false, res.Error
+	}
+
+	if res.RowsAffected > 0 {
+		return true, nil
+	}
+
+	err := repo.db.Model(&models.NotificationThrottle{}).
+		Where("channel = ? AND event_key = ?", throttle.Channel, throttle.EventKey).
+		UpdateColumn("suppressed", gorm.Expr("suppressed + 1")).Error
+
+	return false, err
+}
+
+// ReleaseNotification deletes the reservation of a notification which was not sent, so that the next
+// notification of the key is not suppressed. The reservation replaced any throttle which had expired, so
+// nothing else is lost. Reservations made since then are kept.
+func (repo *NotificationPipelineRepository) ReleaseNotification(throttle *models.NotificationThrottle) error {
+	return repo.db.Unscoped().
+		Where("channel = ? AND event_key = ? AND last_sent_at = ?", throttle.Channel, throttle.EventKey, throttle.LastSentAt).
+		Delete(&models.NotificationThrottle{}).Error
+}
+
+// DeleteNotificationThrottlesBefore deletes the throttles which were last sent before the given time
+func (repo *NotificationPipelineRepository) DeleteNotificationThrottlesBefore(before time.Time) error {
+	return repo.db.Unscoped().Where("last_sent_at < ?", before).Delete(&models.NotificationThrottle{}).Error
+}
+
+// IncrementNotificationChannelUsage increments the number of notifications sent to the channel in the
+// window starting at windowStart, and returns the new count
+func (repo *NotificationPipelineRepository) IncrementNotificationChannelUsage(channel string, windowStart time.Time) (uint, error) {
+	usage := &models.NotificationChannelUsage{}
+
+	err := repo.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("channel = ?", channel).First(usage).Error
+
+		if err == gorm.ErrRecordNotFound {
+			usage = &models.NotificationChannelUsage{
+				Channel:     channel,
+				WindowStart: windowStart,
+				Count:       1,
+			}
+
+			return tx.Create(usage).Error
+		} else if err != nil {
+			return err
+		}
+
+		if usage.WindowStart.Before(windowStart) {
+			usage.WindowStart = windowStart
+			usage.Count = 0
+		}
+
+		usage.Count++
+
+		return tx.Save(usage).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return usage.Count, nil
+}
+
+// CreateNotificationDigestEntry queues a notification for the next digest of its channel
+func (repo *NotificationPipelineRepository) CreateNotificationDigestEntry(entry *models.NotificationDigestEntry) (*models.NotificationDigestEntry, error) {
+	if err := repo.db.Create(entry).Error; err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// ListDueNotificationDigestEntries lists the hourly entries created before hourlyBefore and the daily
+// entries created before dailyBefore
+func (repo *NotificationPipelineRepository) ListDueNotificationDigestEntries(hourlyBefore, dailyBefore time.Time) ([]*models.NotificationDigestEntry, error) {
+	entries := make([]*models.NotificationDigestEntry, 0)
+
+	if err := repo.db.Where(
+		"(frequency = ? AND created_at < ?) OR (frequency = ? AND created_at < ?)",
+		"hourly", hourlyBefore, "daily", dailyBefore,
+	).Order("created_at asc").Find(&entries).Error; err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// DeleteNotificationDigestEntries deletes the digest entries with the given ids
+func (repo *NotificationPipelineRepository) DeleteNotificationDigestEntries(ids []uint) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return repo.db.Unscoped().Where("id IN (?)", ids).Delete(&models.NotificationDigestEntry{}).Error
+}
@@ -0,0 +1,204 @@
+package gorm_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+func TestNotificationThrottles(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_throttles.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	repo := tester.repo.NotificationPipeline()
+	sentAt := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	reserve := func(channel string, lastSentAt, sentAfter time.Time) bool {
+		t.Helper()
+
+		reserved, err := repo.ReserveNotification(&models.NotificationThrottle{
+			ProjectID:  1,
+			Channel:    channel,
+			EventKey:   "incident:1:new",
+			LastSentAt: lastSentAt,
+		}, sentAfter)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		return reserved
+	}
+
+	if !reserve("slack:1", sentAt, sentAt.Add(-time.Hour)) {
+		t.Errorf("expected a notification which was never sent to be reserved")
+	}
+
+	for i := 0; i < 2; i++ {
+		if reserve("slack:1", sentAt.Add(5*time.Minute), sentAt.Add(-5*time.Minute)) {
+			t.Errorf("expected a notification within the window to be suppressed")
+		}
+	}
+
+	// the same key is throttled separately in every channel
+	if !reserve("teams:1", sentAt.Add(5*time.Minute), sentAt.Add(-5*time.Minute)) {
+		t.Errorf("expected a notification to another channel to be reserved")
+	}
+
+	stored := &models.NotificationThrottle{}
+
+	if err := tester.db.Where("channel = ? AND event_key = ?", "slack:1", "incident:1:new").First(stored).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !stored.LastSentAt.Equal(sentAt) || stored.Suppressed != 2 {
+		t.Errorf("expected suppressed notifications to keep the reservation, got last sent at %s and %d suppressed\n",
+			stored.LastSentAt, stored.Suppressed)
+	}
+
+	// reserving the notification again after the window updates the existing throttle
+	if !reserve("slack:1", sentAt.Add(time.Hour), sentAt.Add(time.Minute)) {
+		t.Errorf("expected a notification after the window to be reserved")
+	}
+
+	throttles := []*models.NotificationThrottle{}
+
+	if err := tester.db.Where("channel = ?", "slack:1").Find(&throttles).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(throttles) != 1 {
+		t.Fatalf("length of throttles incorrect: expected %d, got %d\n", 1, len(throttles))
+	}
+
+	if !throttles[0].LastSentAt.Equal(sentAt.Add(time.Hour)) || throttles[0].Suppressed != 0 {
+		t.Errorf("expected the throttle to be reset, got last sent at %s and %d suppressed\n",
+			throttles[0].LastSentAt, throttles[0].Suppressed)
+	}
+
+	// releasing an older reservation keeps the current one
+	err := repo.ReleaseNotification(&models.NotificationThrottle{
+		Channel:    "slack:1",
+		EventKey:   "incident:1:new",
+		LastSentAt: sentAt,
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if reserve("slack:1", sentAt.Add(time.Hour), sentAt.Add(time.Hour)) {
+		t.Errorf("expected the current reservation to be kept")
+	}
+
+	err = repo.ReleaseNotification(&models.NotificationThrottle{
+		Channel:    "slack:1",
+		EventKey:   "incident:1:new",
+		LastSentAt: sentAt.Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !reserve("slack:1", sentAt.Add(time.Hour), sentAt.Add(time.Hour)) {
+		t.Errorf("expected a released notification to be reserved again")
+	}
+
+	if err := repo.DeleteNotificationThrottlesBefore(sentAt.Add(2 * time.Hour)); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !reserve("slack:1", sentAt.Add(time.Hour), sentAt) {
+		t.Errorf("expected deleted throttles not to suppress notifications")
+	}
+}
+
+func TestIncrementNotificationChannelUsage(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_channel_usage.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	repo := tester.repo.NotificationPipeline()
+	window := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	for i := uint(1); i <= 3; i++ {
+		count, err := repo.IncrementNotificationChannelUsage("slack:1", window)
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+
+		if count != i {
+			t.Errorf("incorrect count: expected %d, got %d\n", i, count)
+		}
+	}
+
+	count, err := repo.IncrementNotificationChannelUsage("slack:1", window.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if count != 1 {
+		t.Errorf("expected the count to be reset in a new window, got %d\n", count)
+	}
+}
+
+func TestNotificationDigestEntries(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_digest_entries.db",
+	}
+
+	setupTestEnv(tester, t)
+	defer cleanup(tester, t)
+
+	repo := tester.repo.NotificationPipeline()
+
+	for _, frequency := range []string{"hourly", "daily"} {
+		_, err := repo.CreateNotificationDigestEntry(&models.NotificationDigestEntry{
+			ProjectID: 1,
+			Channel:   "slack:1",
+			Frequency: frequency,
+			EventTime: time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC),
+			Title:     "was successfully updated",
+			Name:      "web",
+			Namespace: "default",
+		})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	now := time.Now()
+
+	// only the hourly entry is due
+	entries, err := repo.ListDueNotificationDigestEntries(now.Add(time.Hour), now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(entries) != 1 || entries[0].Frequency != "hourly" {
+		t.Fatalf("expected the hourly entry to be due, got %d entries\n", len(entries))
+	}
+
+	if !entries[0].EventTime.Equal(time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("incorrect event time: got %s\n", entries[0].EventTime)
+	}
+
+	if err := repo.DeleteNotificationDigestEntries([]uint{entries[0].ID}); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	entries, err = repo.ListDueNotificationDigestEntries(now.Add(time.Hour), now.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(entries) != 1 || entries[0].Frequency != "daily" {
+		t.Errorf("expected only the daily entry to be left, got %d entries\n", len(entries))
+	}
+}
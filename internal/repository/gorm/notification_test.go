@@ -0,0 +1,79 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/internal/models"
+	orm "gorm.io/gorm"
+)
+
+func TestMonitorNotificationConfigRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_monitor_notification_config.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+
+	_, err := tester.repo.MonitorNotificationConfig().ReadMonitorNotificationConfigByProjectID(projectID)
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
+	}
+
+	conf := &models.MonitorNotificationConfig{
+		ProjectID:       projectID,
+		Enabled:         true,
+		MinSeverity:     "high",
+		MutedCategories: "nginx,cert_manager",
+	}
+
+	conf, err = tester.repo.MonitorNotificationConfig().CreateMonitorNotificationConfig(conf)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readConf, err := tester.repo.MonitorNotificationConfig().ReadMonitorNotificationConfigByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff := deep.Equal(conf, readConf); diff != nil {
+		t.Errorf("notification configs not equal:")
+		t.Error(diff)
+	}
+
+	readConf.Email = true
+	readConf.MutedCategories = ""
+
+	_, err = tester.repo.MonitorNotificationConfig().UpdateMonitorNotificationConfig(readConf)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readConf, err = tester.repo.MonitorNotificationConfig().ReadMonitorNotificationConfigByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	confType := readConf.ToMonitorNotificationConfigType()
+
+	if !confType.Email {
+		t.Errorf("expected email notifications to be enabled")
+	}
+
+	if len(confType.MutedCategories) != 0 {
+		t.Errorf("expected no muted categories, got %v\n", confType.MutedCategories)
+	}
+
+	// only one config is stored per project
+	_, err = tester.repo.MonitorNotificationConfig().CreateMonitorNotificationConfig(&models.MonitorNotificationConfig{
+		ProjectID: projectID,
+	})
+	if err == nil {
+		t.Errorf("expected an error creating a second config for the project")
+	}
+}
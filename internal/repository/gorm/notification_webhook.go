@@ -0,0 +1,144 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// the number of deliveries which are kept for each webhook
+const maxNotificationWebhookDeliveries = 100
+
+// NotificationWebhookRepository uses gorm.DB for querying the database
+type NotificationWebhookRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewNotificationWebhookRepository returns a NotificationWebhookRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// webhook secrets
+func NewNotificationWebhookRepository(db *gorm.DB, key *[32]byte) repository.NotificationWebhookRepository {
+	return &NotificationWebhookRepository{db, key}
+}
+
+// CreateNotificationWebhook creates a new notification webhook
+func (repo *NotificationWebhookRepository) CreateNotificationWebhook(webhook *models.NotificationWebhook) (*models.NotificationWebhook, error) {
+	secret := webhook.Secret
+
+	cipherData, err := encryption.Encrypt(webhook.Secret, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = cipherData
+
+	if err := repo.db.Create(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	webhook.Secret = secret
+
+	return webhook, nil
+}
+
+// ReadNotificationWebhook reads a notification webhook of a project by ID
+func (repo *NotificationWebhookRepository) ReadNotificationWebhook(projectID, webhookID uint) (*models.NotificationWebhook, error) {
+	webhook := &models.NotificationWebhook{}
+
+	if err := repo.db.Where("project_id = ? AND id = ?", projectID, webhookID).First(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.decryptSecret(webhook); err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// ListNotificationWebhooksByProjectID lists the notification webhooks of a project
+func (repo *NotificationWebhookRepository) ListNotificationWebhooksByProjectID(projectID uint) ([]*models.NotificationWebhook, error) {
+	webhooks := []*models.NotificationWebhook{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("id asc").Find(&webhooks).Error; err != nil {
+		return nil, err
+	}
+
+	for _, webhook := range webhooks {
+		if err := repo.decryptSecret(webhook); err != nil {
+			return nil, err
+		}
+	}
+
+	return webhooks, nil
+}
+
+// DeleteNotificationWebhook deletes a notification webhook along with its deliveries
+func (repo *NotificationWebhookRepository) DeleteNotificationWebhook(webhook *models.NotificationWebhook) (*models.NotificationWebhook, error) {
+	if err := repo.db.Where("notification_webhook_id = ?", webhook.ID).Delete(&models.NotificationWebhookDelivery{}).Error; err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Delete(webhook).Error; err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+// CreateNotificationWebhookDelivery records a new delivery, removing the oldest deliveries of the
+// webhook to implement a basic fixed-length buffer
+func (repo *NotificationWebhookRepository) CreateNotificationWebhookDelivery(delivery *models.NotificationWebhookDelivery) (*models.NotificationWebhookDelivery, error) {
+	err := repo.db.Exec(`
+		DELETE FROM notification_webhook_deliveries
+		WHERE notification_webhook_id = ? AND id NOT IN (
+			SELECT id FROM notification_webhook_deliveries d2 WHERE d2.notification_webhook_id = ? ORDER BY d2.id desc LIMIT ?
+		)
+	`, delivery.NotificationWebhookID, delivery.NotificationWebhookID, maxNotificationWebhookDeliveries-1).Error
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repo.db.Create(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// UpdateNotificationWebhookDelivery updates a given delivery
+func (repo *NotificationWebhookRepository) UpdateNotificationWebhookDelivery(delivery *models.NotificationWebhookDelivery) (*models.NotificationWebhookDelivery, error) {
+	if err := repo.db.Save(delivery).Error; err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// ListNotificationWebhookDeliveries lists the deliveries of a webhook, most recent first
+func (repo *NotificationWebhookRepository) ListNotificationWebhookDeliveries(webhookID uint) ([]*models.NotificationWebhookDelivery, error) {
+	deliveries := []*models.NotificationWebhookDelivery{}
+
+	if err := repo.db.Where("notification_webhook_id = ?", webhookID).Order("id desc").Find(&deliveries).Error; err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
+
+func (repo *NotificationWebhookRepository) decryptSecret(webhook *models.NotificationWebhook) error {
+	if len(webhook.Secret) == 0 {
+		return nil
+	}
+
+	plaintext, err := encryption.Decrypt(webhook.Secret, repo.key)
+	if err != nil {
+		return err
+	}
+
+	webhook.Secret = plaintext
+
+	return nil
+}
@@ -0,0 +1,167 @@
+package gorm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/models"
+	orm "gorm.io/gorm"
+)
+
+func TestNotificationWebhookRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_webhook.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+
+	webhook := &models.NotificationWebhook{
+		ProjectID:        projectID,
+		Name:             "deploys",
+		URL:              "https://example.com/hooks/porter",
+		ClusterID:        1,
+		ReleaseName:      "web",
+		ReleaseNamespace: "default",
+		Secret:           []byte("webhook-secret"),
+	}
+
+	webhook, err := tester.repo.NotificationWebhook().CreateNotificationWebhook(webhook)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(webhook.Secret) != "webhook-secret" {
+		t.Errorf("expected the created webhook to keep the plaintext secret, got %s\n", webhook.Secret)
+	}
+
+	// the secret is encrypted in the database
+	stored := &models.NotificationWebhook{}
+
+	if err := tester.db.First(stored, webhook.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if bytes.Equal(stored.Secret, []byte("webhook-secret")) {
+		t.Errorf("expected the secret to be encrypted in the database")
+	}
+
+	plaintext, err := encryption.Decrypt(stored.Secret, tester.key)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if string(plaintext) != "webhook-secret" {
+		t.Errorf("incorrect decrypted secret: expected %s, got %s\n", "webhook-secret", plaintext)
+	}
+
+	readWebhook, err := tester.repo.NotificationWebhook().ReadNotificationWebhook(projectID, webhook.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff := deep.Equal(webhook, readWebhook); diff != nil {
+		t.Errorf("webhooks not equal:")
+		t.Error(diff)
+	}
+
+	// webhooks of other projects are not returned
+	_, err = tester.repo.NotificationWebhook().ReadNotificationWebhook(projectID+1, webhook.ID)
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
+	}
+
+	webhooks, err := tester.repo.NotificationWebhook().ListNotificationWebhooksByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(webhooks) != 1 {
+		t.Fatalf("length of webhooks incorrect: expected %d, got %d\n", 1, len(webhooks))
+	}
+
+	if string(webhooks[0].Secret) != "webhook-secret" {
+		t.Errorf("expected listed webhooks to have a decrypted secret, got %s\n", webhooks[0].Secret)
+	}
+}
+
+func TestNotificationWebhookDeliveries(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_notification_webhook_deliveries.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	webhook, err := tester.repo.NotificationWebhook().CreateNotificationWebhook(&models.NotificationWebhook{
+		ProjectID: tester.initProjects[0].Model.ID,
+		Name:      "incidents",
+		URL:       "https://example.com/hooks/porter",
+		Secret:    []byte("webhook-secret"),
+	})
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	for _, event := range []string{"deployment.succeeded", "incident.started"} {
+		_, err := tester.repo.NotificationWebhook().CreateNotificationWebhookDelivery(&models.NotificationWebhookDelivery{
+			NotificationWebhookID: webhook.ID,
+			DeliveryID:            event,
+			Event:                 event,
+		})
+		if err != nil {
+			t.Fatalf("%v\n", err)
+		}
+	}
+
+	deliveries, err := tester.repo.NotificationWebhook().ListNotificationWebhookDeliveries(webhook.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(deliveries) != 2 {
+		t.Fatalf("length of deliveries incorrect: expected %d, got %d\n", 2, len(deliveries))
+	}
+
+	// the most recent delivery is listed first
+	if deliveries[0].Event != "incident.started" {
+		t.Errorf("incorrect first delivery: expected %s, got %s\n", "incident.started", deliveries[0].Event)
+	}
+
+	deliveries[0].Attempts = 1
+	deliveries[0].StatusCode = 200
+
+	_, err = tester.repo.NotificationWebhook().UpdateNotificationWebhookDelivery(deliveries[0])
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	deliveries, err = tester.repo.NotificationWebhook().ListNotificationWebhookDeliveries(webhook.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if deliveries[0].StatusCode != 200 {
+		t.Errorf("incorrect status code: expected %d, got %d\n", 200, deliveries[0].StatusCode)
+	}
+
+	_, err = tester.repo.NotificationWebhook().DeleteNotificationWebhook(webhook)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	deliveries, err = tester.repo.NotificationWebhook().ListNotificationWebhookDeliveries(webhook.ID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(deliveries) != 0 {
+		t.Errorf("expected the deliveries to be deleted along with the webhook, got %d\n", len(deliveries))
+	}
+}
@@ -0,0 +1,73 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// OPAPolicyCollectionRepository uses gorm.DB for querying the database
+type OPAPolicyCollectionRepository struct {
+	db *gorm.DB
+}
+
+// NewOPAPolicyCollectionRepository returns an OPAPolicyCollectionRepository which uses
+// gorm.DB for querying the database
+func NewOPAPolicyCollectionRepository(db *gorm.DB) repository.OPAPolicyCollectionRepository {
+	return &OPAPolicyCollectionRepository{db}
+}
+
+func (repo *OPAPolicyCollectionRepository) CreateOPAPolicyCollection(
+	collection *models.OPAPolicyCollection,
+) (*models.OPAPolicyCollection, error) {
+	if err := repo.db.Create(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) ReadOPAPolicyCollectionByName(
+	projectID uint,
+	name string,
+) (*models.OPAPolicyCollection, error) {
+	collection := &models.OPAPolicyCollection{}
+
+	if err := repo.db.Where("project_id = ? AND name = ?", projectID, name).First(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) ListOPAPolicyCollectionsByProjectID(
+	projectID uint,
+) ([]*models.OPAPolicyCollection, error) {
+	collections := make([]*models.OPAPolicyCollection, 0)
+
+	if err := repo.db.Where("project_id = ?", projectID).Order("name asc").Find(&collections).Error; err != nil {
+		return nil, err
+	}
+
+	return collections, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) UpdateOPAPolicyCollection(
+	collection *models.OPAPolicyCollection,
+) (*models.OPAPolicyCollection, error) {
+	if err := repo.db.Save(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) DeleteOPAPolicyCollection(
+	collection *models.OPAPolicyCollection,
+) (*models.OPAPolicyCollection, error) {
+	if err := repo.db.Delete(collection).Error; err != nil {
+		return nil, err
+	}
+
+	return collection, nil
+}
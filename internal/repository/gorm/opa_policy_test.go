@@ -0,0 +1,95 @@
+package gorm_test
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/porter-dev/porter/internal/models"
+	orm "gorm.io/gorm"
+)
+
+func TestOPAPolicyCollectionRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_opa_policy_collection.db",
+	}
+
+	setupTestEnv(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+
+	collection := &models.OPAPolicyCollection{
+		ProjectID:        projectID,
+		Name:             "web-replicas",
+		Kind:             "helm_release",
+		MatchBytes:       []byte(`{"chart_name":"web"}`),
+		MustExist:        true,
+		OverrideSeverity: "high",
+		PoliciesBytes:    []byte(`[{"name":"replicas","module":"package replicas"}]`),
+	}
+
+	collection, err := tester.repo.OPAPolicyCollection().CreateOPAPolicyCollection(collection)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	readCollection, err := tester.repo.OPAPolicyCollection().ReadOPAPolicyCollectionByName(projectID, "web-replicas")
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if diff := deep.Equal(collection, readCollection); diff != nil {
+		t.Errorf("collections not equal:")
+		t.Error(diff)
+	}
+
+	// collections of other projects are not returned
+	_, err = tester.repo.OPAPolicyCollection().ReadOPAPolicyCollectionByName(projectID+1, "web-replicas")
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
+	}
+
+	readCollection.OverrideSeverity = "low"
+
+	_, err = tester.repo.OPAPolicyCollection().UpdateOPAPolicyCollection(readCollection)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	collections, err := tester.repo.OPAPolicyCollection().ListOPAPolicyCollectionsByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(collections) != 1 {
+		t.Fatalf("length of collections incorrect: expected %d, got %d\n", 1, len(collections))
+	}
+
+	if collections[0].OverrideSeverity != "low" {
+		t.Errorf("incorrect severity: expected %s, got %s\n", "low", collections[0].OverrideSeverity)
+	}
+
+	typed, err := collections[0].ToOPAPolicyCollectionType()
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(typed.Policies) != 1 || typed.Policies[0].Name != "replicas" {
+		t.Errorf("policies not decoded: got %v\n", typed.Policies)
+	}
+
+	if typed.Match.ChartName != "web" {
+		t.Errorf("incorrect match chart name: expected %s, got %s\n", "web", typed.Match.ChartName)
+	}
+
+	_, err = tester.repo.OPAPolicyCollection().DeleteOPAPolicyCollection(collections[0])
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	_, err = tester.repo.OPAPolicyCollection().ReadOPAPolicyCollectionByName(projectID, "web-replicas")
+	if err != orm.ErrRecordNotFound {
+		t.Fatalf("incorrect error: expected %v, got %v\n", orm.ErrRecordNotFound, err)
+	}
+}
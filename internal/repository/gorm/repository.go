@@ -33,10 +33,16 @@ type GormRepository struct {
 	githubAppInstallation     repository.GithubAppInstallationRepository
 	githubAppOAuthIntegration repository.GithubAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	teamsIntegration          repository.TeamsIntegrationRepository
+	discordIntegration        repository.DiscordIntegrationRepository
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
+	monitorNotificationConfig repository.MonitorNotificationConfigRepository
+	notificationWebhook       repository.NotificationWebhookRepository
+	notificationPipeline      repository.NotificationPipelineRepository
+	auditEvent                repository.AuditEventRepository
 	buildEvent                repository.BuildEventRepository
 	kubeEvent                 repository.KubeEventRepository
 	projectUsage              repository.ProjectUsageRepository
@@ -49,6 +55,7 @@ type GormRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	opaPolicyCollection       repository.OPAPolicyCollectionRepository
 	apiContractRevisions      repository.APIContractRevisioner
 	awsAssumeRoleChainer      repository.AWSAssumeRoleChainer
 }
@@ -157,6 +164,14 @@ func (t *GormRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *GormRepository) TeamsIntegration() repository.TeamsIntegrationRepository {
+	return t.teamsIntegration
+}
+
+func (t *GormRepository) DiscordIntegration() repository.DiscordIntegrationRepository {
+	return t.discordIntegration
+}
+
 func (t *GormRepository) GitlabIntegration() repository.GitlabIntegrationRepository {
 	return t.gitlabIntegration
 }
@@ -173,6 +188,22 @@ func (t *GormRepository) JobNotificationConfig() repository.JobNotificationConfi
 	return t.jobNotificationConfig
 }
 
+func (t *GormRepository) MonitorNotificationConfig() repository.MonitorNotificationConfigRepository {
+	return t.monitorNotificationConfig
+}
+
+func (t *GormRepository) NotificationWebhook() repository.NotificationWebhookRepository {
+	return t.notificationWebhook
+}
+
+func (t *GormRepository) NotificationPipeline() repository.NotificationPipelineRepository {
+	return t.notificationPipeline
+}
+
+func (t *GormRepository) AuditEvent() repository.AuditEventRepository {
+	return t.auditEvent
+}
+
 func (t *GormRepository) BuildEvent() repository.BuildEventRepository {
 	return t.buildEvent
 }
@@ -221,6 +252,10 @@ func (t *GormRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *GormRepository) OPAPolicyCollection() repository.OPAPolicyCollectionRepository {
+	return t.opaPolicyCollection
+}
+
 func (t *GormRepository) APIContractRevisioner() repository.APIContractRevisioner {
 	return t.apiContractRevisions
 }
@@ -259,10 +294,16 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		githubAppInstallation:     NewGithubAppInstallationRepository(db),
 		githubAppOAuthIntegration: NewGithubAppOAuthIntegrationRepository(db),
 		slackIntegration:          NewSlackIntegrationRepository(db, key),
+		teamsIntegration:          NewTeamsIntegrationRepository(db, key),
+		discordIntegration:        NewDiscordIntegrationRepository(db, key),
 		gitlabIntegration:         NewGitlabIntegrationRepository(db, key, storageBackend),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(db, key, storageBackend),
 		notificationConfig:        NewNotificationConfigRepository(db),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(db),
+		monitorNotificationConfig: NewMonitorNotificationConfigRepository(db),
+		notificationWebhook:       NewNotificationWebhookRepository(db, key),
+		notificationPipeline:      NewNotificationPipelineRepository(db),
+		auditEvent:                NewAuditEventRepository(db, key),
 		buildEvent:                NewBuildEventRepository(db),
 		kubeEvent:                 NewKubeEventRepository(db, key),
 		projectUsage:              NewProjectUsageRepository(db),
@@ -275,6 +316,7 @@ func NewRepository(db *gorm.DB, key *[32]byte, storageBackend credentials.Creden
 		tag:                       NewTagRepository(db),
 		stack:                     NewStackRepository(db),
 		monitor:                   NewMonitorTestResultRepository(db),
+		opaPolicyCollection:       NewOPAPolicyCollectionRepository(db),
 		apiContractRevisions:      NewAPIContractRevisioner(db),
 		awsAssumeRoleChainer:      NewAWSAssumeRoleChainer(db),
 	}
@@ -0,0 +1,79 @@
+package gorm
+
+import (
+	"github.com/porter-dev/porter/internal/encryption"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+// TeamsIntegrationRepository uses gorm.DB for querying the database
+type TeamsIntegrationRepository struct {
+	db  *gorm.DB
+	key *[32]byte
+}
+
+// NewTeamsIntegrationRepository returns a TeamsIntegrationRepository which uses
+// gorm.DB for querying the database. It accepts an encryption key to encrypt
+// sensitive data
+func NewTeamsIntegrationRepository(
+	db *gorm.DB,
+	key *[32]byte,
+) repository.TeamsIntegrationRepository {
+	return &TeamsIntegrationRepository{db, key}
+}
+
+// CreateTeamsIntegration creates a new Microsoft Teams integration
+func (repo *TeamsIntegrationRepository) CreateTeamsIntegration(
+	teamsInt *ints.TeamsIntegration,
+) (*ints.TeamsIntegration, error) {
+	cipherData, err := encryption.Encrypt(teamsInt.Webhook, repo.key)
+	if err != nil {
+		return nil, err
+	}
+
+	teamsInt.Webhook = cipherData
+
+	if err := repo.db.Create(teamsInt).Error; err != nil {
+		return nil, err
+	}
+
+	return teamsInt, nil
+}
+
+// ListTeamsIntegrationsByProjectID finds all Microsoft Teams integrations
+// for a given project id
+func (repo *TeamsIntegrationRepository) ListTeamsIntegrationsByProjectID(
+	projectID uint,
+) ([]*ints.TeamsIntegration, error) {
+	teamsInts := []*ints.TeamsIntegration{}
+
+	if err := repo.db.Where("project_id = ?", projectID).Find(&teamsInts).Error; err != nil {
+		return nil, err
+	}
+
+	for _, teamsInt := range teamsInts {
+		if len(teamsInt.Webhook) > 0 {
+			plaintext, err := encryption.Decrypt(teamsInt.Webhook, repo.key)
+			if err != nil {
+				return nil, err
+			}
+
+			teamsInt.Webhook = plaintext
+		}
+	}
+
+	return teamsInts, nil
+}
+
+// DeleteTeamsIntegration deletes a Microsoft Teams integration by ID
+func (repo *TeamsIntegrationRepository) DeleteTeamsIntegration(
+	integrationID uint,
+) error {
+	if err := repo.db.Where("id = ?", integrationID).Delete(&ints.TeamsIntegration{}).Error; err != nil {
+		return err
+	}
+
+	return nil
+}
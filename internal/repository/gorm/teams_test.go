@@ -0,0 +1,86 @@
+package gorm_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/porter-dev/porter/internal/encryption"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+)
+
+func TestTeamsIntegrationRoundTrip(t *testing.T) {
+	tester := &tester{
+		dbFileName: "./porter_teams_integration.db",
+	}
+
+	setupTestEnv(tester, t)
+	initUser(tester, t)
+	initProject(tester, t)
+	defer cleanup(tester, t)
+
+	projectID := tester.initProjects[0].Model.ID
+	webhookURL := []byte("https://example.webhook.office.com/webhookb2/abc")
+
+	teamsInt := &ints.TeamsIntegration{
+		UserID:    tester.initUsers[0].Model.ID,
+		ProjectID: projectID,
+		Channel:   "deploys",
+		Webhook:   webhookURL,
+	}
+
+	teamsInt, err := tester.repo.TeamsIntegration().CreateTeamsIntegration(teamsInt)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	// the webhook URL is encrypted in the database
+	stored := &ints.TeamsIntegration{}
+
+	if err := tester.db.First(stored, teamsInt.ID).Error; err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if bytes.Equal(stored.Webhook, webhookURL) {
+		t.Errorf("expected the webhook URL to be encrypted in the database")
+	}
+
+	plaintext, err := encryption.Decrypt(stored.Webhook, tester.key)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if !bytes.Equal(plaintext, webhookURL) {
+		t.Errorf("incorrect decrypted webhook URL: expected %s, got %s\n", webhookURL, plaintext)
+	}
+
+	teamsInts, err := tester.repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(teamsInts) != 1 {
+		t.Fatalf("length of integrations incorrect: expected %d, got %d\n", 1, len(teamsInts))
+	}
+
+	if !bytes.Equal(teamsInts[0].Webhook, webhookURL) {
+		t.Errorf("incorrect listed webhook URL: expected %s, got %s\n", webhookURL, teamsInts[0].Webhook)
+	}
+
+	if teamsInts[0].Channel != "deploys" {
+		t.Errorf("incorrect channel: expected %s, got %s\n", "deploys", teamsInts[0].Channel)
+	}
+
+	if err := tester.repo.TeamsIntegration().DeleteTeamsIntegration(teamsInt.ID); err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	teamsInts, err = tester.repo.TeamsIntegration().ListTeamsIntegrationsByProjectID(projectID)
+	if err != nil {
+		t.Fatalf("%v\n", err)
+	}
+
+	if len(teamsInts) != 0 {
+		t.Errorf("length of integrations incorrect: expected %d, got %d\n", 0, len(teamsInts))
+	}
+}
@@ -53,6 +53,22 @@ type SlackIntegrationRepository interface {
 	DeleteSlackIntegration(integrationID uint) error
 }
 
+// TeamsIntegrationRepository represents the set of queries on the Microsoft Teams
+// integration
+type TeamsIntegrationRepository interface {
+	CreateTeamsIntegration(teamsInt *ints.TeamsIntegration) (*ints.TeamsIntegration, error)
+	ListTeamsIntegrationsByProjectID(projectID uint) ([]*ints.TeamsIntegration, error)
+	DeleteTeamsIntegration(integrationID uint) error
+}
+
+// DiscordIntegrationRepository represents the set of queries on the Discord
+// integration
+type DiscordIntegrationRepository interface {
+	CreateDiscordIntegration(discordInt *ints.DiscordIntegration) (*ints.DiscordIntegration, error)
+	ListDiscordIntegrationsByProjectID(projectID uint) ([]*ints.DiscordIntegration, error)
+	DeleteDiscordIntegration(integrationID uint) error
+}
+
 // AWSIntegrationRepository represents the set of queries on the AWS auth
 // mechanism
 type AWSIntegrationRepository interface {
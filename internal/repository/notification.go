@@ -15,3 +15,9 @@ type JobNotificationConfigRepository interface {
 	ReadNotificationConfig(projID, clusterID uint, name, namespace string) (*models.JobNotificationConfig, error)
 	UpdateNotificationConfig(am *models.JobNotificationConfig) (*models.JobNotificationConfig, error)
 }
+
+type MonitorNotificationConfigRepository interface {
+	CreateMonitorNotificationConfig(conf *models.MonitorNotificationConfig) (*models.MonitorNotificationConfig, error)
+	ReadMonitorNotificationConfigByProjectID(projectID uint) (*models.MonitorNotificationConfig, error)
+	UpdateMonitorNotificationConfig(conf *models.MonitorNotificationConfig) (*models.MonitorNotificationConfig, error)
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+)
+
+type NotificationPipelineRepository interface {
+	ReserveNotification(throttle *models.NotificationThrottle, sentAfter time.Time) (bool, error)
+	ReleaseNotification(throttle *models.NotificationThrottle) error
+	DeleteNotificationThrottlesBefore(before time.Time) error
+
+	IncrementNotificationChannelUsage(channel string, windowStart time.Time) (uint, error)
+
+	CreateNotificationDigestEntry(entry *models.NotificationDigestEntry) (*models.NotificationDigestEntry, error)
+	ListDueNotificationDigestEntries(hourlyBefore, dailyBefore time.Time) ([]*models.NotificationDigestEntry, error)
+	DeleteNotificationDigestEntries(ids []uint) error
+}
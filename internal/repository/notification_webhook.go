@@ -0,0 +1,14 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+type NotificationWebhookRepository interface {
+	CreateNotificationWebhook(webhook *models.NotificationWebhook) (*models.NotificationWebhook, error)
+	ReadNotificationWebhook(projectID, webhookID uint) (*models.NotificationWebhook, error)
+	ListNotificationWebhooksByProjectID(projectID uint) ([]*models.NotificationWebhook, error)
+	DeleteNotificationWebhook(webhook *models.NotificationWebhook) (*models.NotificationWebhook, error)
+
+	CreateNotificationWebhookDelivery(delivery *models.NotificationWebhookDelivery) (*models.NotificationWebhookDelivery, error)
+	UpdateNotificationWebhookDelivery(delivery *models.NotificationWebhookDelivery) (*models.NotificationWebhookDelivery, error)
+	ListNotificationWebhookDeliveries(webhookID uint) ([]*models.NotificationWebhookDelivery, error)
+}
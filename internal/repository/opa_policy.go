@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/porter-dev/porter/internal/models"
+
+// OPAPolicyCollectionRepository represents the set of queries on the OPAPolicyCollection model
+type OPAPolicyCollectionRepository interface {
+	CreateOPAPolicyCollection(collection *models.OPAPolicyCollection) (*models.OPAPolicyCollection, error)
+	ReadOPAPolicyCollectionByName(projectID uint, name string) (*models.OPAPolicyCollection, error)
+	ListOPAPolicyCollectionsByProjectID(projectID uint) ([]*models.OPAPolicyCollection, error)
+	UpdateOPAPolicyCollection(collection *models.OPAPolicyCollection) (*models.OPAPolicyCollection, error)
+	DeleteOPAPolicyCollection(collection *models.OPAPolicyCollection) (*models.OPAPolicyCollection, error)
+}
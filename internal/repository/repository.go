@@ -27,10 +27,16 @@ type Repository interface {
 	GithubAppInstallation() GithubAppInstallationRepository
 	GithubAppOAuthIntegration() GithubAppOAuthIntegrationRepository
 	SlackIntegration() SlackIntegrationRepository
+	TeamsIntegration() TeamsIntegrationRepository
+	DiscordIntegration() DiscordIntegrationRepository
 	GitlabIntegration() GitlabIntegrationRepository
 	GitlabAppOAuthIntegration() GitlabAppOAuthIntegrationRepository
 	NotificationConfig() NotificationConfigRepository
 	JobNotificationConfig() JobNotificationConfigRepository
+	MonitorNotificationConfig() MonitorNotificationConfigRepository
+	NotificationWebhook() NotificationWebhookRepository
+	NotificationPipeline() NotificationPipelineRepository
+	AuditEvent() AuditEventRepository
 	BuildEvent() BuildEventRepository
 	KubeEvent() KubeEventRepository
 	ProjectUsage() ProjectUsageRepository
@@ -43,6 +49,7 @@ type Repository interface {
 	Tag() TagRepository
 	Stack() StackRepository
 	MonitorTestResult() MonitorTestResultRepository
+	OPAPolicyCollection() OPAPolicyCollectionRepository
 	APIContractRevisioner() APIContractRevisioner
 	AWSAssumeRoleChainer() AWSAssumeRoleChainer
 }
@@ -1,32 +1,105 @@
 package test
 
 import (
+	"errors"
+	"time"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
+// APITokenRepository implements repository.APITokenRepository, keeping tokens in memory
 type APITokenRepository struct {
 	canQuery bool
+	tokens   []*models.APIToken
 }
 
+// NewAPITokenRepository will return errors if canQuery is false
 func NewAPITokenRepository(canQuery bool) repository.APITokenRepository {
-	return &APITokenRepository{canQuery}
+	return &APITokenRepository{canQuery, []*models.APIToken{}}
 }
 
 func (repo *APITokenRepository) CreateAPIToken(a *models.APIToken) (*models.APIToken, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.tokens = append(repo.tokens, a)
+	a.ID = uint(len(repo.tokens))
+
+	return a, nil
 }
 
 func (repo *APITokenRepository) ListAPITokensByProjectID(projectID uint) ([]*models.APIToken, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.APIToken, 0)
+
+	for _, tok := range repo.tokens {
+		if tok.ProjectID == projectID {
+			res = append(res, tok)
+		}
+	}
+
+	return res, nil
 }
 
 func (repo *APITokenRepository) ReadAPIToken(projectID uint, uid string) (*models.APIToken, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, tok := range repo.tokens {
+		if tok.ProjectID == projectID && tok.UniqueID == uid {
+			return tok, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *APITokenRepository) UpdateAPIToken(
 	token *models.APIToken,
 ) (*models.APIToken, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(token.ID-1) >= len(repo.tokens) || repo.tokens[token.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.tokens[token.ID-1] = token
+
+	return token, nil
+}
+
+func (repo *APITokenRepository) UpdateAPITokenUsage(token *models.APIToken) error {
+	_, err := repo.UpdateAPIToken(token)
+
+	return err
+}
+
+func (repo *APITokenRepository) ListStaleAPITokensByProjectID(
+	projectID uint,
+	unusedSince time.Time,
+) ([]*models.APIToken, error) {
+	tokens, err := repo.ListAPITokensByProjectID(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make([]*models.APIToken, 0)
+
+	for _, tok := range tokens {
+		if !tok.Revoked && ((tok.LastUsedAt != nil && tok.LastUsedAt.Before(unusedSince)) ||
+			(tok.LastUsedAt == nil && tok.CreatedAt.Before(unusedSince))) {
+			res = append(res, tok)
+		}
+	}
+
+	return res, nil
 }
@@ -0,0 +1,88 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// AuditEventRepository will return errors on queries if canQuery is false, and only stores
+// the created audit events in-memory, since every mutating request records an audit event
+type AuditEventRepository struct {
+	canQuery bool
+	events   []*models.AuditEvent
+}
+
+func NewAuditEventRepository(canQuery bool) repository.AuditEventRepository {
+	return &AuditEventRepository{canQuery, []*models.AuditEvent{}}
+}
+
+func (repo *AuditEventRepository) CreateAuditEvent(event *models.AuditEvent) (*models.AuditEvent, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.events = append(repo.events, event)
+	event.ID = uint(len(repo.events))
+
+	return event, nil
+}
+
+func (repo *AuditEventRepository) ListAuditEventsByProjectID(
+	projectID uint,
+	opts *types.ListAuditEventsRequest,
+	limit, skip int,
+) ([]*models.AuditEvent, int64, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) ListAuditEventProjectIDs() ([]uint, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) DeleteAuditEventsBefore(projectID uint, before time.Time, maxID uint) error {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) ListAuditEventsAfterID(
+	projectID, afterID uint,
+	createdBefore time.Time,
+	limit int,
+) ([]*models.AuditEvent, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) GetLastAuditEventIDBefore(projectID uint, before time.Time) (uint, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) CreateAuditSink(sink *models.AuditSink) (*models.AuditSink, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) ReadAuditSink(projectID, sinkID uint) (*models.AuditSink, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) ListAuditSinksByProjectID(projectID uint) ([]*models.AuditSink, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) ListAuditSinks() ([]*models.AuditSink, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) UpdateAuditSinkCursor(sink *models.AuditSink) (*models.AuditSink, error) {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) UpdateAuditSinkDelivery(sink *models.AuditSink, prevLastEventID uint) error {
+	panic("unimplemented")
+}
+
+func (repo *AuditEventRepository) DeleteAuditSink(sink *models.AuditSink) (*models.AuditSink, error) {
+	panic("unimplemented")
+}
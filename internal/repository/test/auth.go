@@ -653,7 +653,7 @@ func (repo *GitlabIntegrationRepository) ReadGitlabIntegration(projectID, id uin
 		return nil, errors.New("Cannot read from database")
 	}
 
-	if int(id-1) >= len(repo.gitlabIntegrations) || repo.gitlabIntegrations[id-1] == nil {
+	if id == 0 || int(id-1) >= len(repo.gitlabIntegrations) || repo.gitlabIntegrations[id-1] == nil {
 		return nil, gorm.ErrRecordNotFound
 	}
 
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// DiscordIntegrationRepository implements repository.DiscordIntegrationRepository, keeping
+// integrations in memory
+type DiscordIntegrationRepository struct {
+	canQuery     bool
+	integrations []*ints.DiscordIntegration
+}
+
+func NewDiscordIntegrationRepository(canQuery bool) repository.DiscordIntegrationRepository {
+	return &DiscordIntegrationRepository{canQuery, []*ints.DiscordIntegration{}}
+}
+
+func (s *DiscordIntegrationRepository) CreateDiscordIntegration(discordInt *ints.DiscordIntegration) (*ints.DiscordIntegration, error) {
+	if !s.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	s.integrations = append(s.integrations, discordInt)
+	discordInt.ID = uint(len(s.integrations))
+
+	return discordInt, nil
+}
+
+func (s *DiscordIntegrationRepository) ListDiscordIntegrationsByProjectID(projectID uint) ([]*ints.DiscordIntegration, error) {
+	if !s.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.DiscordIntegration, 0)
+
+	for _, discordInt := range s.integrations {
+		if discordInt != nil && discordInt.ProjectID == projectID {
+			res = append(res, discordInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *DiscordIntegrationRepository) DeleteDiscordIntegration(integrationID uint) error {
+	if !s.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if integrationID == 0 || int(integrationID-1) >= len(s.integrations) || s.integrations[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	s.integrations[integrationID-1] = nil
+
+	return nil
+}
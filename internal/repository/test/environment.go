@@ -1,21 +1,40 @@
 package test
 
 import (
+	"errors"
+	"strings"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
-// EnvironmentRepository uses gorm.DB for querying the database
-type EnvironmentRepository struct{}
+// EnvironmentRepository implements repository.EnvironmentRepository, keeping environments and
+// deployments in memory
+type EnvironmentRepository struct {
+	canQuery     bool
+	environments []*models.Environment
+	deployments  []*models.Deployment
+}
 
-// NewEnvironmentRepository returns a DefaultEnvironmentRepository which uses
-// gorm.DB for querying the database
-func NewEnvironmentRepository() repository.EnvironmentRepository {
-	return &EnvironmentRepository{}
+// NewEnvironmentRepository will return errors if canQuery is false
+func NewEnvironmentRepository(canQuery bool) repository.EnvironmentRepository {
+	return &EnvironmentRepository{
+		canQuery,
+		[]*models.Environment{},
+		[]*models.Deployment{},
+	}
 }
 
 func (repo *EnvironmentRepository) CreateEnvironment(env *models.Environment) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.environments = append(repo.environments, env)
+	env.ID = uint(len(repo.environments))
+
+	return env, nil
 }
 
 func (repo *EnvironmentRepository) ReadEnvironment(projectID, clusterID, gitInstallationID uint, gitRepoOwner, gitRepoName string) (*models.Environment, error) {
@@ -23,7 +42,21 @@ func (repo *EnvironmentRepository) ReadEnvironment(projectID, clusterID, gitInst
 }
 
 func (repo *EnvironmentRepository) ReadEnvironmentByID(projectID, clusterID, envID uint) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(envID-1) >= len(repo.environments) || repo.environments[envID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	env := repo.environments[envID-1]
+
+	if env.ProjectID != projectID || env.ClusterID != clusterID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return env, nil
 }
 
 func (repo *EnvironmentRepository) ReadEnvironmentByOwnerRepoName(
@@ -34,15 +67,51 @@ func (repo *EnvironmentRepository) ReadEnvironmentByOwnerRepoName(
 }
 
 func (repo *EnvironmentRepository) ReadEnvironmentByWebhookIDOwnerRepoName(webhookID, owner, repoName string) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	// the latest matching environment is returned, as in the gorm repository
+	for i := len(repo.environments) - 1; i >= 0; i-- {
+		env := repo.environments[i]
+
+		if env != nil && env.WebhookID == webhookID && strings.EqualFold(env.GitRepoOwner, owner) &&
+			strings.EqualFold(env.GitRepoName, repoName) {
+			return env, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *EnvironmentRepository) ListEnvironments(projectID, clusterID uint) ([]*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Environment, 0)
+
+	for _, env := range repo.environments {
+		if env != nil && env.ProjectID == projectID && env.ClusterID == clusterID {
+			res = append(res, env)
+		}
+	}
+
+	return res, nil
 }
 
 func (repo *EnvironmentRepository) UpdateEnvironment(environment *models.Environment) (*models.Environment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(environment.ID-1) >= len(repo.environments) || repo.environments[environment.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.environments[environment.ID-1] = environment
+
+	return environment, nil
 }
 
 func (repo *EnvironmentRepository) DeleteEnvironment(env *models.Environment) (*models.Environment, error) {
@@ -50,11 +119,28 @@ func (repo *EnvironmentRepository) DeleteEnvironment(env *models.Environment) (*
 }
 
 func (repo *EnvironmentRepository) CreateDeployment(deployment *models.Deployment) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.deployments = append(repo.deployments, deployment)
+	deployment.ID = uint(len(repo.deployments))
+
+	return deployment, nil
 }
 
 func (repo *EnvironmentRepository) UpdateDeployment(deployment *models.Deployment) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(deployment.ID-1) >= len(repo.deployments) || repo.deployments[deployment.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deployments[deployment.ID-1] = deployment
+
+	return deployment, nil
 }
 
 func (repo *EnvironmentRepository) ReadDeployment(environmentID uint, namespace string) (*models.Deployment, error) {
@@ -62,11 +148,37 @@ func (repo *EnvironmentRepository) ReadDeployment(environmentID uint, namespace
 }
 
 func (repo *EnvironmentRepository) ReadDeploymentByID(projectID, clusterID, id uint) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if int(id-1) >= len(repo.deployments) || repo.deployments[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	depl := repo.deployments[id-1]
+
+	// deployments belong to a project and cluster through their environment
+	if _, err := repo.ReadEnvironmentByID(projectID, clusterID, depl.EnvironmentID); err != nil {
+		return nil, err
+	}
+
+	return depl, nil
 }
 
 func (repo *EnvironmentRepository) ReadDeploymentByGitDetails(environmentID uint, owner, repoName string, prNumber uint) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, depl := range repo.deployments {
+		if depl != nil && depl.EnvironmentID == environmentID && strings.EqualFold(depl.RepoOwner, owner) &&
+			strings.EqualFold(depl.RepoName, repoName) && depl.PullRequestID == prNumber {
+			return depl, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *EnvironmentRepository) ReadDeploymentForBranch(environmentID uint, owner, name, branch string) (*models.Deployment, error) {
@@ -78,9 +190,47 @@ func (repo *EnvironmentRepository) ListDeploymentsByCluster(projectID, clusterID
 }
 
 func (repo *EnvironmentRepository) ListDeployments(environmentID uint, states ...string) ([]*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Deployment, 0)
+
+	for _, depl := range repo.deployments {
+		if depl == nil || depl.EnvironmentID != environmentID {
+			continue
+		}
+
+		if len(states) > 0 && !hasDeploymentState(depl, states) {
+			continue
+		}
+
+		res = append(res, depl)
+	}
+
+	return res, nil
+}
+
+func hasDeploymentState(depl *models.Deployment, states []string) bool {
+	for _, state := range states {
+		if string(depl.Status) == state {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (repo *EnvironmentRepository) DeleteDeployment(deployment *models.Deployment) (*models.Deployment, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if int(deployment.ID-1) >= len(repo.deployments) || repo.deployments[deployment.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deployments[deployment.ID-1] = nil
+
+	return deployment, nil
 }
@@ -1,26 +1,59 @@
 package test
 
 import (
+	"errors"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
-type NotificationConfigRepository struct{}
+// NotificationConfigRepository implements repository.NotificationConfigRepository, keeping
+// notification configs in memory
+type NotificationConfigRepository struct {
+	canQuery bool
+	configs  []*models.NotificationConfig
+}
 
 func NewNotificationConfigRepository(canQuery bool) repository.NotificationConfigRepository {
-	return &NotificationConfigRepository{}
+	return &NotificationConfigRepository{canQuery, []*models.NotificationConfig{}}
 }
 
 func (n *NotificationConfigRepository) CreateNotificationConfig(am *models.NotificationConfig) (*models.NotificationConfig, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	n.configs = append(n.configs, am)
+	am.ID = uint(len(n.configs))
+
+	return am, nil
 }
 
 func (n *NotificationConfigRepository) ReadNotificationConfig(id uint) (*models.NotificationConfig, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if id == 0 || int(id-1) >= len(n.configs) || n.configs[id-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return n.configs[id-1], nil
 }
 
 func (n *NotificationConfigRepository) UpdateNotificationConfig(am *models.NotificationConfig) (*models.NotificationConfig, error) {
-	panic("not implemented") // TODO: Implement
+	if !n.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if am.ID == 0 || int(am.ID-1) >= len(n.configs) || n.configs[am.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	n.configs[am.ID-1] = am
+
+	return am, nil
 }
 
 type JobNotificationConfigRepository struct{}
@@ -40,3 +73,21 @@ func (n *JobNotificationConfigRepository) ReadNotificationConfig(projID, cluster
 func (n *JobNotificationConfigRepository) UpdateNotificationConfig(am *models.JobNotificationConfig) (*models.JobNotificationConfig, error) {
 	panic("not implemented") // TODO: Implement
 }
+
+type MonitorNotificationConfigRepository struct{}
+
+func NewMonitorNotificationConfigRepository(canQuery bool) repository.MonitorNotificationConfigRepository {
+	return &MonitorNotificationConfigRepository{}
+}
+
+func (n *MonitorNotificationConfigRepository) CreateMonitorNotificationConfig(conf *models.MonitorNotificationConfig) (*models.MonitorNotificationConfig, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *MonitorNotificationConfigRepository) ReadMonitorNotificationConfigByProjectID(projectID uint) (*models.MonitorNotificationConfig, error) {
+	panic("not implemented") // TODO: Implement
+}
+
+func (n *MonitorNotificationConfigRepository) UpdateMonitorNotificationConfig(conf *models.MonitorNotificationConfig) (*models.MonitorNotificationConfig, error) {
+	panic("not implemented") // TODO: Implement
+}
@@ -0,0 +1,158 @@
+package test
+
+import (
+	"errors"
+	"time"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+)
+
+// NotificationPipelineRepository implements repository.NotificationPipelineRepository, keeping
+// throttles, channel usages and digest entries in memory
+type NotificationPipelineRepository struct {
+	canQuery      bool
+	throttles     map[string]*models.NotificationThrottle
+	usages        map[string]*models.NotificationChannelUsage
+	digestEntries []*models.NotificationDigestEntry
+}
+
+// NewNotificationPipelineRepository will return errors if canQuery is false
+func NewNotificationPipelineRepository(canQuery bool) repository.NotificationPipelineRepository {
+	return &NotificationPipelineRepository{
+		canQuery,
+		make(map[string]*models.NotificationThrottle),
+		make(map[string]*models.NotificationChannelUsage),
+		[]*models.NotificationDigestEntry{},
+	}
+}
+
+func (repo *NotificationPipelineRepository) ReserveNotification(throttle *models.NotificationThrottle, sentAfter time.Time) (bool, error) {
+	if !repo.canQuery {
+		return false, errors.New("Cannot write database")
+	}
+
+	existing, ok := repo.throttles[throttle.Channel+"/"+throttle.EventKey]
+
+	if ok && !existing.LastSentAt.Before(sentAfter) {
+		existing.Suppressed++
+
+		return false, nil
+	}
+
+	throttle.Suppressed = 0
+
+	if ok {
+		throttle.ID = existing.ID
+	} else {
+		throttle.ID = uint(len(repo.throttles) + 1)
+	}
+
+	repo.throttles[throttle.Channel+"/"+throttle.EventKey] = throttle
+
+	return true, nil
+}
+
+func (repo *NotificationPipelineRepository) ReleaseNotification(throttle *models.NotificationThrottle) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	existing, ok := repo.throttles[throttle.Channel+"/"+throttle.EventKey]
+
+	if ok && existing.LastSentAt.Equal(throttle.LastSentAt) {
+		delete(repo.throttles, throttle.Channel+"/"+throttle.EventKey)
+	}
+
+	return nil
+}
+
+func (repo *NotificationPipelineRepository) DeleteNotificationThrottlesBefore(before time.Time) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	for key, throttle := range repo.throttles {
+		if throttle.LastSentAt.Before(before) {
+			delete(repo.throttles, key)
+		}
+	}
+
+	return nil
+}
+
+func (repo *NotificationPipelineRepository) IncrementNotificationChannelUsage(channel string, windowStart time.Time) (uint, error) {
+	if !repo.canQuery {
+		return 0, errors.New("Cannot write database")
+	}
+
+	usage, ok := repo.usages[channel]
+
+	if !ok {
+		usage = &models.NotificationChannelUsage{
+			Channel:     channel,
+			WindowStart: windowStart,
+		}
+
+		repo.usages[channel] = usage
+	}
+
+	if usage.WindowStart.Before(windowStart) {
+		usage.WindowStart = windowStart
+		usage.Count = 0
+	}
+
+	usage.Count++
+
+	return usage.Count, nil
+}
+
+func (repo *NotificationPipelineRepository) CreateNotificationDigestEntry(entry *models.NotificationDigestEntry) (*models.NotificationDigestEntry, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+
+	repo.digestEntries = append(repo.digestEntries, entry)
+	entry.ID = uint(len(repo.digestEntries))
+
+	return entry, nil
+}
+
+func (repo *NotificationPipelineRepository) ListDueNotificationDigestEntries(hourlyBefore, dailyBefore time.Time) ([]*models.NotificationDigestEntry, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.NotificationDigestEntry, 0)
+
+	for _, entry := range repo.digestEntries {
+		if entry == nil {
+			continue
+		}
+
+		if (entry.Frequency == "hourly" && entry.CreatedAt.Before(hourlyBefore)) ||
+			(entry.Frequency == "daily" && entry.CreatedAt.Before(dailyBefore)) {
+			res = append(res, entry)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *NotificationPipelineRepository) DeleteNotificationDigestEntries(ids []uint) error {
+	if !repo.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	for _, id := range ids {
+		if int(id-1) < len(repo.digestEntries) {
+			repo.digestEntries[id-1] = nil
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// NotificationWebhookRepository implements repository.NotificationWebhookRepository, keeping
+// webhooks and their deliveries in memory
+type NotificationWebhookRepository struct {
+	canQuery   bool
+	webhooks   []*models.NotificationWebhook
+	deliveries []*models.NotificationWebhookDelivery
+}
+
+func NewNotificationWebhookRepository(canQuery bool) repository.NotificationWebhookRepository {
+	return &NotificationWebhookRepository{
+		canQuery,
+		[]*models.NotificationWebhook{},
+		[]*models.NotificationWebhookDelivery{},
+	}
+}
+
+func (repo *NotificationWebhookRepository) CreateNotificationWebhook(webhook *models.NotificationWebhook) (*models.NotificationWebhook, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.webhooks = append(repo.webhooks, webhook)
+	webhook.ID = uint(len(repo.webhooks))
+
+	return webhook, nil
+}
+
+func (repo *NotificationWebhookRepository) ReadNotificationWebhook(projectID, webhookID uint) (*models.NotificationWebhook, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	if webhookID == 0 || int(webhookID-1) >= len(repo.webhooks) || repo.webhooks[webhookID-1] == nil ||
+		repo.webhooks[webhookID-1].ProjectID != projectID {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	return repo.webhooks[webhookID-1], nil
+}
+
+func (repo *NotificationWebhookRepository) ListNotificationWebhooksByProjectID(projectID uint) ([]*models.NotificationWebhook, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.NotificationWebhook, 0)
+
+	for _, webhook := range repo.webhooks {
+		if webhook != nil && webhook.ProjectID == projectID {
+			res = append(res, webhook)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *NotificationWebhookRepository) DeleteNotificationWebhook(webhook *models.NotificationWebhook) (*models.NotificationWebhook, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if webhook.ID == 0 || int(webhook.ID-1) >= len(repo.webhooks) || repo.webhooks[webhook.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.webhooks[webhook.ID-1] = nil
+
+	return webhook, nil
+}
+
+func (repo *NotificationWebhookRepository) CreateNotificationWebhookDelivery(delivery *models.NotificationWebhookDelivery) (*models.NotificationWebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.deliveries = append(repo.deliveries, delivery)
+	delivery.ID = uint(len(repo.deliveries))
+
+	return delivery, nil
+}
+
+func (repo *NotificationWebhookRepository) UpdateNotificationWebhookDelivery(delivery *models.NotificationWebhookDelivery) (*models.NotificationWebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if delivery.ID == 0 || int(delivery.ID-1) >= len(repo.deliveries) || repo.deliveries[delivery.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.deliveries[delivery.ID-1] = delivery
+
+	return delivery, nil
+}
+
+func (repo *NotificationWebhookRepository) ListNotificationWebhookDeliveries(webhookID uint) ([]*models.NotificationWebhookDelivery, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.NotificationWebhookDelivery, 0)
+
+	for _, delivery := range repo.deliveries {
+		if delivery != nil && delivery.NotificationWebhookID == webhookID {
+			res = append(res, delivery)
+		}
+	}
+
+	return res, nil
+}
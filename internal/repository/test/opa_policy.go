@@ -0,0 +1,100 @@
+package test
+
+import (
+	"errors"
+
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// OPAPolicyCollectionRepository implements repository.OPAPolicyCollectionRepository, keeping
+// policy collections in memory
+type OPAPolicyCollectionRepository struct {
+	canQuery    bool
+	collections []*models.OPAPolicyCollection
+}
+
+func NewOPAPolicyCollectionRepository(canQuery bool) repository.OPAPolicyCollectionRepository {
+	return &OPAPolicyCollectionRepository{canQuery, []*models.OPAPolicyCollection{}}
+}
+
+func (repo *OPAPolicyCollectionRepository) CreateOPAPolicyCollection(
+	collection *models.OPAPolicyCollection,
+) (*models.OPAPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.collections = append(repo.collections, collection)
+	collection.ID = uint(len(repo.collections))
+
+	return collection, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) ReadOPAPolicyCollectionByName(
+	projectID uint,
+	name string,
+) (*models.OPAPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, collection := range repo.collections {
+		if collection != nil && collection.ProjectID == projectID && collection.Name == name {
+			return collection, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
+}
+
+func (repo *OPAPolicyCollectionRepository) ListOPAPolicyCollectionsByProjectID(
+	projectID uint,
+) ([]*models.OPAPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.OPAPolicyCollection, 0)
+
+	for _, collection := range repo.collections {
+		if collection != nil && collection.ProjectID == projectID {
+			res = append(res, collection)
+		}
+	}
+
+	return res, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) UpdateOPAPolicyCollection(
+	collection *models.OPAPolicyCollection,
+) (*models.OPAPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if collection.ID == 0 || int(collection.ID-1) >= len(repo.collections) || repo.collections[collection.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.collections[collection.ID-1] = collection
+
+	return collection, nil
+}
+
+func (repo *OPAPolicyCollectionRepository) DeleteOPAPolicyCollection(
+	collection *models.OPAPolicyCollection,
+) (*models.OPAPolicyCollection, error) {
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if collection.ID == 0 || int(collection.ID-1) >= len(repo.collections) || repo.collections[collection.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.collections[collection.ID-1] = nil
+
+	return collection, nil
+}
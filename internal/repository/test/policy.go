@@ -1,40 +1,93 @@
 package test
 
 import (
+	"errors"
+
 	"github.com/porter-dev/porter/internal/models"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
+// PolicyRepository implements repository.PolicyRepository, keeping policies in memory
 type PolicyRepository struct {
 	canQuery bool
+	policies []*models.Policy
 }
 
-// NewPolicyRepository returns a PolicyRepository which uses
-// gorm.DB for querying the database
+// NewPolicyRepository will return errors if canQuery is false
 func NewPolicyRepository(canQuery bool) repository.PolicyRepository {
-	return &PolicyRepository{canQuery}
+	return &PolicyRepository{canQuery, []*models.Policy{}}
 }
 
 func (repo *PolicyRepository) CreatePolicy(a *models.Policy) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	repo.policies = append(repo.policies, a)
+	a.ID = uint(len(repo.policies))
+
+	return a, nil
 }
 
 func (repo *PolicyRepository) ListPoliciesByProjectID(projectID uint) ([]*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*models.Policy, 0)
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID {
+			res = append(res, policy)
+		}
+	}
+
+	return res, nil
 }
 
 func (repo *PolicyRepository) ReadPolicy(projectID uint, uid string) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	for _, policy := range repo.policies {
+		if policy != nil && policy.ProjectID == projectID && policy.UniqueID == uid {
+			return policy, nil
+		}
+	}
+
+	return nil, gorm.ErrRecordNotFound
 }
 
 func (repo *PolicyRepository) UpdatePolicy(
 	policy *models.Policy,
 ) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if policy.ID == 0 || int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = policy
+
+	return policy, nil
 }
 
 func (repo *PolicyRepository) DeletePolicy(
 	policy *models.Policy,
 ) (*models.Policy, error) {
-	panic("unimplemented")
+	if !repo.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	if policy.ID == 0 || int(policy.ID-1) >= len(repo.policies) || repo.policies[policy.ID-1] == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+
+	repo.policies[policy.ID-1] = nil
+
+	return policy, nil
 }
@@ -166,7 +166,6 @@ func (repo *ProjectRepository) ListProjectRoles(projID uint) ([]models.Role, err
 	}
 
 	index := int(projID - 1)
-	repo.projects[index] = nil
 
 	return repo.projects[index].Roles, nil
 }
@@ -32,8 +32,14 @@ type TestRepository struct {
 	gitlabIntegration         repository.GitlabIntegrationRepository
 	gitlabAppOAuthIntegration repository.GitlabAppOAuthIntegrationRepository
 	slackIntegration          repository.SlackIntegrationRepository
+	teamsIntegration          repository.TeamsIntegrationRepository
+	discordIntegration        repository.DiscordIntegrationRepository
 	notificationConfig        repository.NotificationConfigRepository
 	jobNotificationConfig     repository.JobNotificationConfigRepository
+	monitorNotificationConfig repository.MonitorNotificationConfigRepository
+	notificationWebhook       repository.NotificationWebhookRepository
+	notificationPipeline      repository.NotificationPipelineRepository
+	auditEvent                repository.AuditEventRepository
 	buildEvent                repository.BuildEventRepository
 	kubeEvent                 repository.KubeEventRepository
 	projectUsage              repository.ProjectUsageRepository
@@ -47,6 +53,7 @@ type TestRepository struct {
 	tag                       repository.TagRepository
 	stack                     repository.StackRepository
 	monitor                   repository.MonitorTestResultRepository
+	opaPolicyCollection       repository.OPAPolicyCollectionRepository
 	apiContractRevision       repository.APIContractRevisioner
 	awsAssumeRoleChainer      repository.AWSAssumeRoleChainer
 }
@@ -159,6 +166,14 @@ func (t *TestRepository) SlackIntegration() repository.SlackIntegrationRepositor
 	return t.slackIntegration
 }
 
+func (t *TestRepository) TeamsIntegration() repository.TeamsIntegrationRepository {
+	return t.teamsIntegration
+}
+
+func (t *TestRepository) DiscordIntegration() repository.DiscordIntegrationRepository {
+	return t.discordIntegration
+}
+
 func (t *TestRepository) NotificationConfig() repository.NotificationConfigRepository {
 	return t.notificationConfig
 }
@@ -167,6 +182,22 @@ func (t *TestRepository) JobNotificationConfig() repository.JobNotificationConfi
 	return t.jobNotificationConfig
 }
 
+func (t *TestRepository) MonitorNotificationConfig() repository.MonitorNotificationConfigRepository {
+	return t.monitorNotificationConfig
+}
+
+func (t *TestRepository) NotificationWebhook() repository.NotificationWebhookRepository {
+	return t.notificationWebhook
+}
+
+func (t *TestRepository) NotificationPipeline() repository.NotificationPipelineRepository {
+	return t.notificationPipeline
+}
+
+func (t *TestRepository) AuditEvent() repository.AuditEventRepository {
+	return t.auditEvent
+}
+
 func (t *TestRepository) BuildEvent() repository.BuildEventRepository {
 	return t.buildEvent
 }
@@ -219,6 +250,10 @@ func (t *TestRepository) MonitorTestResult() repository.MonitorTestResultReposit
 	return t.monitor
 }
 
+func (t *TestRepository) OPAPolicyCollection() repository.OPAPolicyCollectionRepository {
+	return t.opaPolicyCollection
+}
+
 func (t *TestRepository) APIContractRevisioner() repository.APIContractRevisioner {
 	return t.apiContractRevision
 }
@@ -241,7 +276,7 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitActionConfig:           NewGitActionConfigRepository(canQuery),
 		invite:                    NewInviteRepository(canQuery),
 		release:                   NewReleaseRepository(canQuery),
-		environment:               NewEnvironmentRepository(),
+		environment:               NewEnvironmentRepository(canQuery),
 		authCode:                  NewAuthCodeRepository(canQuery),
 		dnsRecord:                 NewDNSRecordRepository(canQuery),
 		pwResetToken:              NewPWResetTokenRepository(canQuery),
@@ -258,8 +293,14 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		gitlabIntegration:         NewGitlabIntegrationRepository(canQuery),
 		gitlabAppOAuthIntegration: NewGitlabAppOAuthIntegrationRepository(canQuery),
 		slackIntegration:          NewSlackIntegrationRepository(canQuery),
+		teamsIntegration:          NewTeamsIntegrationRepository(canQuery),
+		discordIntegration:        NewDiscordIntegrationRepository(canQuery),
 		notificationConfig:        NewNotificationConfigRepository(canQuery),
 		jobNotificationConfig:     NewJobNotificationConfigRepository(canQuery),
+		monitorNotificationConfig: NewMonitorNotificationConfigRepository(canQuery),
+		notificationWebhook:       NewNotificationWebhookRepository(canQuery),
+		notificationPipeline:      NewNotificationPipelineRepository(canQuery),
+		auditEvent:                NewAuditEventRepository(canQuery),
 		buildEvent:                NewBuildEventRepository(canQuery),
 		kubeEvent:                 NewKubeEventRepository(canQuery),
 		projectUsage:              NewProjectUsageRepository(canQuery),
@@ -273,6 +314,7 @@ func NewRepository(canQuery bool, failingMethods ...string) repository.Repositor
 		tag:                       NewTagRepository(),
 		stack:                     NewStackRepository(),
 		monitor:                   NewMonitorTestResultRepository(canQuery),
+		opaPolicyCollection:       NewOPAPolicyCollectionRepository(canQuery),
 		apiContractRevision:       NewAPIContractRevisioner(),
 		awsAssumeRoleChainer:      NewAWSAssumeRoleChainer(),
 	}
@@ -1,24 +1,61 @@
 package test
 
 import (
+	"errors"
+
 	ints "github.com/porter-dev/porter/internal/models/integrations"
 	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
 )
 
-type SlackIntegrationRepository struct{}
+// SlackIntegrationRepository implements repository.SlackIntegrationRepository, keeping
+// integrations in memory
+type SlackIntegrationRepository struct {
+	canQuery     bool
+	integrations []*ints.SlackIntegration
+}
 
 func NewSlackIntegrationRepository(canQuery bool) repository.SlackIntegrationRepository {
-	return &SlackIntegrationRepository{}
+	return &SlackIntegrationRepository{canQuery, []*ints.SlackIntegration{}}
 }
 
 func (s *SlackIntegrationRepository) CreateSlackIntegration(slackInt *ints.SlackIntegration) (*ints.SlackIntegration, error) {
-	panic("not implemented") // TODO: Implement
+	if !s.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	s.integrations = append(s.integrations, slackInt)
+	slackInt.ID = uint(len(s.integrations))
+
+	return slackInt, nil
 }
 
 func (s *SlackIntegrationRepository) ListSlackIntegrationsByProjectID(projectID uint) ([]*ints.SlackIntegration, error) {
-	panic("not implemented") // TODO: Implement
+	if !s.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.SlackIntegration, 0)
+
+	for _, slackInt := range s.integrations {
+		if slackInt != nil && slackInt.ProjectID == projectID {
+			res = append(res, slackInt)
+		}
+	}
+
+	return res, nil
 }
 
 func (s *SlackIntegrationRepository) DeleteSlackIntegration(integrationID uint) error {
-	panic("not implemented") // TODO: Implement
+	if !s.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if integrationID == 0 || int(integrationID-1) >= len(s.integrations) || s.integrations[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	s.integrations[integrationID-1] = nil
+
+	return nil
 }
@@ -0,0 +1,61 @@
+package test
+
+import (
+	"errors"
+
+	ints "github.com/porter-dev/porter/internal/models/integrations"
+	"github.com/porter-dev/porter/internal/repository"
+	"gorm.io/gorm"
+)
+
+// TeamsIntegrationRepository implements repository.TeamsIntegrationRepository, keeping
+// integrations in memory
+type TeamsIntegrationRepository struct {
+	canQuery     bool
+	integrations []*ints.TeamsIntegration
+}
+
+func NewTeamsIntegrationRepository(canQuery bool) repository.TeamsIntegrationRepository {
+	return &TeamsIntegrationRepository{canQuery, []*ints.TeamsIntegration{}}
+}
+
+func (s *TeamsIntegrationRepository) CreateTeamsIntegration(teamsInt *ints.TeamsIntegration) (*ints.TeamsIntegration, error) {
+	if !s.canQuery {
+		return nil, errors.New("Cannot write database")
+	}
+
+	s.integrations = append(s.integrations, teamsInt)
+	teamsInt.ID = uint(len(s.integrations))
+
+	return teamsInt, nil
+}
+
+func (s *TeamsIntegrationRepository) ListTeamsIntegrationsByProjectID(projectID uint) ([]*ints.TeamsIntegration, error) {
+	if !s.canQuery {
+		return nil, errors.New("Cannot read from database")
+	}
+
+	res := make([]*ints.TeamsIntegration, 0)
+
+	for _, teamsInt := range s.integrations {
+		if teamsInt != nil && teamsInt.ProjectID == projectID {
+			res = append(res, teamsInt)
+		}
+	}
+
+	return res, nil
+}
+
+func (s *TeamsIntegrationRepository) DeleteTeamsIntegration(integrationID uint) error {
+	if !s.canQuery {
+		return errors.New("Cannot write database")
+	}
+
+	if integrationID == 0 || int(integrationID-1) >= len(s.integrations) || s.integrations[integrationID-1] == nil {
+		return gorm.ErrRecordNotFound
+	}
+
+	s.integrations[integrationID-1] = nil
+
+	return nil
+}
@@ -0,0 +1,84 @@
+//go:build ee
+
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/auditexport"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+/*
+
+                            === Audit Export Job ===
+
+   This job sends the new audit events of every project to the audit sinks of the project, such
+   as syslog servers, S3 buckets or HTTPS endpoints. It should be enqueued once per minute.
+
+*/
+
+type auditExport struct {
+	enqueueTime time.Time
+	repo        repository.Repository
+}
+
+// AuditExportOpts holds the options required to run this job
+type AuditExportOpts struct {
+	DBConf *env.DBConf
+}
+
+func NewAuditExport(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *AuditExportOpts,
+) (*auditExport, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	return &auditExport{enqueueTime, repo}, nil
+}
+
+func (a *auditExport) ID() string {
+	return "audit-export"
+}
+
+func (a *auditExport) EnqueueTime() time.Time {
+	return a.enqueueTime
+}
+
+func (a *auditExport) Run() error {
+	log.Println("exporting audit events")
+
+	if err := auditexport.ExportAll(a.repo.AuditEvent(), time.Now()); err != nil {
+		log.Printf("error exporting audit events: %v", err)
+		return err
+	}
+
+	log.Println("audit events exported")
+
+	return nil
+}
+
+func (a *auditExport) SetData([]byte) {}
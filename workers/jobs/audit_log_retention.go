@@ -0,0 +1,133 @@
+//go:build ee
+
+package jobs
+
+import (
+	"errors"
+	"log"
+	"math"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Audit Log Retention Job ===
+
+   This job deletes the audit events which are older than the audit log retention of their
+   project. It should be enqueued once per day.
+
+*/
+
+// maxAuditEventID is the largest ID an audit event can have, as IDs are stored as signed integers
+const maxAuditEventID uint = math.MaxInt64
+
+type auditLogRetention struct {
+	enqueueTime time.Time
+	repo        repository.Repository
+}
+
+// AuditLogRetentionOpts holds the options required to run this job
+type AuditLogRetentionOpts struct {
+	DBConf *env.DBConf
+}
+
+func NewAuditLogRetention(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *AuditLogRetentionOpts,
+) (*auditLogRetention, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	return &auditLogRetention{enqueueTime, repo}, nil
+}
+
+func (a *auditLogRetention) ID() string {
+	return "audit-log-retention"
+}
+
+func (a *auditLogRetention) EnqueueTime() time.Time {
+	return a.enqueueTime
+}
+
+func (a *auditLogRetention) Run() error {
+	log.Println("deleting expired audit events")
+
+	projectIDs, err := a.repo.AuditEvent().ListAuditEventProjectIDs()
+	if err != nil {
+		log.Printf("error listing projects with audit events: %v", err)
+		return err
+	}
+
+	now := time.Now().UTC()
+
+	for _, projectID := range projectIDs {
+		project, err := a.repo.Project().ReadProject(projectID)
+
+		// the audit events of deleted projects are kept for the default retention
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			project = &models.Project{}
+		} else if err != nil {
+			log.Printf("error reading project with ID %d: %v. skipping ...", projectID, err)
+			continue
+		}
+
+		retention := project.GetAuditLogRetention()
+
+		sinks, err := a.repo.AuditEvent().ListAuditSinksByProjectID(projectID)
+		if err != nil {
+			log.Printf("error listing audit sinks of project with ID %d: %v. skipping ...", projectID, err)
+			continue
+		}
+
+		// events which were not delivered to every sink of the project yet are kept for up to
+		// twice the retention, so that events are not lost while a sink is down
+		maxID := maxAuditEventID
+
+		for _, sink := range sinks {
+			if sink.LastEventID < maxID {
+				maxID = sink.LastEventID
+			}
+		}
+
+		err = a.repo.AuditEvent().DeleteAuditEventsBefore(projectID, now.Add(-retention), maxID)
+
+		if err == nil && len(sinks) > 0 {
+			err = a.repo.AuditEvent().DeleteAuditEventsBefore(projectID, now.Add(-2*retention), maxAuditEventID)
+		}
+
+		if err != nil {
+			log.Printf("error deleting audit events of project with ID %d: %v. skipping ...", projectID, err)
+			continue
+		}
+	}
+
+	log.Println("expired audit events deleted")
+
+	return nil
+}
+
+func (a *auditLogRetention) SetData([]byte) {}
@@ -0,0 +1,84 @@
+//go:build ee
+
+package jobs
+
+import (
+	"log"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/notifier/pipeline"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Notification Digest Job ===
+
+   This job sends the hourly and daily digests of low severity notifications, which are batched
+   by the notification pipeline of the server. It should be enqueued at least once per hour.
+
+*/
+
+type notificationDigest struct {
+	enqueueTime time.Time
+	repo        repository.Repository
+}
+
+// NotificationDigestOpts holds the options required to run this job
+type NotificationDigestOpts struct {
+	DBConf *env.DBConf
+}
+
+func NewNotificationDigest(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *NotificationDigestOpts,
+) (*notificationDigest, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	return &notificationDigest{enqueueTime, repo}, nil
+}
+
+func (n *notificationDigest) ID() string {
+	return "notification-digest"
+}
+
+func (n *notificationDigest) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *notificationDigest) Run() error {
+	log.Println("sending notification digests")
+
+	if err := pipeline.FlushDigests(n.repo, time.Now().UTC()); err != nil {
+		log.Printf("error sending notification digests: %v", err)
+		return err
+	}
+
+	log.Println("notification digests sent")
+
+	return nil
+}
+
+func (n *notificationDigest) SetData([]byte) {}
@@ -0,0 +1,215 @@
+//go:build ee
+
+package jobs
+
+import (
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/porter-dev/porter/api/server/shared/config/env"
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/ee/integrations/vault"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/oauth"
+	"github.com/porter-dev/porter/internal/repository"
+	rcreds "github.com/porter-dev/porter/internal/repository/credentials"
+	rgorm "github.com/porter-dev/porter/internal/repository/gorm"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+/*
+
+                         === Preview Deployments Idle Sleeper Job ===
+
+   This job goes through every preview environment with idle sleep enabled in all connected clusters
+   and scales the workloads of the deployments that have not received a push for longer than the
+   idle period of their preview environment to zero. Sleeping deployments are woken up on the next
+   push, or through the API.
+
+*/
+
+type previewDeploymentsIdleSleeper struct {
+	enqueueTime time.Time
+	db          *gorm.DB
+	doConf      *oauth2.Config
+	repo        repository.Repository
+}
+
+// PreviewDeploymentsIdleSleeperOpts holds the options required to run this job
+type PreviewDeploymentsIdleSleeperOpts struct {
+	DBConf         *env.DBConf
+	ServerURL      string
+	DOClientID     string
+	DOClientSecret string
+	DOScopes       []string
+}
+
+func NewPreviewDeploymentsIdleSleeper(
+	db *gorm.DB,
+	enqueueTime time.Time,
+	opts *PreviewDeploymentsIdleSleeperOpts,
+) (*previewDeploymentsIdleSleeper, error) {
+	var credBackend rcreds.CredentialStorage
+
+	if opts.DBConf.VaultAPIKey != "" && opts.DBConf.VaultServerURL != "" && opts.DBConf.VaultPrefix != "" {
+		credBackend = vault.NewClient(
+			opts.DBConf.VaultServerURL,
+			opts.DBConf.VaultAPIKey,
+			opts.DBConf.VaultPrefix,
+		)
+	}
+
+	doConf := oauth.NewDigitalOceanClient(&oauth.Config{
+		ClientID:     opts.DOClientID,
+		ClientSecret: opts.DOClientSecret,
+		Scopes:       opts.DOScopes,
+		BaseURL:      opts.ServerURL,
+	})
+
+	var key [32]byte
+
+	for i, b := range []byte(opts.DBConf.EncryptionKey) {
+		key[i] = b
+	}
+
+	repo := rgorm.NewRepository(db, &key, credBackend)
+
+	return &previewDeploymentsIdleSleeper{enqueueTime, db, doConf, repo}, nil
+}
+
+func (n *previewDeploymentsIdleSleeper) ID() string {
+	return "preview-deployments-idle-sleeper"
+}
+
+func (n *previewDeploymentsIdleSleeper) EnqueueTime() time.Time {
+	return n.enqueueTime
+}
+
+func (n *previewDeploymentsIdleSleeper) Run() error {
+	var count int64
+
+	if err := n.db.Model(&models.Cluster{}).Count(&count).Error; err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+
+	log.Println("starting sleep of idle preview deployments")
+
+	for i := 0; i < (int(count)/stepSize)+1; i++ {
+		var clusters []*models.Cluster
+
+		if err := n.db.Order("id asc").Offset(i * stepSize).Limit(stepSize).Find(&clusters).
+			Error; err != nil {
+			return err
+		}
+
+		for _, cluster := range clusters {
+			if !cluster.PreviewEnvsEnabled {
+				continue
+			}
+
+			envs, err := n.repo.Environment().ListEnvironments(cluster.ProjectID, cluster.ID)
+			if err != nil {
+				log.Printf("error listing environments for cluster %s: %v", cluster.Name, err)
+				continue
+			}
+
+			for _, env := range envs {
+				if env.IdleSleepHours == 0 {
+					continue
+				}
+
+				wg.Add(1)
+
+				go func(env *models.Environment, cluster *models.Cluster) {
+					defer wg.Done()
+
+					getAgent := func() (*kubernetes.Agent, error) {
+						return kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
+							Cluster:                   cluster,
+							Repo:                      n.repo,
+							DigitalOceanOAuth:         n.doConf,
+							AllowInClusterConnections: false,
+							Timeout:                   10 * time.Second,
+						})
+					}
+
+					err := sleepIdleDeployments(n.repo.Environment(), env, getAgent, time.Now())
+					if err != nil {
+						log.Printf("error putting idle deployments of %s/%s to sleep: %v", env.GitRepoOwner,
+							env.GitRepoName, err)
+					}
+				}(env, cluster)
+			}
+
+			wg.Wait()
+		}
+	}
+
+	log.Println("finished sleep of idle preview deployments")
+
+	return nil
+}
+
+func (n *previewDeploymentsIdleSleeper) SetData([]byte) {}
+
+// sleepIdleDeployments puts every deployment of the environment which has not received a push for
+// longer than the idle period of the environment to sleep. The agent is only created once a
+// deployment has to be put to sleep.
+func sleepIdleDeployments(
+	repo repository.EnvironmentRepository,
+	env *models.Environment,
+	getAgent func() (*kubernetes.Agent, error),
+	now time.Time,
+) error {
+	idleDuration := time.Duration(env.IdleSleepHours) * time.Hour
+
+	depls, err := repo.ListDeployments(env.ID)
+	if err != nil {
+		return fmt.Errorf("error listing deployments: %w", err)
+	}
+
+	var k8sAgent *kubernetes.Agent
+
+	for _, depl := range depls {
+		if depl.IsSleeping || depl.Namespace == "" || depl.Status == types.DeploymentStatusInactive ||
+			depl.LastActiveAt().Add(idleDuration).After(now) {
+			continue
+		}
+
+		if k8sAgent == nil {
+			k8sAgent, err = getAgent()
+			if err != nil {
+				return fmt.Errorf("error getting k8s agent: %w", err)
+			}
+		}
+
+		log.Printf("putting deployment '%s' to sleep after %s without pushes", depl.PRName, idleDuration)
+
+		err := k8sAgent.SleepNamespace(depl.Namespace)
+		if err != nil {
+			log.Printf("error putting deployment '%s' to sleep: %v. skipping ...", depl.PRName, err)
+			continue
+		}
+
+		// putting the deployment to sleep updates it, so the last push time must be
+		// set for the TTL of older deployments to keep counting from their last update
+		if depl.LastPushedAt.IsZero() {
+			depl.LastPushedAt = depl.UpdatedAt
+		}
+
+		depl.IsSleeping = true
+
+		_, err = repo.UpdateDeployment(depl)
+		if err != nil {
+			log.Printf("error updating deployment '%s': %v", depl.PRName, err)
+		}
+	}
+
+	return nil
+}
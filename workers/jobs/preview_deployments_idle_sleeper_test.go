@@ -0,0 +1,182 @@
+//go:build ee
+
+package jobs
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/porter-dev/porter/api/types"
+	"github.com/porter-dev/porter/internal/kubernetes"
+	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/repository/test"
+	"gorm.io/gorm"
+
+	appsv1 "k8s.io/api/apps/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newSleeperDeployment(namespace string) *appsv1.Deployment {
+	replicas := int32(2)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "web",
+			Namespace: namespace,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+		},
+	}
+}
+
+func TestSleepIdleDeployments(t *testing.T) {
+	now := time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	repo := test.NewEnvironmentRepository(true)
+
+	env, err := repo.CreateEnvironment(&models.Environment{
+		ProjectID:      1,
+		ClusterID:      1,
+		IdleSleepHours: 24,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	depls := []*models.Deployment{
+		{
+			// idle for longer than the idle period
+			EnvironmentID: env.ID,
+			Namespace:     "pr-1",
+			Status:        types.DeploymentStatusCreated,
+			PRName:        "idle",
+			LastPushedAt:  now.Add(-25 * time.Hour),
+		},
+		{
+			// pushed within the idle period
+			EnvironmentID: env.ID,
+			Namespace:     "pr-2",
+			Status:        types.DeploymentStatusCreated,
+			PRName:        "active",
+			LastPushedAt:  now.Add(-23 * time.Hour),
+		},
+		{
+			// never pushed, so the last update is used
+			EnvironmentID: env.ID,
+			Namespace:     "pr-3",
+			Status:        types.DeploymentStatusCreated,
+			PRName:        "never-pushed",
+			Model: gorm.Model{
+				UpdatedAt: now.Add(-48 * time.Hour),
+			},
+		},
+		{
+			EnvironmentID: env.ID,
+			Namespace:     "pr-4",
+			Status:        types.DeploymentStatusInactive,
+			PRName:        "inactive",
+			LastPushedAt:  now.Add(-48 * time.Hour),
+		},
+		{
+			EnvironmentID: env.ID,
+			Namespace:     "pr-5",
+			Status:        types.DeploymentStatusCreated,
+			PRName:        "already-sleeping",
+			LastPushedAt:  now.Add(-48 * time.Hour),
+			IsSleeping:    true,
+		},
+	}
+
+	for _, depl := range depls {
+		if _, err := repo.CreateDeployment(depl); err != nil {
+			t.Fatalf("%v", err)
+		}
+	}
+
+	k8sAgent := kubernetes.GetAgentTesting(
+		newSleeperDeployment("pr-1"),
+		newSleeperDeployment("pr-2"),
+		newSleeperDeployment("pr-3"),
+		newSleeperDeployment("pr-4"),
+		newSleeperDeployment("pr-5"),
+	)
+
+	err = sleepIdleDeployments(repo, env, func() (*kubernetes.Agent, error) {
+		return k8sAgent, nil
+	}, now)
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	expSleeping := map[string]bool{
+		"idle":             true,
+		"active":           false,
+		"never-pushed":     true,
+		"inactive":         false,
+		"already-sleeping": true,
+	}
+
+	// deployments which were already sleeping are not scaled down again
+	expScaledDown := map[string]bool{
+		"pr-1": true,
+		"pr-3": true,
+	}
+
+	for _, depl := range depls {
+		if depl.IsSleeping != expSleeping[depl.PRName] {
+			t.Errorf("deployment %s: expected sleeping to be %t", depl.PRName, expSleeping[depl.PRName])
+		}
+
+		k8sDepl, err := k8sAgent.Clientset.AppsV1().Deployments(depl.Namespace).Get(
+			context.Background(), "web", metav1.GetOptions{},
+		)
+		if err != nil {
+			t.Fatalf("%v", err)
+		}
+
+		if scaledDown := *k8sDepl.Spec.Replicas == 0; scaledDown != expScaledDown[depl.Namespace] {
+			t.Errorf("namespace %s: expected scaled down to be %t", depl.Namespace, expScaledDown[depl.Namespace])
+		}
+	}
+
+	// the TTL of deployments which were never pushed keeps counting from their last update
+	if !depls[2].LastPushedAt.Equal(now.Add(-48 * time.Hour)) {
+		t.Errorf("expected last pushed time to be set to the last update, got %s", depls[2].LastPushedAt)
+	}
+}
+
+func TestSleepIdleDeploymentsNoAgentWhenActive(t *testing.T) {
+	now := time.Now()
+
+	repo := test.NewEnvironmentRepository(true)
+
+	env, err := repo.CreateEnvironment(&models.Environment{
+		ProjectID:      1,
+		ClusterID:      1,
+		IdleSleepHours: 24,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	_, err = repo.CreateDeployment(&models.Deployment{
+		EnvironmentID: env.ID,
+		Namespace:     "pr-1",
+		Status:        types.DeploymentStatusCreated,
+		LastPushedAt:  now,
+	})
+	if err != nil {
+		t.Fatalf("%v", err)
+	}
+
+	// the cluster is not contacted when no deployment has to be put to sleep
+	err = sleepIdleDeployments(repo, env, func() (*kubernetes.Agent, error) {
+		return nil, fmt.Errorf("agent should not be created")
+	}, now)
+	if err != nil {
+		t.Errorf("%v", err)
+	}
+}
@@ -26,6 +26,7 @@ import (
 
    This job goes through every active preview environment in all connected clusters and deletes the
    deployments that have exceeded their TTL, corresponding to their respective preview environment.
+   The TTL set in the settings of a preview environment takes precedence over the global TTL.
 
 */
 
@@ -93,20 +94,17 @@ func (n *previewDeploymentsTTLDeleter) EnqueueTime() time.Time {
 }
 
 func (n *previewDeploymentsTTLDeleter) Run() error {
-	if n.previewDeploymentsTTL == "" {
-		log.Println("no TTL set for preview deployments, skipping job altogether")
-		return nil
-	}
-
-	ttlDuration, err := time.ParseDuration(n.previewDeploymentsTTL)
-	if err != nil {
-		log.Printf("error parsing preview deployments TTL: %v. skipping job altogether", err)
-		return nil
-	}
+	var globalTTL time.Duration
 
-	if ttlDuration.Hours() < 24 || ttlDuration.Hours() > 720 {
-		log.Printf("preview deployments TTL must be between 24 (1 day) and 720 hours (30 days). skipping job altogether")
-		return nil
+	if n.previewDeploymentsTTL == "" {
+		log.Println("no global TTL set for preview deployments, only deleting deployments of environments with a TTL")
+	} else if ttlDuration, err := time.ParseDuration(n.previewDeploymentsTTL); err != nil {
+		log.Printf("error parsing preview deployments TTL: %v. only deleting deployments of environments with a TTL", err)
+	} else if ttlDuration.Hours() < 24 || ttlDuration.Hours() > 720 {
+		log.Printf("preview deployments TTL must be between 24 (1 day) and 720 hours (30 days). " +
+			"only deleting deployments of environments with a TTL")
+	} else {
+		globalTTL = ttlDuration
 	}
 
 	var count int64
@@ -141,9 +139,19 @@ func (n *previewDeploymentsTTLDeleter) Run() error {
 			log.Printf("found %d environments for cluster %s", len(envs), cluster.Name)
 
 			for _, env := range envs {
+				ttlDuration := globalTTL
+
+				if env.DeploymentTTLHours > 0 {
+					ttlDuration = time.Duration(env.DeploymentTTLHours) * time.Hour
+				}
+
+				if ttlDuration == 0 {
+					continue
+				}
+
 				wg.Add(1)
 
-				go func(env *models.Environment, cluster *models.Cluster) {
+				go func(env *models.Environment, cluster *models.Cluster, ttlDuration time.Duration) {
 					defer wg.Done()
 
 					depls, err := n.repo.Environment().ListDeployments(env.ID)
@@ -155,7 +163,7 @@ func (n *previewDeploymentsTTLDeleter) Run() error {
 					log.Printf("found %d deployments for %s/%s", len(depls), env.GitRepoOwner, env.GitRepoName)
 
 					log.Printf("deleting preview deployments based on TTL %s for %s/%s",
-						n.previewDeploymentsTTL, env.GitRepoOwner, env.GitRepoName)
+						ttlDuration, env.GitRepoOwner, env.GitRepoName)
 
 					k8sAgent, err := kubernetes.GetAgentOutOfClusterConfig(&kubernetes.OutOfClusterConfig{
 						Cluster:                   cluster,
@@ -171,7 +179,7 @@ func (n *previewDeploymentsTTLDeleter) Run() error {
 
 					for _, depl := range depls {
 						// delete the deployment if it has been inactive for longer than the set TTL
-						if depl.UpdatedAt.Add(ttlDuration).Before(time.Now()) {
+						if depl.LastActiveAt().Add(ttlDuration).Before(time.Now()) {
 							if depl.Namespace != "" {
 								log.Printf("deleting namespace for deployment '%s'", depl.PRName)
 
@@ -199,7 +207,7 @@ func (n *previewDeploymentsTTLDeleter) Run() error {
 							}
 						}
 					}
-				}(env, cluster)
+				}(env, cluster, ttlDuration)
 			}
 
 			wg.Wait()
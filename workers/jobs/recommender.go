@@ -4,7 +4,8 @@
 
                             === Recommender Job ===
 
-This job checks to see if a cluster matches policies set by the OPA config file.
+This job checks to see if a cluster matches policies set by the OPA config file, along with
+the custom policies uploaded by the project of the cluster.
 
 */
 
@@ -25,6 +26,9 @@ import (
 	"github.com/porter-dev/porter/internal/encryption"
 	"github.com/porter-dev/porter/internal/kubernetes"
 	"github.com/porter-dev/porter/internal/models"
+	"github.com/porter-dev/porter/internal/notifier"
+	"github.com/porter-dev/porter/internal/notifier/sendgrid"
+	"github.com/porter-dev/porter/internal/notifier/slack"
 	"github.com/porter-dev/porter/internal/oauth"
 	"github.com/porter-dev/porter/internal/opa"
 	"github.com/porter-dev/porter/internal/repository"
@@ -43,6 +47,13 @@ type recommender struct {
 	categories           []string
 	policies             *opa.KubernetesPolicies
 	runRecommenderID     string
+	serverURL            string
+	sendgridOpts         *sendgridOpts
+}
+
+// sendgridOpts are used to email project members when a monitor changes status
+type sendgridOpts struct {
+	apiKey, senderEmail, templateID string
 }
 
 // RecommenderOpts holds the options required to run this job
@@ -53,6 +64,10 @@ type RecommenderOpts struct {
 	DOScopes       []string
 	ServerURL      string
 
+	SendgridAPIKey                  string
+	SendgridSenderEmail             string
+	SendgridMonitorStatusTemplateID string
+
 	LegacyProjectIDs []uint
 
 	Input map[string]interface{}
@@ -127,8 +142,15 @@ func NewRecommender(
 		return nil, err
 	}
 
+	var sgOpts *sendgridOpts
+
+	if opts.SendgridAPIKey != "" && opts.SendgridSenderEmail != "" && opts.SendgridMonitorStatusTemplateID != "" {
+		sgOpts = &sendgridOpts{opts.SendgridAPIKey, opts.SendgridSenderEmail, opts.SendgridMonitorStatusTemplateID}
+	}
+
 	return &recommender{
 		enqueueTime, db, repo, doConf, clusterIDs, parsedInput.Categories, opaPolicies, string(recommenderID),
+		opts.ServerURL, sgOpts,
 	}, nil
 }
 
@@ -185,6 +207,10 @@ func (n *recommender) EnqueueTime() time.Time {
 }
 
 func (n *recommender) Run() error {
+	// the built-in policies along with the custom policies of each project
+	projectPolicies := make(map[uint]*opa.KubernetesPolicies)
+	projectNotifiers := make(map[uint]notifier.MonitorNotifier)
+
 	for _, ids := range n.clusterAndProjectIDs {
 		fmt.Println(ids.projectID, ids.clusterID)
 
@@ -217,7 +243,14 @@ func (n *recommender) Run() error {
 			continue
 		}
 
-		runner := opa.NewRunner(n.policies, cluster, k8sAgent, dynamicClient)
+		policies, exists := projectPolicies[ids.projectID]
+
+		if !exists {
+			policies = n.getProjectPolicies(ids.projectID)
+			projectPolicies[ids.projectID] = policies
+		}
+
+		runner := opa.NewRunner(policies, cluster, k8sAgent, dynamicClient)
 
 		queryResults, err := runner.GetRecommendations(n.categories)
 		if err != nil {
@@ -225,11 +258,21 @@ func (n *recommender) Run() error {
 			continue
 		}
 
+		monitorNotifier, exists := projectNotifiers[ids.projectID]
+
+		if !exists {
+			monitorNotifier = n.getMonitorNotifier(ids.projectID)
+			projectNotifiers[ids.projectID] = monitorNotifier
+		}
+
 		for _, queryRes := range queryResults {
 			fmt.Println(queryRes.ObjectID, queryRes.Allow, queryRes.PolicyTitle, queryRes.PolicyMessage)
 
 			monitor, err := n.repo.MonitorTestResult().ReadMonitorTestResult(ids.projectID, ids.clusterID, queryRes.ObjectID)
 
+			// a new monitor is treated as if it was previously passing, so that only failures are notified
+			prevRunResult := string(types.MonitorTestStatusSuccess)
+
 			if err != nil {
 				if errors.Is(err, gorm.ErrRecordNotFound) {
 					monitor, err = n.repo.MonitorTestResult().CreateMonitorTestResult(n.getMonitorTestResultFromQueryResult(cluster, queryRes, n.runRecommenderID))
@@ -237,12 +280,25 @@ func (n *recommender) Run() error {
 					continue
 				}
 			} else {
+				prevRunResult = monitor.LastRunResult
 				monitor, err = n.repo.MonitorTestResult().UpdateMonitorTestResult(mergeMonitorTestResultFromQueryResult(monitor, queryRes, n.runRecommenderID))
 			}
 
 			if err != nil {
 				continue
 			}
+
+			if monitor.LastRunResult != prevRunResult && !cluster.NotificationsDisabled {
+				err = monitorNotifier.NotifyStatusChange(&notifier.MonitorNotifyOpts{
+					ClusterName: cluster.Name,
+					Result:      monitor.ToMonitorTestResultType(),
+					URL:         fmt.Sprintf("%s/cluster-dashboard?project_id=%d", n.serverURL, ids.projectID),
+				})
+
+				if err != nil {
+					log.Printf("error notifying status change of %s for cluster ID %d: %v", monitor.ObjectID, ids.clusterID, err)
+				}
+			}
 		}
 
 		err = n.repo.MonitorTestResult().ArchiveMonitorTestResults(ids.projectID, ids.clusterID, n.runRecommenderID)
@@ -263,6 +319,102 @@ func (n *recommender) Run() error {
 	return nil
 }
 
+// getProjectPolicies adds the custom policy collections of a project to the built-in policies. If the
+// custom policies cannot be read, only the built-in policies are used.
+func (n *recommender) getProjectPolicies(projectID uint) *opa.KubernetesPolicies {
+	collections, err := n.repo.OPAPolicyCollection().ListOPAPolicyCollectionsByProjectID(projectID)
+	if err != nil {
+		log.Printf("error listing custom policies for project ID %d: %v. using built-in policies ...", projectID, err)
+		return n.policies
+	}
+
+	if len(collections) == 0 {
+		return n.policies
+	}
+
+	collectionTypes := make([]*types.OPAPolicyCollection, 0)
+
+	for _, collection := range collections {
+		collectionType, err := collection.ToOPAPolicyCollectionType()
+		if err != nil {
+			log.Printf("error reading custom policy collection %s for project ID %d: %v. skipping collection ...",
+				collection.Name, projectID, err)
+			continue
+		}
+
+		collectionTypes = append(collectionTypes, collectionType)
+	}
+
+	policies, errs := n.policies.WithCustomPolicies(collectionTypes)
+
+	for _, err := range errs {
+		log.Printf("error loading custom policies for project ID %d: %v. skipping collection ...", projectID, err)
+	}
+
+	return policies
+}
+
+// getMonitorNotifier returns the notifier for monitor status changes in a project, which is a no-op if
+// the project has not enabled monitor notifications
+func (n *recommender) getMonitorNotifier(projectID uint) notifier.MonitorNotifier {
+	conf, err := n.repo.MonitorNotificationConfig().ReadMonitorNotificationConfigByProjectID(projectID)
+	if err != nil {
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			log.Printf("error reading monitor notification config for project ID %d: %v", projectID, err)
+		}
+
+		return notifier.NewMultiMonitorNotifier(nil)
+	}
+
+	notifConf := conf.ToMonitorNotificationConfigType()
+
+	if !notifConf.Enabled {
+		return notifier.NewMultiMonitorNotifier(notifConf)
+	}
+
+	notifiers := make([]notifier.MonitorNotifier, 0)
+
+	slackInts, err := n.repo.SlackIntegration().ListSlackIntegrationsByProjectID(projectID)
+	if err != nil {
+		log.Printf("error listing slack integrations for project ID %d: %v", projectID, err)
+	} else if len(slackInts) > 0 {
+		notifiers = append(notifiers, slack.NewMonitorNotifier(slackInts...))
+	}
+
+	if notifConf.Email && n.sendgridOpts != nil {
+		users, err := getProjectUsers(n.repo, projectID)
+		if err != nil {
+			log.Printf("error listing users for project ID %d: %v", projectID, err)
+		} else {
+			notifiers = append(notifiers, sendgrid.NewMonitorNotifier(&sendgrid.MonitorNotifierOpts{
+				SharedOpts: &sendgrid.SharedOpts{
+					APIKey:      n.sendgridOpts.apiKey,
+					SenderEmail: n.sendgridOpts.senderEmail,
+				},
+				MonitorStatusTemplateID: n.sendgridOpts.templateID,
+				Users:                   users,
+			}))
+		}
+	}
+
+	return notifier.NewMultiMonitorNotifier(notifConf, notifiers...)
+}
+
+func getProjectUsers(repo repository.Repository, projectID uint) ([]*models.User, error) {
+	roles, err := repo.Project().ListProjectRoles(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	idArr := make([]uint, 0)
+
+	for _, role := range roles {
+		idArr = append(idArr, role.UserID)
+	}
+
+	return repo.User().ListUsersByIDs(idArr)
+}
+
 func (n *recommender) getMonitorTestResultFromQueryResult(cluster *models.Cluster, queryRes *opa.OPARecommenderQueryResult, recommenderID string) *models.MonitorTestResult {
 	runResult := types.MonitorTestStatusSuccess
 
@@ -299,7 +451,7 @@ func mergeMonitorTestResultFromQueryResult(monitor *models.MonitorTestResult, qu
 
 	currTime := time.Now()
 
-	if isStatusChange := monitor.LastRunResult == string(runResult); isStatusChange {
+	if isStatusChange := monitor.LastRunResult != string(runResult); isStatusChange {
 		monitor.LastStatusChange = &currTime
 	}
 
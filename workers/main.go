@@ -67,8 +67,11 @@ type EnvConf struct {
 	RevisionsCount     int    `env:"REVISIONS_COUNT,default=20"`
 
 	// "recommender"
-	OPAConfigFileDir string `env:"OPA_CONFIG_FILE_DIR,default=./internal/opa"`
-	LegacyProjectIDs []uint `env:"LEGACY_PROJECT_IDS"`
+	OPAConfigFileDir                string `env:"OPA_CONFIG_FILE_DIR,default=./internal/opa"`
+	LegacyProjectIDs                []uint `env:"LEGACY_PROJECT_IDS"`
+	SendgridAPIKey                  string `env:"SENDGRID_API_KEY"`
+	SendgridSenderEmail             string `env:"SENDGRID_SENDER_EMAIL"`
+	SendgridMonitorStatusTemplateID string `env:"SENDGRID_MONITOR_STATUS_TEMPLATE_ID"`
 
 	// "preview-deployments-ttl-deleter"
 	PreviewDeploymentsTTL string `env:"PREVIEW_DEPLOYMENTS_TTL"`
@@ -236,6 +239,10 @@ func getJob(id string, input map[string]interface{}) worker.Job {
 			ServerURL:        envDecoder.ServerURL,
 			Input:            input,
 			LegacyProjectIDs: envDecoder.LegacyProjectIDs,
+
+			SendgridAPIKey:                  envDecoder.SendgridAPIKey,
+			SendgridSenderEmail:             envDecoder.SendgridSenderEmail,
+			SendgridMonitorStatusTemplateID: envDecoder.SendgridMonitorStatusTemplateID,
 		}, opaPolicies)
 		if err != nil {
 			log.Printf("error creating job with ID: recommender. Error: %v", err)
@@ -257,6 +264,50 @@ func getJob(id string, input map[string]interface{}) worker.Job {
 			return nil
 		}
 
+		return newJob
+	} else if id == "notification-digest" {
+		newJob, err := jobs.NewNotificationDigest(dbConn, time.Now().UTC(), &jobs.NotificationDigestOpts{
+			DBConf: &envDecoder.DBConf,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: notification-digest. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "audit-export" {
+		newJob, err := jobs.NewAuditExport(dbConn, time.Now().UTC(), &jobs.AuditExportOpts{
+			DBConf: &envDecoder.DBConf,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: audit-export. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "audit-log-retention" {
+		newJob, err := jobs.NewAuditLogRetention(dbConn, time.Now().UTC(), &jobs.AuditLogRetentionOpts{
+			DBConf: &envDecoder.DBConf,
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: audit-log-retention. Error: %v", err)
+			return nil
+		}
+
+		return newJob
+	} else if id == "preview-deployments-idle-sleeper" {
+		newJob, err := jobs.NewPreviewDeploymentsIdleSleeper(dbConn, time.Now().UTC(), &jobs.PreviewDeploymentsIdleSleeperOpts{
+			DBConf:         &envDecoder.DBConf,
+			ServerURL:      envDecoder.ServerURL,
+			DOClientID:     envDecoder.DOClientID,
+			DOClientSecret: envDecoder.DOClientSecret,
+			DOScopes:       []string{"read", "write"},
+		})
+		if err != nil {
+			log.Printf("error creating job with ID: preview-deployments-idle-sleeper. Error: %v", err)
+			return nil
+		}
+
 		return newJob
 	}
 